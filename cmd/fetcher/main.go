@@ -34,9 +34,11 @@ import (
 	"golang.org/x/oauth2"
 	"knative.dev/operator/pkg/blob"
 	"knative.dev/operator/pkg/github"
+	"knative.dev/operator/pkg/oci"
 	"knative.dev/operator/pkg/packages"
 	"knative.dev/operator/pkg/reconciler/common"
 	"knative.dev/operator/pkg/reconciler/knativeserving/security"
+	"knative.dev/operator/pkg/registry"
 )
 
 var (
@@ -66,6 +68,10 @@ func main() {
 	ctx := context.Background()
 	client := getClient(ctx)
 	ghClient := ghclient.NewClient(client)
+	// The OCI client authenticates with the token services of the registries, when listing and
+	// downloading the OCI artifacts. The assets of the other sources are downloaded as they are.
+	ociClient := oci.NewClient()
+	clients := packages.Clients{HTTP: http.DefaultClient, OCI: ociClient.HTTP}
 
 	// Clear the destination so that no existing files remain
 	if err := os.RemoveAll(*outDir); err != nil && !os.IsNotExist(err) {
@@ -87,20 +93,20 @@ func main() {
 			}
 		}
 
-		if err := ensureRepo(ctx, repos, ghClient, v.Primary); err != nil {
+		if err := ensureRepo(ctx, repos, ghClient, ociClient, v.Primary); err != nil {
 			log.Printf("Unable to fetch %s: %v", v.Primary, err)
 			os.Exit(2)
 		}
 
 		for _, s := range v.Additional {
-			if err := ensureRepo(ctx, repos, ghClient, s); err != nil {
+			if err := ensureRepo(ctx, repos, ghClient, ociClient, s); err != nil {
 				log.Printf("Unable to fetch %s: %v", s, err)
 				os.Exit(2)
 			}
 		}
 
 		for _, release := range packages.LastN(versionCurrentPackage, *maxVersions, repos[v.Primary.String()]) {
-			if err := packages.HandleRelease(*outDir, http.DefaultClient, *v, release, repos); err != nil {
+			if err := packages.HandleRelease(*outDir, clients, *v, release, repos); err != nil {
 				log.Printf("Unable to fetch %s: %v", release, err)
 			}
 			log.Printf("Wrote %s ==> %s", v.String(), release.String())
@@ -116,7 +122,7 @@ func getClient(ctx context.Context) *http.Client {
 	return oauth2.NewClient(ctx, staticToken)
 }
 
-func ensureRepo(ctx context.Context, known map[string][]packages.Release, client *ghclient.Client, src packages.Source) error {
+func ensureRepo(ctx context.Context, known map[string][]packages.Release, client *ghclient.Client, ociClient *oci.Client, src packages.Source) error {
 	if known[src.String()] != nil {
 		return nil
 	}
@@ -140,5 +146,13 @@ func ensureRepo(ctx context.Context, known map[string][]packages.Release, client
 		known[src.String()] = releases
 		return nil
 	}
-	return errors.New("must specify one of S3 or GitHub")
+	if src.OCI != (packages.OCISource{}) {
+		releases, err := registry.GetReleases(ctx, ociClient, src.OCI)
+		if err != nil {
+			return err
+		}
+		known[src.String()] = releases
+		return nil
+	}
+	return errors.New("must specify one of S3, GitHub or OCI")
 }
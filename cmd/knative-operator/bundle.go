@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/bundle"
+)
+
+const bundleUsage = `Usage: knative-operator bundle <export|import> [flags]
+
+Commands:
+  export  write the manifests and the list of images of a component to a tarball
+  import  rewrite the registry overrides of a component to pull the images of a bundle from a mirror
+`
+
+// runBundle exports and imports the bundles used to install the components at disconnected sites.
+func runBundle(args []string) error {
+	if len(args) < 1 {
+		return errors.New(strings.TrimSpace(bundleUsage))
+	}
+	switch args[0] {
+	case "export":
+		return runBundleExport(args[1:])
+	case "import":
+		return runBundleImport(args[1:])
+	default:
+		return fmt.Errorf("unknown bundle command %q\n\n%s", args[0], bundleUsage)
+	}
+}
+
+func runBundleExport(args []string) error {
+	fs := flag.NewFlagSet("bundle export", flag.ExitOnError)
+	file := fs.String("f", "", "the file containing the KnativeServing or KnativeEventing")
+	kodata := fs.String("kodata", "", "the directory of the release manifests, defaults to $KO_DATA_PATH")
+	out := fs.String("o", "", "the tarball to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *out == "" {
+		return errors.New("-f and -o are required")
+	}
+	if err := setKoDataPath(*kodata); err != nil {
+		return err
+	}
+
+	instance, err := readComponent(*file)
+	if err != nil {
+		return err
+	}
+	// The manifest is rendered as for a new installation, to collect the images it references.
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(fake.New()))
+	if err != nil {
+		return err
+	}
+	if err := render(context.Background(), newRenderer(instance, manifest), &manifest, instance); err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	index, err := bundle.Export(context.Background(), f, instance, manifest)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return fmt.Errorf("failed to export the bundle: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d manifests and %d images of version %s to %s\n",
+		len(index.Manifests), len(index.Images), index.Version, *out)
+	return nil
+}
+
+func runBundleImport(args []string) error {
+	fs := flag.NewFlagSet("bundle import", flag.ExitOnError)
+	bundlePath := fs.String("bundle", "", "the tarball written by bundle export")
+	file := fs.String("f", "", "the file containing the KnativeServing or KnativeEventing to rewrite")
+	mirror := fs.String("mirror", "", "the private registry the images are copied to, e.g. registry.example.com/knative")
+	out := fs.String("o", "", "write the rewritten resource to this file, instead of stdout")
+	extractDir := fs.String("extract-dir", "", "extract the manifests of the bundle into this directory")
+	imagesOut := fs.String("images-out", "", "write the images to copy as \"<source> <destination>\" lines to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *bundlePath == "" || *file == "" || *mirror == "" {
+		return errors.New("-bundle, -f and -mirror are required")
+	}
+
+	f, err := os.Open(*bundlePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	index, err := bundle.Read(f, *extractDir)
+	if err != nil {
+		return err
+	}
+	instance, err := readComponent(*file)
+	if err != nil {
+		return err
+	}
+	copies, err := bundle.Import(index, instance, *mirror)
+	if err != nil {
+		return err
+	}
+
+	if *imagesOut != "" {
+		var b strings.Builder
+		for _, c := range copies {
+			fmt.Fprintf(&b, "%s %s\n", c.Source, c.Destination)
+		}
+		if err := os.WriteFile(*imagesOut, []byte(b.String()), 0644); err != nil {
+			return err
+		}
+	}
+	data, err := yaml.Marshal(instance)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Rewrote the overrides of %d images of version %s\n", len(copies), index.Version)
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0644)
+}
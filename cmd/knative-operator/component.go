@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	"knative.dev/operator/pkg/reconciler/knativeeventing"
+	"knative.dev/operator/pkg/reconciler/knativeserving"
+)
+
+// renderer is implemented by the renderers of all the components.
+type renderer interface {
+	Stages(state *common.ReconcileState) common.Stages
+	Installed(ctx context.Context, instance base.KComponent) (*mf.Manifest, error)
+}
+
+// readComponent reads a KnativeServing or a KnativeEventing from the given file.
+func readComponent(path string) (base.KComponent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var instance base.KComponent
+	switch meta.Kind {
+	case "KnativeServing":
+		instance = &v1beta1.KnativeServing{}
+	case "KnativeEventing":
+		instance = &v1beta1.KnativeEventing{}
+	default:
+		return nil, fmt.Errorf("%s contains a %q, want a KnativeServing or a KnativeEventing", path, meta.Kind)
+	}
+	if err := yaml.UnmarshalStrict(data, instance); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if instance.GetNamespace() == "" {
+		return nil, fmt.Errorf("%s does not specify the namespace of the %s", path, meta.Kind)
+	}
+	// The API version of the file may differ, the reconcilers always work with v1beta1.
+	instance.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(meta.Kind))
+	return instance, nil
+}
+
+// newRenderer returns the renderer of the given component.
+func newRenderer(instance base.KComponent, manifest mf.Manifest) renderer {
+	if _, ok := instance.(*v1beta1.KnativeEventing); ok {
+		return knativeeventing.NewRenderer(manifest, common.NoExtension(context.Background(), nil))
+	}
+	return knativeserving.NewRenderer(manifest, common.NoExtension(context.Background(), nil))
+}
+
+// render executes the stages of the renderer on the given manifest, for the version the controller
+// installs next: the version a failed upgrade has been rolled back to, or the next version on the
+// upgrade path.
+func render(ctx context.Context, r renderer, manifest *mf.Manifest, instance base.KComponent) error {
+	restore, err := common.ResolveVersion(instance, specVersion(instance))
+	defer restore()
+	if err != nil {
+		return err
+	}
+	var state common.ReconcileState
+	_, err = r.Stages(&state).Execute(ctx, manifest, instance)
+	return err
+}
+
+// specVersion returns the spec.version field of the component.
+func specVersion(instance base.KComponent) *string {
+	if ke, ok := instance.(*v1beta1.KnativeEventing); ok {
+		return &ke.Spec.Version
+	}
+	return &instance.(*v1beta1.KnativeServing).Spec.Version
+}
+
+// setKoDataPath points the operator to the given directory of release manifests.
+func setKoDataPath(path string) error {
+	if path != "" {
+		return os.Setenv(common.KoEnvKey, path)
+	}
+	if os.Getenv(common.KoEnvKey) == "" {
+		return fmt.Errorf("either --kodata or the %s environment variable is required", common.KoEnvKey)
+	}
+	return nil
+}
+
+// toYAML serializes the resources of the manifest as a multi-document YAML.
+func toYAML(manifest mf.Manifest) ([]byte, error) {
+	var b strings.Builder
+	for i, u := range manifest.Resources() {
+		data, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(data)
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package main is the main package for the knative-operator command line tool. It runs the
+// reconciliation stages of the operator outside of the controller, to inspect what the operator
+// would install for a KnativeServing or KnativeEventing, and bundles what it would install for
+// disconnected sites.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: knative-operator <command> [flags]
+
+Commands:
+  render  render the manifest of a component without a cluster
+  plan    render the manifest of a component and diff it against the live cluster
+  bundle  export and import the bundles to install a component at disconnected sites
+
+Run "knative-operator <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "render":
+		err = runRender(os.Args[2:])
+	case "plan":
+		err = runPlan(os.Args[2:])
+	case "bundle":
+		err = runBundle(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	mfc "github.com/manifestival/client-go-client"
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	clientset "knative.dev/operator/pkg/client/clientset/versioned"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// runPlan renders the manifest of a component, and prints the changes applying it would make to
+// the cluster.
+func runPlan(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	file := fs.String("f", "", "the file containing the KnativeServing or KnativeEventing")
+	kubeconfig := fs.String("kubeconfig", os.Getenv(clientcmd.RecommendedConfigPathEnvVar), "path to the kubeconfig of the cluster")
+	kodata := fs.String("kodata", "", "the directory of the release manifests, defaults to $"+common.KoEnvKey)
+	manifestOut := fs.String("manifest-out", "", "write the rendered manifest to this file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+	if err := setKoDataPath(*kodata); err != nil {
+		return err
+	}
+
+	instance, err := readComponent(*file)
+	if err != nil {
+		return err
+	}
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := copyLiveStatus(ctx, config, instance); err != nil {
+		return err
+	}
+	client, err := mfc.NewClient(config)
+	if err != nil {
+		return err
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		return err
+	}
+	r := newRenderer(instance, manifest)
+	if err := render(ctx, r, &manifest, instance); err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	if *manifestOut != "" {
+		data, err := toYAML(manifest)
+		if err != nil {
+			return err
+		}
+		if *manifestOut == "-" {
+			_, err = os.Stdout.Write(append(data, []byte("---\n")...))
+		} else {
+			err = os.WriteFile(*manifestOut, data, 0644)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	installed, err := r.Installed(ctx, instance)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to obtain the installed manifest; obsolete resources are not planned:", err)
+		installed = nil
+	}
+	getter, err := mfdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	plan, err := common.ComputePlan(ctx, getter, &manifest, installed)
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan.String())
+	fmt.Println("Plan:", plan.Summary())
+	return nil
+}
+
+// copyLiveStatus copies the UID and the status of the component in the cluster, if it exists, so that
+// the manifest is rendered and diffed the way the controller would do it.
+func copyLiveStatus(ctx context.Context, config *rest.Config, instance base.KComponent) error {
+	operatorClient, err := clientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	client := operatorClient.OperatorV1beta1()
+	switch obj := instance.(type) {
+	case *v1beta1.KnativeServing:
+		live, err := client.KnativeServings(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get the KnativeServing: %w", err)
+		}
+		obj.UID = live.UID
+		obj.Status = live.Status
+		if equality.Semantic.DeepEqual(obj.Spec, live.Spec) {
+			// An unchanged spec keeps the generation, and so a rollback of a failed upgrade.
+			obj.Generation = live.Generation
+		}
+	case *v1beta1.KnativeEventing:
+		live, err := client.KnativeEventings(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get the KnativeEventing: %w", err)
+		}
+		obj.UID = live.UID
+		obj.Status = live.Status
+		if equality.Semantic.DeepEqual(obj.Spec, live.Spec) {
+			// An unchanged spec keeps the generation, and so a rollback of a failed upgrade.
+			obj.Generation = live.Generation
+		}
+	}
+	return nil
+}
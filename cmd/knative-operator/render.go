@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// runRender renders the manifest of a component without a cluster, and writes it to stdout or to
+// a directory.
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	file := fs.String("f", "", "the file containing the KnativeServing or KnativeEventing")
+	kodata := fs.String("kodata", "", "the directory of the release manifests, defaults to $"+common.KoEnvKey)
+	outDir := fs.String("output-dir", "", "write every resource to its own file in this directory, instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+	if err := setKoDataPath(*kodata); err != nil {
+		return err
+	}
+
+	instance, err := readComponent(*file)
+	if err != nil {
+		return err
+	}
+	// The fake client has no objects, so the manifest is rendered as for a new installation.
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(fake.New()))
+	if err != nil {
+		return err
+	}
+	if err := render(context.Background(), newRenderer(instance, manifest), &manifest, instance); err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Rendered %d resources of version %s\n", len(manifest.Resources()), common.TargetVersion(instance))
+
+	if *outDir == "" {
+		data, err := toYAML(manifest)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return writeDir(manifest, *outDir)
+}
+
+// writeDir writes every resource of the manifest to its own file. The files are numbered, so that
+// applying the directory preserves the order of the manifest.
+func writeDir(manifest mf.Manifest, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for i, u := range manifest.Resources() {
+		data, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		parts := []string{fmt.Sprintf("%04d", i), strings.ToLower(u.GetKind())}
+		if u.GetNamespace() != "" {
+			parts = append(parts, u.GetNamespace())
+		}
+		parts = append(parts, u.GetName())
+		name := strings.ReplaceAll(strings.Join(parts, "-"), ":", "_") + ".yaml"
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -21,14 +21,18 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/operator/pkg/apis/operator"
+	"knative.dev/operator/pkg/apis/operator/base"
 	operatorv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/manifests"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
+	"knative.dev/pkg/webhook/resourcesemantics"
 	"knative.dev/pkg/webhook/resourcesemantics/conversion"
+	"knative.dev/pkg/webhook/resourcesemantics/validation"
 )
 
 func main() {
@@ -41,10 +45,35 @@ func main() {
 
 	sharedmain.WebhookMainWithContext(ctx, webhook.NameFromEnv(),
 		certificates.NewController,
+		newValidationController,
 		newConversionController,
 	)
 }
 
+func newValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return validation.NewAdmissionController(ctx,
+		// Name of the resource webhook.
+		"validation.webhook.operator.knative.dev",
+
+		// The path on which to serve the webhook.
+		"/resource-validation",
+
+		// The resources to validate.
+		map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
+			operatorv1beta1.SchemeGroupVersion.WithKind("KnativeServing"):  &operatorv1beta1.KnativeServing{},
+			operatorv1beta1.SchemeGroupVersion.WithKind("KnativeEventing"): &operatorv1beta1.KnativeEventing{},
+		},
+
+		// A function that infuses the context passed to Validate with the releases bundled with the operator.
+		func(ctx context.Context) context.Context {
+			return base.WithReleaseInspector(ctx, manifests.Inspector{})
+		},
+
+		// Whether to disallow unknown fields.
+		true,
+	)
+}
+
 func newConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	var (
 		v1beta1 = operatorv1beta1.SchemeGroupVersion.Version
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// KafkaBrokerConfiguration specifies whether to install and how to configure the Kafka broker of
+// eventing-kafka-broker.
+type KafkaBrokerConfiguration struct {
+	Enabled bool `json:"enabled"`
+
+	KafkaConfiguration `json:",inline"`
+
+	// NumPartitions is the number of partitions of the topics of the brokers, set as
+	// default.topic.partitions of the kafka-broker-config ConfigMap.
+	// +optional
+	NumPartitions *int32 `json:"numPartitions,omitempty"`
+
+	// ReplicationFactor is the replication factor of the topics of the brokers, set as
+	// default.topic.replication.factor of the kafka-broker-config ConfigMap.
+	// +optional
+	ReplicationFactor *int32 `json:"replicationFactor,omitempty"`
+}
+
+// KafkaChannelConfiguration specifies whether to install and how to configure the KafkaChannel of
+// eventing-kafka-broker.
+type KafkaChannelConfiguration struct {
+	Enabled bool `json:"enabled"`
+
+	KafkaConfiguration `json:",inline"`
+}
+
+// KafkaConfiguration is the Kafka cluster and the data plane options of a Kafka broker or channel.
+type KafkaConfiguration struct {
+	// BootstrapServers are the addresses of the bootstrap servers of the Kafka cluster.
+	// +optional
+	BootstrapServers []string `json:"bootstrapServers,omitempty"`
+
+	// AuthSecretName is the name of the Secret in the namespace of the KnativeEventing, which holds
+	// the credentials and the TLS settings to connect to the Kafka cluster.
+	// +optional
+	AuthSecretName string `json:"authSecretName,omitempty"`
+
+	// Producer sets properties of the Kafka producers of the data plane, e.g. acks.
+	// +optional
+	Producer map[string]string `json:"producer,omitempty"`
+
+	// Consumer sets properties of the Kafka consumers of the data plane, e.g. max.poll.records.
+	// +optional
+	Consumer map[string]string `json:"consumer,omitempty"`
+}
+
+// RabbitmqBrokerConfiguration specifies whether to install and how to configure the RabbitMQ broker
+// of eventing-rabbitmq.
+type RabbitmqBrokerConfiguration struct {
+	Enabled bool `json:"enabled"`
+
+	// ClusterRef is the RabbitMQ cluster of the brokers, which do not refer to a config of their own.
+	// The operator creates a RabbitmqBrokerConfig with it, and refers the RabbitMQBroker class of
+	// config-br-defaults to it.
+	// +optional
+	ClusterRef *RabbitmqClusterReference `json:"clusterRef,omitempty"`
+
+	// QueueType is the type of the queues of the brokers, quorum or classic.
+	// +optional
+	// +kubebuilder:validation:Enum=quorum;classic
+	QueueType string `json:"queueType,omitempty"`
+}
+
+// RabbitmqClusterReference refers to a RabbitMQ cluster, either a RabbitmqCluster managed by the
+// RabbitMQ Cluster Operator, or an external one through a connection Secret.
+type RabbitmqClusterReference struct {
+	// Name is the name of the RabbitmqCluster.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the namespace of the RabbitmqCluster or of the connection Secret.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ConnectionSecret is the name of the Secret with the uri, username and password of an external
+	// RabbitMQ cluster.
+	// +optional
+	ConnectionSecret string `json:"connectionSecret,omitempty"`
+}
+
+// BrokerDefaults are the defaults of the brokers in config-br-defaults, besides the cluster-wide
+// broker class of spec.defaultBrokerClass.
+type BrokerDefaults struct {
+	// Delivery is the delivery of the brokers, which do not set one, of every broker class.
+	// +optional
+	Delivery *BrokerDelivery `json:"delivery,omitempty"`
+
+	// NamespaceBrokerClasses are the broker classes of the brokers, which do not set one, keyed by
+	// namespace. The classes must be installed.
+	// +optional
+	NamespaceBrokerClasses map[string]string `json:"namespaceBrokerClasses,omitempty"`
+}
+
+// BrokerDelivery is the delivery of the events of the brokers to their subscribers.
+type BrokerDelivery struct {
+	// Retry is the minimum number of retries before an event is sent to the dead letter sink.
+	// +optional
+	Retry *int32 `json:"retry,omitempty"`
+
+	// BackoffPolicy is the backoff policy of the retries, linear or exponential.
+	// +optional
+	// +kubebuilder:validation:Enum=linear;exponential
+	BackoffPolicy string `json:"backoffPolicy,omitempty"`
+
+	// BackoffDelay is the delay before retrying, as an ISO 8601 duration, e.g. PT0.2S.
+	// +optional
+	BackoffDelay string `json:"backoffDelay,omitempty"`
+
+	// DeadLetterSink receives the events, which could not be delivered.
+	// +optional
+	DeadLetterSink *DeadLetterSink `json:"deadLetterSink,omitempty"`
+}
+
+// DeadLetterSink is an addressable object or a URI receiving the events, which could not be
+// delivered.
+type DeadLetterSink struct {
+	// Ref refers to an addressable object, e.g. a Service.
+	// +optional
+	Ref *DeadLetterSinkReference `json:"ref,omitempty"`
+
+	// URI is the absolute URI of the sink, or the path relative to the address of Ref.
+	// +optional
+	URI string `json:"uri,omitempty"`
+}
+
+// DeadLetterSinkReference refers to the addressable object of a DeadLetterSink.
+type DeadLetterSinkReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+
+	// Namespace is the namespace of the object, by default the namespace of the broker.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DeliverySpec converts the delivery to the delivery of config-br-defaults.
+func (bd *BrokerDelivery) DeliverySpec() (*eventingduckv1.DeliverySpec, error) {
+	if bd == nil {
+		return nil, nil
+	}
+	spec := &eventingduckv1.DeliverySpec{Retry: bd.Retry}
+	if bd.BackoffPolicy != "" {
+		policy := eventingduckv1.BackoffPolicyType(bd.BackoffPolicy)
+		spec.BackoffPolicy = &policy
+	}
+	if bd.BackoffDelay != "" {
+		delay := bd.BackoffDelay
+		spec.BackoffDelay = &delay
+	}
+	if sink := bd.DeadLetterSink; sink != nil {
+		spec.DeadLetterSink = &duckv1.Destination{}
+		if sink.Ref != nil {
+			spec.DeadLetterSink.Ref = &duckv1.KReference{
+				APIVersion: sink.Ref.APIVersion,
+				Kind:       sink.Ref.Kind,
+				Name:       sink.Ref.Name,
+				Namespace:  sink.Ref.Namespace,
+			}
+		}
+		if sink.URI != "" {
+			uri, err := apis.ParseURL(sink.URI)
+			if err != nil {
+				return nil, err
+			}
+			spec.DeadLetterSink.URI = uri
+		}
+	}
+	return spec, nil
+}
+
+// ChannelDefaults are the default channel implementations in default-ch-webhook, which back the
+// channels created without a channel template, e.g. by the MTChannelBasedBroker.
+type ChannelDefaults struct {
+	// Cluster is the channel implementation of the namespaces without a default of their own.
+	// +optional
+	Cluster *ChannelTemplate `json:"cluster,omitempty"`
+
+	// Namespaces are the channel implementations keyed by namespace.
+	// +optional
+	Namespaces map[string]ChannelTemplate `json:"namespaces,omitempty"`
+}
+
+// ChannelTemplate is an installed channel implementation and the spec of its channels.
+type ChannelTemplate struct {
+	// Kind is the kind of the channels, InMemoryChannel or KafkaChannel.
+	// +kubebuilder:validation:Enum=InMemoryChannel;KafkaChannel
+	Kind string `json:"kind"`
+
+	// Spec is the spec of the channels, e.g. the numPartitions of KafkaChannels.
+	// +optional
+	Spec *runtime.RawExtension `json:"spec,omitempty"`
+}
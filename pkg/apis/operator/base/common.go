@@ -39,6 +39,12 @@ const (
 	VersionMigrationEligible apis.ConditionType = "VersionMigrationEligible"
 	// TargetClusterResolved is a Condition indicating whether the target cluster has been resolved.
 	TargetClusterResolved apis.ConditionType = "TargetClusterResolved"
+	// RolledBack is a Condition indicating that an upgrade did not become ready within the progress
+	// deadline, and the previously installed release has been reinstalled.
+	RolledBack apis.ConditionType = "RolledBack"
+	// ResourcesInSync is a Condition indicating whether the live resources of the component match
+	// its transformed manifest. It is only maintained if drift detection is enabled.
+	ResourcesInSync apis.ConditionType = "ResourcesInSync"
 )
 
 // KComponent is a common interface for accessing meta, spec and status of all known types.
@@ -84,6 +90,18 @@ type KComponentSpec interface {
 
 	// GetClusterProfileRef gets the reference to a ClusterProfile for multi-cluster deployment.
 	GetClusterProfileRef() *ClusterProfileReference
+
+	// GetUpgradeStrategy gets the options for upgrading the component.
+	GetUpgradeStrategy() *UpgradeStrategy
+
+	// GetDriftPolicy gets how the operator handles changes made to its resources by others.
+	GetDriftPolicy() DriftPolicy
+
+	// GetApplyStrategy gets how the operator applies the manifest.
+	GetApplyStrategy() *ApplyStrategy
+
+	// GetManifestVerification gets how the signatures of the manifests are verified.
+	GetManifestVerification() *ManifestVerification
 }
 
 // KComponentStatus is a common interface for status mutations of all known types.
@@ -125,6 +143,39 @@ type KComponentStatus interface {
 	// SetManifests sets the url links of the manifests
 	SetManifests(manifests []string)
 
+	// GetUpgrade gets the progress of the upgrade across multiple minor versions.
+	GetUpgrade() *UpgradeStatus
+	// SetUpgrade sets the progress of the upgrade across multiple minor versions.
+	SetUpgrade(upgrade *UpgradeStatus)
+
+	// GetRollback gets the release to roll back to, if the upgrade does not become ready.
+	GetRollback() *RollbackStatus
+	// SetRollback sets the release to roll back to, if the upgrade does not become ready.
+	SetRollback(rollback *RollbackStatus)
+	// GetHistory gets the releases successfully installed, the most recent first.
+	GetHistory() []InstallRecord
+	// SetHistory sets the releases successfully installed, the most recent first.
+	SetHistory(history []InstallRecord)
+	// GetResolvedImages gets the digests the images have been pinned to.
+	GetResolvedImages() []ResolvedImage
+	// SetResolvedImages sets the digests the images have been pinned to.
+	SetResolvedImages(images []ResolvedImage)
+
+	// MarkRolledBack marks the RolledBack status as true with the given message.
+	MarkRolledBack(msg string)
+	// ClearRolledBack removes the RolledBack status.
+	ClearRolledBack()
+
+	// MarkResourcesInSync marks the ResourcesInSync status as true.
+	MarkResourcesInSync()
+	// MarkResourcesDriftCorrected marks the ResourcesInSync status as true, after the drifted
+	// resources have been applied again.
+	MarkResourcesDriftCorrected(msg string)
+	// MarkResourcesDrifted marks the ResourcesInSync status as false with the given message.
+	MarkResourcesDrifted(msg string)
+	// ClearResourcesInSync removes the ResourcesInSync status.
+	ClearResourcesInSync()
+
 	// MarkTargetClusterResolved marks the TargetClusterResolved status as true.
 	MarkTargetClusterResolved()
 	// MarkTargetClusterNotResolved marks the TargetClusterResolved status as false with the given reason and message.
@@ -191,6 +242,27 @@ type CommonSpec struct {
 	// component is reconciled on the referenced remote cluster.
 	// +optional
 	ClusterProfileRef *ClusterProfileReference `json:"clusterProfileRef,omitempty"`
+
+	// UpgradeStrategy enables rolling back to the previously installed release, if an upgrade
+	// does not become ready within the progress deadline.
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
+	// DriftPolicy specifies how the operator handles changes made to its resources by others:
+	// "report" emits events and marks the ResourcesInSync condition false, "correct" applies
+	// the drifted resources again, and "ignore" disables the detection. Defaults to "ignore".
+	// +optional
+	// +kubebuilder:validation:Enum=report;correct;ignore
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// ApplyStrategy specifies how the operator applies the manifest.
+	// +optional
+	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// ManifestVerification requires the manifests and additional manifests to carry a detached
+	// signature, which is verified against a public key or a keyless identity before installing them.
+	// +optional
+	ManifestVerification *ManifestVerification `json:"manifestVerification,omitempty"`
 }
 
 // GetConfig implements KComponentSpec.
@@ -253,6 +325,29 @@ func (c *CommonSpec) GetClusterProfileRef() *ClusterProfileReference {
 	return c.ClusterProfileRef
 }
 
+// GetUpgradeStrategy implements KComponentSpec.
+func (c *CommonSpec) GetUpgradeStrategy() *UpgradeStrategy {
+	return c.UpgradeStrategy
+}
+
+// GetDriftPolicy implements KComponentSpec.
+func (c *CommonSpec) GetDriftPolicy() DriftPolicy {
+	if c.DriftPolicy == "" {
+		return DriftPolicyIgnore
+	}
+	return c.DriftPolicy
+}
+
+// GetApplyStrategy implements KComponentSpec.
+func (c *CommonSpec) GetApplyStrategy() *ApplyStrategy {
+	return c.ApplyStrategy
+}
+
+// GetManifestVerification implements KComponentSpec.
+func (c *CommonSpec) GetManifestVerification() *ManifestVerification {
+	return c.ManifestVerification
+}
+
 // ConfigMapData is a nested map of maps representing all upstream ConfigMaps. The first
 // level key is the key to the ConfigMap itself (i.e. "logging") while the second level
 // is the data to be filled into the respective ConfigMap.
@@ -277,6 +372,33 @@ type Registry struct {
 	// same namespace as the knative-serving deployments, and not the namespace of this resource.
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Mirrors replace the prefixes of the images with the prefixes of their mirrors, after the
+	// default and the overrides have been applied. The longest matching prefix wins.
+	// +optional
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+
+	// PinDigests resolves the tags of the images to their digests, and pins the images to them.
+	// The digests are recorded in status.resolvedImages, and kept as long as the images do not change.
+	// +optional
+	PinDigests bool `json:"pinDigests,omitempty"`
+}
+
+// RegistryMirror maps the images of an upstream registry, or of a repository prefix, to a mirror,
+// like the registry mirrors of containerd.
+type RegistryMirror struct {
+	// Prefix is the prefix of the upstream images, e.g. gcr.io/knative-releases.
+	Prefix string `json:"prefix"`
+	// Mirror replaces the prefix, e.g. registry.example.com/knative-releases.
+	Mirror string `json:"mirror"`
+}
+
+// ResolvedImage records the digest an image has been pinned to.
+type ResolvedImage struct {
+	// Image is the image, as referenced after the overrides and mirrors have been applied.
+	Image string `json:"image"`
+	// Digest is the digest of the image, in the "sha256:<hex>" format.
+	Digest string `json:"digest"`
 }
 
 // NamespaceConfiguration defines the configurations of namespaces to override.
@@ -441,6 +563,50 @@ type ProbesRequirementsOverride struct {
 type Manifest struct {
 	// The link of the manifest URL
 	Url string `json:"URL"`
+	// Digest is the sha256 digest of the manifest, in the "sha256:<hex>" format. The manifest is not
+	// installed if its content does not match. For oci:// URLs, it is the digest of the artifact.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
+	// SignatureURL is the link of the detached signature of the manifest, as created by
+	// `cosign sign-blob`. Defaults to the manifest URL with the ".sig" suffix, when
+	// spec.manifestVerification is set.
+	// +optional
+	SignatureURL string `json:"signatureURL,omitempty"`
+	// CertificateURL is the link of the signing certificate of the manifest, used by keyless
+	// verification. Defaults to the manifest URL with the ".pem" suffix.
+	// +optional
+	CertificateURL string `json:"certificateURL,omitempty"`
+}
+
+// ManifestVerification specifies how the detached signatures of the manifests are verified. One of
+// PublicKey or Keyless must be set.
+type ManifestVerification struct {
+	// PublicKey is the PEM-encoded public key, which signed the manifests, e.g. the cosign.pub
+	// file created by `cosign generate-key-pair`.
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+	// Keyless verifies the manifests signed with a short-lived certificate, issued by a
+	// certificate authority like Fulcio to an OIDC identity.
+	// +optional
+	Keyless *KeylessVerification `json:"keyless,omitempty"`
+}
+
+// KeylessVerification specifies the identity, which must have signed the manifests.
+type KeylessVerification struct {
+	// Issuer is the OIDC issuer of the identity, recorded in the signing certificate,
+	// e.g. https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer"`
+	// Subject is the identity, either an email or a URI like the workflow of a GitHub Action,
+	// recorded in the subject alternative names of the signing certificate.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// SubjectRegExp is a regular expression, which the identity must match, if Subject is not set.
+	// +optional
+	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+	// TrustedRoots are the PEM-encoded root and intermediate certificates of the certificate
+	// authority, which issued the signing certificates.
+	TrustedRoots string `json:"trustedRoots"`
 }
 
 // HighAvailability specifies options for deploying Knative Serving control
@@ -471,3 +637,96 @@ type ClusterProfileReference struct {
 	// +kubebuilder:validation:MinLength=1
 	Namespace string `json:"namespace"`
 }
+
+// UpgradeStatus records the progress of an upgrade, which spans multiple minor versions and is
+// therefore carried out one minor version at a time.
+type UpgradeStatus struct {
+	// Path is the list of versions installed in turn, ending with the target version.
+	Path []string `json:"path,omitempty"`
+	// CurrentStep is the index in Path of the version being installed.
+	CurrentStep int `json:"currentStep"`
+}
+
+// UpgradeStrategy specifies how the operator upgrades a component.
+type UpgradeStrategy struct {
+	// ProgressDeadline is how long the deployments of a new version have to become available,
+	// before the operator rolls back to the previously installed release. Defaults to 10m.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// DriftPolicy specifies how the operator handles live resources, which no longer match the manifest.
+type DriftPolicy string
+
+const (
+	// DriftPolicyReport reports the drifted resources, and leaves them as they are.
+	DriftPolicyReport DriftPolicy = "report"
+	// DriftPolicyCorrect reports the drifted resources, and applies the manifest to them again.
+	DriftPolicyCorrect DriftPolicy = "correct"
+	// DriftPolicyIgnore disables the detection of drifted resources.
+	DriftPolicyIgnore DriftPolicy = "ignore"
+)
+
+// ApplyType is the mechanism used to apply the manifest.
+type ApplyType string
+
+const (
+	// ClientSideApply applies the manifest with a three-way merge against the last applied
+	// configuration, overwriting the fields changed by others.
+	ClientSideApply ApplyType = "ClientSide"
+	// ServerSideApply applies the manifest with server-side apply, owning only the fields it sets.
+	ServerSideApply ApplyType = "ServerSide"
+)
+
+// ApplyStrategy specifies how the operator applies the manifest.
+type ApplyStrategy struct {
+	// Type is either "ClientSide", the default, or "ServerSide". With server-side apply, the
+	// operator only owns the fields set in the manifest, under the "knative-operator" field
+	// manager, and leaves the other fields to their owners.
+	// +optional
+	// +kubebuilder:validation:Enum=ClientSide;ServerSide
+	Type ApplyType `json:"type,omitempty"`
+	// ForceConflicts makes server-side apply take over the fields of the manifest, which are
+	// owned by other managers with a different value. Otherwise such conflicts fail the
+	// installation, and are listed in the InstallSucceeded condition.
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+}
+
+// IsServerSide returns true if the manifest is applied with server-side apply.
+func (s *ApplyStrategy) IsServerSide() bool {
+	return s != nil && s.Type == ServerSideApply
+}
+
+// RollbackStatus records the release installed before an upgrade, which is reinstalled if the
+// upgrade does not become ready within the progress deadline.
+type RollbackStatus struct {
+	// Version is the version of the release.
+	Version string `json:"version,omitempty"`
+	// Manifests are the url links of the manifests of the release.
+	// +optional
+	Manifests []string `json:"manifests,omitempty"`
+	// UpgradeStartTime is when the upgrade away from the release started.
+	UpgradeStartTime metav1.Time `json:"upgradeStartTime,omitempty"`
+	// RolledBackGeneration is the generation of the component, whose upgrade has been rolled back.
+	// The upgrade is not attempted again until the spec of the component changes.
+	// +optional
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+}
+
+// InstallRecord records a release successfully installed by the operator.
+type InstallRecord struct {
+	// Version is the version of the release.
+	Version string `json:"version"`
+	// Manifests are the url links of the manifests of the release.
+	// +optional
+	Manifests []string `json:"manifests,omitempty"`
+	// Digest is the sha256 digest of the transformed manifest, which has been applied.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// InstallTime is when the release became ready.
+	InstallTime metav1.Time `json:"installTime"`
+	// SupersededTime is when the next release became ready.
+	// +optional
+	SupersededTime *metav1.Time `json:"supersededTime,omitempty"`
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// latestVersion is the special version, which always resolves to the newest release known to the operator.
+	latestVersion = "latest"
+	// containerNameVariable is the placeholder for the container or image name in Registry.Default.
+	containerNameVariable = "${NAME}"
+)
+
+// manifestDigest matches the digests of the manifests.
+var manifestDigest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// TargetResources contains the names of the resources in the target manifest of a component,
+// which can be referenced from its spec.
+// +k8s:deepcopy-gen=false
+type TargetResources struct {
+	// ConfigMaps contains the names of all the ConfigMaps.
+	ConfigMaps sets.String
+	// Workloads contains the names of all the Deployments and StatefulSets, and the generate names
+	// of all the Jobs.
+	Workloads sets.String
+}
+
+// ReleaseInspector gives access to the releases bundled with the operator, so that a spec can be
+// validated against them. The API types cannot depend on the reconcilers, so an implementation is
+// injected into the context by the webhook.
+type ReleaseInspector interface {
+	// CheckVersion returns an error if the target version of the component is not available, or if
+	// the installed version cannot be migrated to it.
+	CheckVersion(comp KComponent) error
+	// TargetResources returns the resources in the target manifest of the component. It returns nil,
+	// if they cannot be determined without fetching remote manifests.
+	TargetResources(ctx context.Context, comp KComponent) (*TargetResources, error)
+}
+
+type releaseInspectorKey struct{}
+
+// WithReleaseInspector attaches the given ReleaseInspector to the context.
+func WithReleaseInspector(ctx context.Context, ri ReleaseInspector) context.Context {
+	return context.WithValue(ctx, releaseInspectorKey{}, ri)
+}
+
+// GetReleaseInspector returns the ReleaseInspector attached to the context, or nil if there is none.
+func GetReleaseInspector(ctx context.Context) ReleaseInspector {
+	ri, _ := ctx.Value(releaseInspectorKey{}).(ReleaseInspector)
+	return ri
+}
+
+// ValidateCommonSpec validates the fields shared by all the components. The returned errors are
+// relative to the spec of the component.
+func (c *CommonSpec) ValidateCommonSpec(ctx context.Context, comp KComponent) *apis.FieldError {
+	var errs *apis.FieldError
+	errs = errs.Also(validateVersion(c.Version).ViaField("version"))
+	errs = errs.Also(c.Registry.validate().ViaField("registry"))
+	errs = errs.Also(validateManifests(c.Manifests).ViaField("manifests"))
+	errs = errs.Also(validateManifests(c.AdditionalManifests).ViaField("additionalManifests"))
+	errs = errs.Also(validateWorkloadNames(c.DeploymentOverride).ViaField("deployments"))
+	errs = errs.Also(validateWorkloadNames(c.Workloads).ViaField("workloads"))
+	if c.HighAvailability != nil && c.HighAvailability.Replicas != nil && *c.HighAvailability.Replicas < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*c.HighAvailability.Replicas, "replicas").ViaField("high-availability"))
+	}
+	if c.UpgradeStrategy != nil && c.UpgradeStrategy.ProgressDeadline != nil && c.UpgradeStrategy.ProgressDeadline.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(c.UpgradeStrategy.ProgressDeadline.Duration.String(), "progressDeadline",
+			"must be positive").ViaField("upgradeStrategy"))
+	}
+	switch c.DriftPolicy {
+	case "", DriftPolicyReport, DriftPolicyCorrect, DriftPolicyIgnore:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(c.DriftPolicy, "driftPolicy", "must be report, correct or ignore"))
+	}
+	errs = errs.Also(c.ApplyStrategy.validate().ViaField("applyStrategy"))
+	errs = errs.Also(c.ManifestVerification.validate().ViaField("manifestVerification"))
+	if c.ManifestVerification != nil {
+		errs = errs.Also(validateSignedManifests(c.Manifests).ViaField("manifests"))
+		errs = errs.Also(validateSignedManifests(c.AdditionalManifests).ViaField("additionalManifests"))
+	}
+	if errs != nil {
+		// The remaining checks need a well-formed spec to resolve the target manifest.
+		return errs
+	}
+
+	ri := GetReleaseInspector(ctx)
+	if ri == nil {
+		return nil
+	}
+	if err := ri.CheckVersion(comp); err != nil {
+		return apis.ErrInvalidValue(c.Version, "version", err.Error())
+	}
+	resources, err := ri.TargetResources(ctx, comp)
+	if err != nil {
+		return apis.ErrGeneric(fmt.Sprintf("failed to load the target manifest: %v", err))
+	}
+	if resources == nil {
+		return nil
+	}
+	errs = errs.Also(c.validateConfigNames(resources.ConfigMaps).ViaField("config"))
+	errs = errs.Also(validateWorkloadTargets(c.DeploymentOverride, resources.Workloads).ViaField("deployments"))
+	errs = errs.Also(validateWorkloadTargets(c.Workloads, resources.Workloads).ViaField("workloads"))
+	return errs
+}
+
+func validateVersion(version string) *apis.FieldError {
+	if version == "" || strings.EqualFold(version, latestVersion) {
+		return nil
+	}
+	v := version
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return apis.ErrInvalidValue(version, apis.CurrentField, "must be a semantic version, a major.minor version or latest")
+	}
+	return nil
+}
+
+func (r *Registry) validate() *apis.FieldError {
+	var errs *apis.FieldError
+	if r.Default != "" {
+		if !strings.Contains(r.Default, containerNameVariable) {
+			errs = errs.Also(apis.ErrInvalidValue(r.Default, "default",
+				fmt.Sprintf("must contain the %s placeholder", containerNameVariable)))
+		} else if _, err := name.ParseReference(strings.ReplaceAll(r.Default, containerNameVariable, "name")); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(r.Default, "default", err.Error()))
+		}
+	}
+	for i, m := range r.Mirrors {
+		if m.Prefix == "" {
+			errs = errs.Also(apis.ErrMissingField("prefix").ViaFieldIndex("mirrors", i))
+		} else if strings.Contains(m.Prefix, "://") {
+			errs = errs.Also(apis.ErrInvalidValue(m.Prefix, "prefix", "must not contain a scheme").ViaFieldIndex("mirrors", i))
+		}
+		if m.Mirror == "" {
+			errs = errs.Also(apis.ErrMissingField("mirror").ViaFieldIndex("mirrors", i))
+		} else if _, err := name.NewRepository(strings.TrimSuffix(m.Mirror, "/") + "/name"); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(m.Mirror, "mirror", err.Error()).ViaFieldIndex("mirrors", i))
+		}
+	}
+	for key, image := range r.Override {
+		if _, err := name.ParseReference(image); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(image, apis.CurrentField, err.Error()).ViaKey(key).ViaField("override"))
+		}
+	}
+	return errs
+}
+
+func (s *ApplyStrategy) validate() *apis.FieldError {
+	if s == nil {
+		return nil
+	}
+	switch s.Type {
+	case "", ClientSideApply:
+		if s.ForceConflicts {
+			return apis.ErrGeneric("forceConflicts requires the ServerSide type", "forceConflicts")
+		}
+	case ServerSideApply:
+	default:
+		return apis.ErrInvalidValue(s.Type, "type", "must be ClientSide or ServerSide")
+	}
+	return nil
+}
+
+func validateManifests(manifests []Manifest) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, m := range manifests {
+		if strings.TrimSpace(m.Url) == "" {
+			errs = errs.Also(apis.ErrMissingField("URL").ViaIndex(i))
+		}
+		if m.Digest != "" && !manifestDigest.MatchString(m.Digest) {
+			errs = errs.Also(apis.ErrInvalidValue(m.Digest, "digest", "must be sha256:<64 hex digits>").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// validateSignedManifests rejects the oci:// manifests, whose signatures cannot be verified, when
+// spec.manifestVerification is set.
+func validateSignedManifests(manifests []Manifest) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, m := range manifests {
+		if strings.HasPrefix(m.Url, "oci://") {
+			errs = errs.Also(apis.ErrGeneric("signatures of oci:// manifests are not supported, pin them with digest instead",
+				"URL").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+func (v *ManifestVerification) validate() *apis.FieldError {
+	if v == nil {
+		return nil
+	}
+	if (v.PublicKey == "") == (v.Keyless == nil) {
+		return apis.ErrMissingOneOf("publicKey", "keyless")
+	}
+	if v.Keyless == nil {
+		if block, _ := pem.Decode([]byte(v.PublicKey)); block == nil {
+			return apis.ErrInvalidValue("<key>", "publicKey", "must be a PEM-encoded public key")
+		}
+		return nil
+	}
+	var errs *apis.FieldError
+	if v.Keyless.Issuer == "" {
+		errs = errs.Also(apis.ErrMissingField("issuer"))
+	}
+	if v.Keyless.TrustedRoots == "" {
+		errs = errs.Also(apis.ErrMissingField("trustedRoots"))
+	}
+	if v.Keyless.Subject == "" && v.Keyless.SubjectRegExp == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("subject", "subjectRegExp"))
+	}
+	if v.Keyless.SubjectRegExp != "" {
+		if _, err := regexp.Compile(v.Keyless.SubjectRegExp); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v.Keyless.SubjectRegExp, "subjectRegExp", err.Error()))
+		}
+	}
+	return errs.ViaField("keyless")
+}
+
+func validateWorkloadNames(overrides []WorkloadOverride) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, o := range overrides {
+		if o.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		}
+		if o.Replicas != nil && *o.Replicas < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*o.Replicas, "replicas").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+func validateWorkloadTargets(overrides []WorkloadOverride, workloads sets.String) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, o := range overrides {
+		if !workloads.Has(o.Name) {
+			errs = errs.Also(apis.ErrInvalidValue(o.Name, "name",
+				"no workload with this name exists in the target manifest").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+func (c *CommonSpec) validateConfigNames(configMaps sets.String) *apis.FieldError {
+	var errs *apis.FieldError
+	for key := range c.Config {
+		// The "config-" prefix is optional.
+		if !configMaps.Has(key) && !configMaps.Has("config-"+key) {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, apis.CurrentField,
+				"no ConfigMap with this name exists in the target manifest"))
+		}
+	}
+	return errs
+}
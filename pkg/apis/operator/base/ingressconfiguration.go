@@ -18,6 +18,7 @@ package base
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // IstioIngressConfiguration specifies options for the istio ingresses.
@@ -32,6 +33,37 @@ type IstioIngressConfiguration struct {
 	// KnativeLocalGateway overrides the knative-local-gateway.
 	// +optional
 	KnativeLocalGateway *IstioGatewayOverride `json:"knative-local-gateway,omitempty"`
+
+	// Gateways are additional Istio Gateways, rendered by the operator and added to the
+	// external-gateways or local-gateways entries of the config-istio ConfigMap.
+	// +optional
+	Gateways []IstioGateway `json:"gateways,omitempty"`
+}
+
+// IstioGateway is an additional Istio Gateway of Knative Serving.
+type IstioGateway struct {
+	// Name is the name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. It defaults to the namespace of the KnativeServing.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Local makes the Gateway serve the cluster local traffic, instead of the external traffic.
+	// +optional
+	Local bool `json:"local,omitempty"`
+
+	// Service is the address of the Service of the Istio ingress gateway, e.g.
+	// tenant-ingressgateway.istio-system.svc.cluster.local.
+	Service string `json:"service"`
+
+	// LabelSelector selects the Knative Services using the Gateway. It is required, as the
+	// built-in gateways are the default ones.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// Selector and Servers of the Gateway. The selector defaults to istio: ingressgateway, and the
+	// servers to an HTTP server on port 80 for the external Gateways, and 8081 for the local ones.
+	IstioGatewayOverride `json:",inline"`
 }
 
 // KourierIngressConfiguration specifies whether to enable the kourier ingresses.
@@ -53,18 +85,206 @@ type KourierIngressConfiguration struct {
 
 	// BootstrapConfigmapName specifies the ConfigMap name which contains envoy bootstrap.
 	BootstrapConfigmapName string `json:"bootstrap-configmap,omitempty"`
+
+	// ClusterCertSecret is the name of the Secret in the namespace of the KnativeServing, which
+	// contains the certificate of the Kourier gateway for the cluster local TLS traffic.
+	// +optional
+	ClusterCertSecret string `json:"cluster-cert-secret,omitempty"`
+
+	// CertsSecret is the namespace/name of the Secret, which contains the certificate of the Kourier
+	// gateway for the external HTTPS traffic.
+	// +optional
+	CertsSecret string `json:"certs-secret,omitempty"`
+
+	// ExtAuthz configures the external authorization service of the Kourier gateway.
+	// +optional
+	ExtAuthz *KourierExtAuthzConfiguration `json:"extauthz,omitempty"`
+
+	// Tracing configures the distributed tracing of the Kourier gateway.
+	// +optional
+	Tracing *KourierTracingConfiguration `json:"tracing,omitempty"`
+
+	// EnableProxyProtocol makes the Kourier gateway expect the PROXY protocol.
+	// +optional
+	EnableProxyProtocol bool `json:"enable-proxy-protocol,omitempty"`
 }
 
-// ContourIngressConfiguration specifies whether to enable the contour ingresses.
+// KourierExtAuthzConfiguration configures the external authorization service of Kourier.
+type KourierExtAuthzConfiguration struct {
+	// Host is the host and port of the external authorization service, e.g. my-auth:2222.
+	Host string `json:"host"`
+
+	// Protocol is the protocol used to query the external authorization service. It defaults to grpc.
+	// +optional
+	// +kubebuilder:validation:Enum=grpc;http;https
+	Protocol string `json:"protocol,omitempty"`
+
+	// FailureModeAllow lets the traffic through, when the external authorization service is down.
+	// +optional
+	FailureModeAllow bool `json:"failure-mode-allow,omitempty"`
+}
+
+// KourierTracingConfiguration configures the distributed tracing of Kourier.
+type KourierTracingConfiguration struct {
+	// Endpoint is the endpoint of the OTLP collector, e.g. http://otel-collector.observability.svc:4317.
+	Endpoint string `json:"endpoint"`
+
+	// Protocol is the protocol of the OTLP collector, grpc or http/protobuf. It defaults to grpc.
+	// +optional
+	// +kubebuilder:validation:Enum=grpc;http/protobuf
+	Protocol string `json:"protocol,omitempty"`
+
+	// SamplingRate is the fraction of the requests traced, between 0.0 and 1.0. It defaults to 1.0.
+	// +optional
+	SamplingRate string `json:"sampling-rate,omitempty"`
+}
+
+// ContourIngressConfiguration specifies options for the contour ingresses.
 type ContourIngressConfiguration struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+
+	// External configures the Contour of the external traffic, the ExternalIP visibility of the
+	// config-contour ConfigMap.
+	// +optional
+	External *ContourVisibilityConfiguration `json:"external,omitempty"`
+
+	// Internal configures the Contour of the cluster local traffic, the ClusterLocal visibility of
+	// the config-contour ConfigMap.
+	// +optional
+	Internal *ContourVisibilityConfiguration `json:"internal,omitempty"`
+
+	// DefaultTLSSecret is the namespace/name of the Secret used by the HTTPProxies, when auto-TLS
+	// is disabled. The operator delegates it to all namespaces with a TLSCertificateDelegation.
+	// +optional
+	DefaultTLSSecret string `json:"default-tls-secret,omitempty"`
+
+	// TimeoutPolicyIdle sets the idle timeout of the HTTPProxies, a duration or "infinity".
+	// +optional
+	TimeoutPolicyIdle string `json:"timeout-policy-idle,omitempty"`
+
+	// TimeoutPolicyResponse sets the response timeout of the HTTPProxies, a duration or "infinity".
+	// +optional
+	TimeoutPolicyResponse string `json:"timeout-policy-response,omitempty"`
+}
+
+// ContourVisibilityConfiguration configures the Contour of a visibility.
+type ContourVisibilityConfiguration struct {
+	// Class is the ingress class of the Contour, contour-external or contour-internal by default.
+	// +optional
+	Class string `json:"class,omitempty"`
+
+	// Service is the namespace/name of the Envoy Service of the Contour, contour-external/envoy or
+	// contour-internal/envoy by default.
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// ServiceType overrides the type of the Envoy Service. The Service of a Contour installed
+	// separately is patched in the cluster, and must exist.
+	// +optional
+	ServiceType v1.ServiceType `json:"service-type,omitempty"`
+
+	// ServiceAnnotations are added to the Envoy Service. The Service of a Contour installed
+	// separately is patched in the cluster, and must exist.
+	// +optional
+	ServiceAnnotations map[string]string `json:"service-annotations,omitempty"`
 }
 
-// GatewayAPIIngressConfiguration specifies whether to enable the gateway-api ingresses.
+// GatewayAPIIngressConfiguration specifies options for the gateway-api ingresses.
 type GatewayAPIIngressConfiguration struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+
+	// ExternalGateway configures the Gateway of the external traffic, written to the
+	// external-gateways entry of the config-gateway ConfigMap.
+	// +optional
+	ExternalGateway *GatewayAPIGateway `json:"external-gateway,omitempty"`
+
+	// LocalGateway configures the Gateway of the cluster local traffic, written to the
+	// local-gateways entry of the config-gateway ConfigMap.
+	// +optional
+	LocalGateway *GatewayAPIGateway `json:"local-gateway,omitempty"`
+}
+
+// GatewayAPIGateway configures a Gateway used by net-gateway-api.
+type GatewayAPIGateway struct {
+	// Class is the name of the GatewayClass of the Gateway, which must exist in the cluster.
+	Class string `json:"class"`
+
+	// Gateway is the namespace/name of the Gateway.
+	Gateway string `json:"gateway"`
+
+	// Service is the namespace/name of the Service of the Gateway, used to probe it. If it is not
+	// set, net-gateway-api probes the first address in the status of the Gateway.
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// SupportedFeatures are the features of the Gateway API supported by the Gateway, e.g.
+	// HTTPRouteRequestTimeout.
+	// +optional
+	SupportedFeatures []string `json:"supported-features,omitempty"`
+
+	// ProxyProtocolEnabled specifies whether the Gateway expects the PROXY protocol.
+	// +optional
+	ProxyProtocolEnabled bool `json:"proxy-protocol-enabled,omitempty"`
+
+	// Create makes the operator create the Gateway, with the given listeners.
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// Listeners are the listeners of the created Gateway. It defaults to an HTTP listener named http
+	// on port 80.
+	// +optional
+	Listeners []GatewayAPIListener `json:"listeners,omitempty"`
+}
+
+// GatewayAPIListener is a listener of a created Gateway.
+type GatewayAPIListener struct {
+	// Name is the name of the listener, unique in the Gateway.
+	Name string `json:"name"`
+
+	// Port is the port of the listener.
+	Port int32 `json:"port"`
+
+	// Protocol is the protocol of the listener, HTTP or HTTPS. It defaults to HTTP.
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Protocol string `json:"protocol,omitempty"`
+
+	// Hostname restricts the listener to the matching hosts.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// CertificateRefs are the Secrets holding the TLS certificates of HTTPS listeners.
+	// +optional
+	CertificateRefs []GatewayAPICertificateRef `json:"certificate-refs,omitempty"`
+}
+
+// GatewayAPICertificateRef references a Secret holding a TLS certificate.
+type GatewayAPICertificateRef struct {
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Secret. It defaults to the namespace of the Gateway.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// CustomIngressConfiguration specifies a networking layer, which is not shipped with the operator,
+// installed from arbitrary manifests.
+type CustomIngressConfiguration struct {
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Manifests are the manifests of the networking layer. The variable ${VERSION} in their URLs is
+	// replaced with the version of Knative Serving.
+	// +optional
+	Manifests []Manifest `json:"manifests,omitempty"`
+
+	// IngressClass is the ingress class of the networking layer, set as the ingress-class of the
+	// config-network ConfigMap, unless it is set in spec.config.
+	// +optional
+	IngressClass string `json:"ingress-class,omitempty"`
 }
 
 // IstioGatewayOverride override the knative-ingress-gateway and knative-local-gateway(cluster-local-gateway)
@@ -16,14 +16,24 @@ limitations under the License.
 
 package base
 
+// SourceConfiguration specifies whether to enable a source of the catalog, which has no typed
+// options.
+type SourceConfiguration struct {
+	Enabled bool `json:"enabled"`
+}
+
 // AwssqsSourceConfiguration specifies whether to enable the awssqs source.
 type AwssqsSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
 }
 
-// CephSourceConfiguration specifies whether to enable the ceph source.
+// CephSourceConfiguration specifies whether to enable and how to configure the ceph source.
 type CephSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
 }
 
 // CouchdbSourceConfiguration specifies whether to enable the couchdb source.
@@ -31,19 +41,44 @@ type CouchdbSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
 }
 
-// GithubSourceConfiguration specifies whether to enable the github source.
+// GithubSourceConfiguration specifies whether to enable and how to configure the github source.
 type GithubSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
 }
 
-// GitlabSourceConfiguration specifies whether to enable the gitlab source.
+// GitlabSourceConfiguration specifies whether to enable and how to configure the gitlab source.
 type GitlabSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
 }
 
-// KafkaSourceConfiguration specifies whether to enable the kafka source.
+// KafkaSourceConfiguration specifies whether to enable and how to configure the kafka source.
 type KafkaSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
+
+	// Dispatcher configures the dispatcher, which runs the adapters of the KafkaSources.
+	// +optional
+	Dispatcher *SourceWorkloadConfiguration `json:"dispatcher,omitempty"`
+
+	// Features sets the feature flags of the config-kafka-features ConfigMap, e.g.
+	// dispatcher-rate-limiter.
+	// +optional
+	Features map[string]string `json:"features,omitempty"`
+
+	// Defaults sets the defaults of the KafkaSources, in the config-kafka-source-defaults ConfigMap.
+	// +optional
+	Defaults *KafkaSourceDefaults `json:"defaults,omitempty"`
 }
 
 // NatssSourceConfiguration specifies whether to enable the natss source.
@@ -56,12 +91,69 @@ type PrometheusSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
 }
 
-// RabbitmqSourceConfiguration specifies whether to enable the rabbitmq source.
+// RabbitmqSourceConfiguration specifies whether to enable and how to configure the rabbitmq source.
 type RabbitmqSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
 }
 
-// RedisSourceConfiguration specifies whether to enable the redis source.
+// RedisSourceConfiguration specifies whether to enable and how to configure the redis source.
 type RedisSourceConfiguration struct {
 	Enabled bool `json:"enabled"`
+
+	// Controller configures the controller of the source.
+	// +optional
+	Controller *SourceWorkloadConfiguration `json:"controller,omitempty"`
+
+	// Defaults sets the defaults of the RedisStreamSources, in the config-redis ConfigMap.
+	// +optional
+	Defaults *RedisSourceDefaults `json:"defaults,omitempty"`
+}
+
+// SourceWorkloadConfiguration configures a workload of an eventing source.
+type SourceWorkloadConfiguration struct {
+	// Replicas is the number of replicas of the workload.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources overrides the resources of the containers of the workload.
+	// +optional
+	Resources []ResourceRequirementsOverride `json:"resources,omitempty"`
+}
+
+// KafkaSourceDefaults are the defaults of the KafkaSources, used to scale them with KEDA.
+type KafkaSourceDefaults struct {
+	// AutoscalingClass is the class of the autoscaler, e.g. keda.autoscaling.knative.dev.
+	// +optional
+	AutoscalingClass string `json:"autoscalingClass,omitempty"`
+
+	// MinScale is the minimum number of replicas of a KafkaSource.
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+
+	// MaxScale is the maximum number of replicas of a KafkaSource.
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
+
+	// PollingInterval is the interval in seconds, at which KEDA polls the metrics.
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds, which KEDA waits before scaling down.
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// KafkaLagThreshold is the lag of a partition, above which KEDA scales up.
+	// +optional
+	KafkaLagThreshold *int32 `json:"kafkaLagThreshold,omitempty"`
+}
+
+// RedisSourceDefaults are the defaults of the RedisStreamSources.
+type RedisSourceDefaults struct {
+	// NumConsumers is the number of consumers in the consumer group of a RedisStreamSource.
+	// +optional
+	NumConsumers *int32 `json:"numConsumers,omitempty"`
 }
@@ -23,8 +23,26 @@ package base
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyStrategy.
+func (in *ApplyStrategy) DeepCopy() *ApplyStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwssqsSourceConfiguration) DeepCopyInto(out *AwssqsSourceConfiguration) {
 	*out = *in
@@ -41,9 +59,68 @@ func (in *AwssqsSourceConfiguration) DeepCopy() *AwssqsSourceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDefaults) DeepCopyInto(out *BrokerDefaults) {
+	*out = *in
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(BrokerDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceBrokerClasses != nil {
+		in, out := &in.NamespaceBrokerClasses, &out.NamespaceBrokerClasses
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDefaults.
+func (in *BrokerDefaults) DeepCopy() *BrokerDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDelivery) DeepCopyInto(out *BrokerDelivery) {
+	*out = *in
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeadLetterSink != nil {
+		in, out := &in.DeadLetterSink, &out.DeadLetterSink
+		*out = new(DeadLetterSink)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDelivery.
+func (in *BrokerDelivery) DeepCopy() *BrokerDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephSourceConfiguration) DeepCopyInto(out *CephSourceConfiguration) {
 	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -57,6 +134,55 @@ func (in *CephSourceConfiguration) DeepCopy() *CephSourceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelDefaults) DeepCopyInto(out *ChannelDefaults) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ChannelTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]ChannelTemplate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelDefaults.
+func (in *ChannelDefaults) DeepCopy() *ChannelDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelTemplate) DeepCopyInto(out *ChannelTemplate) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelTemplate.
+func (in *ChannelTemplate) DeepCopy() *ChannelTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProfileReference) DeepCopyInto(out *ClusterProfileReference) {
 	*out = *in
@@ -154,6 +280,21 @@ func (in *CommonSpec) DeepCopyInto(out *CommonSpec) {
 		*out = new(ClusterProfileReference)
 		**out = **in
 	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyStrategy != nil {
+		in, out := &in.ApplyStrategy, &out.ApplyStrategy
+		*out = new(ApplyStrategy)
+		**out = **in
+	}
+	if in.ManifestVerification != nil {
+		in, out := &in.ManifestVerification, &out.ManifestVerification
+		*out = new(ManifestVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -202,6 +343,16 @@ func (in ConfigMapData) DeepCopy() ConfigMapData {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContourIngressConfiguration) DeepCopyInto(out *ContourIngressConfiguration) {
 	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ContourVisibilityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(ContourVisibilityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -215,6 +366,29 @@ func (in *ContourIngressConfiguration) DeepCopy() *ContourIngressConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContourVisibilityConfiguration) DeepCopyInto(out *ContourVisibilityConfiguration) {
+	*out = *in
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourVisibilityConfiguration.
+func (in *ContourVisibilityConfiguration) DeepCopy() *ContourVisibilityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ContourVisibilityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CouchdbSourceConfiguration) DeepCopyInto(out *CouchdbSourceConfiguration) {
 	*out = *in
@@ -247,6 +421,64 @@ func (in *CustomCerts) DeepCopy() *CustomCerts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomIngressConfiguration) DeepCopyInto(out *CustomIngressConfiguration) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]Manifest, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomIngressConfiguration.
+func (in *CustomIngressConfiguration) DeepCopy() *CustomIngressConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomIngressConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadLetterSink) DeepCopyInto(out *DeadLetterSink) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(DeadLetterSinkReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadLetterSink.
+func (in *DeadLetterSink) DeepCopy() *DeadLetterSink {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadLetterSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadLetterSinkReference) DeepCopyInto(out *DeadLetterSinkReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadLetterSinkReference.
+func (in *DeadLetterSinkReference) DeepCopy() *DeadLetterSinkReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadLetterSinkReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvRequirementsOverride) DeepCopyInto(out *EnvRequirementsOverride) {
 	*out = *in
@@ -270,9 +502,63 @@ func (in *EnvRequirementsOverride) DeepCopy() *EnvRequirementsOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPICertificateRef) DeepCopyInto(out *GatewayAPICertificateRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPICertificateRef.
+func (in *GatewayAPICertificateRef) DeepCopy() *GatewayAPICertificateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPICertificateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPIGateway) DeepCopyInto(out *GatewayAPIGateway) {
+	*out = *in
+	if in.SupportedFeatures != nil {
+		in, out := &in.SupportedFeatures, &out.SupportedFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]GatewayAPIListener, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIGateway.
+func (in *GatewayAPIGateway) DeepCopy() *GatewayAPIGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPIGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayAPIIngressConfiguration) DeepCopyInto(out *GatewayAPIIngressConfiguration) {
 	*out = *in
+	if in.ExternalGateway != nil {
+		in, out := &in.ExternalGateway, &out.ExternalGateway
+		*out = new(GatewayAPIGateway)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalGateway != nil {
+		in, out := &in.LocalGateway, &out.LocalGateway
+		*out = new(GatewayAPIGateway)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -286,9 +572,35 @@ func (in *GatewayAPIIngressConfiguration) DeepCopy() *GatewayAPIIngressConfigura
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPIListener) DeepCopyInto(out *GatewayAPIListener) {
+	*out = *in
+	if in.CertificateRefs != nil {
+		in, out := &in.CertificateRefs, &out.CertificateRefs
+		*out = make([]GatewayAPICertificateRef, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIListener.
+func (in *GatewayAPIListener) DeepCopy() *GatewayAPIListener {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPIListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GithubSourceConfiguration) DeepCopyInto(out *GithubSourceConfiguration) {
 	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -305,6 +617,11 @@ func (in *GithubSourceConfiguration) DeepCopy() *GithubSourceConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitlabSourceConfiguration) DeepCopyInto(out *GitlabSourceConfiguration) {
 	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -339,6 +656,54 @@ func (in *HighAvailability) DeepCopy() *HighAvailability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallRecord) DeepCopyInto(out *InstallRecord) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.InstallTime.DeepCopyInto(&out.InstallTime)
+	if in.SupersededTime != nil {
+		in, out := &in.SupersededTime, &out.SupersededTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallRecord.
+func (in *InstallRecord) DeepCopy() *InstallRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioGateway) DeepCopyInto(out *IstioGateway) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.IstioGatewayOverride.DeepCopyInto(&out.IstioGatewayOverride)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioGateway.
+func (in *IstioGateway) DeepCopy() *IstioGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IstioGatewayOverride) DeepCopyInto(out *IstioGatewayOverride) {
 	*out = *in
@@ -382,6 +747,13 @@ func (in *IstioIngressConfiguration) DeepCopyInto(out *IstioIngressConfiguration
 		*out = new(IstioGatewayOverride)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]IstioGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -479,41 +851,241 @@ func (in *IstioServerTLSSettings) DeepCopy() *IstioServerTLSSettings {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaSourceConfiguration) DeepCopyInto(out *KafkaSourceConfiguration) {
+func (in *KafkaBrokerConfiguration) DeepCopyInto(out *KafkaBrokerConfiguration) {
 	*out = *in
+	in.KafkaConfiguration.DeepCopyInto(&out.KafkaConfiguration)
+	if in.NumPartitions != nil {
+		in, out := &in.NumPartitions, &out.NumPartitions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceConfiguration.
-func (in *KafkaSourceConfiguration) DeepCopy() *KafkaSourceConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBrokerConfiguration.
+func (in *KafkaBrokerConfiguration) DeepCopy() *KafkaBrokerConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaSourceConfiguration)
+	out := new(KafkaBrokerConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KourierIngressConfiguration) DeepCopyInto(out *KourierIngressConfiguration) {
+func (in *KafkaChannelConfiguration) DeepCopyInto(out *KafkaChannelConfiguration) {
 	*out = *in
+	in.KafkaConfiguration.DeepCopyInto(&out.KafkaConfiguration)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KourierIngressConfiguration.
-func (in *KourierIngressConfiguration) DeepCopy() *KourierIngressConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelConfiguration.
+func (in *KafkaChannelConfiguration) DeepCopy() *KafkaChannelConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(KourierIngressConfiguration)
+	out := new(KafkaChannelConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Manifest) DeepCopyInto(out *Manifest) {
+func (in *KafkaConfiguration) DeepCopyInto(out *KafkaConfiguration) {
 	*out = *in
-	return
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Producer != nil {
+		in, out := &in.Producer, &out.Producer
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Consumer != nil {
+		in, out := &in.Consumer, &out.Consumer
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfiguration.
+func (in *KafkaConfiguration) DeepCopy() *KafkaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSourceConfiguration) DeepCopyInto(out *KafkaSourceConfiguration) {
+	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(KafkaSourceDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceConfiguration.
+func (in *KafkaSourceConfiguration) DeepCopy() *KafkaSourceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSourceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSourceDefaults) DeepCopyInto(out *KafkaSourceDefaults) {
+	*out = *in
+	if in.MinScale != nil {
+		in, out := &in.MinScale, &out.MinScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxScale != nil {
+		in, out := &in.MaxScale, &out.MaxScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KafkaLagThreshold != nil {
+		in, out := &in.KafkaLagThreshold, &out.KafkaLagThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceDefaults.
+func (in *KafkaSourceDefaults) DeepCopy() *KafkaSourceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSourceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeylessVerification) DeepCopyInto(out *KeylessVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeylessVerification.
+func (in *KeylessVerification) DeepCopy() *KeylessVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(KeylessVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KourierExtAuthzConfiguration) DeepCopyInto(out *KourierExtAuthzConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KourierExtAuthzConfiguration.
+func (in *KourierExtAuthzConfiguration) DeepCopy() *KourierExtAuthzConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KourierExtAuthzConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KourierIngressConfiguration) DeepCopyInto(out *KourierIngressConfiguration) {
+	*out = *in
+	if in.ExtAuthz != nil {
+		in, out := &in.ExtAuthz, &out.ExtAuthz
+		*out = new(KourierExtAuthzConfiguration)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(KourierTracingConfiguration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KourierIngressConfiguration.
+func (in *KourierIngressConfiguration) DeepCopy() *KourierIngressConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KourierIngressConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KourierTracingConfiguration) DeepCopyInto(out *KourierTracingConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KourierTracingConfiguration.
+func (in *KourierTracingConfiguration) DeepCopy() *KourierTracingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KourierTracingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Manifest) DeepCopyInto(out *Manifest) {
+	*out = *in
+	return
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manifest.
@@ -526,6 +1098,27 @@ func (in *Manifest) DeepCopy() *Manifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestVerification) DeepCopyInto(out *ManifestVerification) {
+	*out = *in
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(KeylessVerification)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestVerification.
+func (in *ManifestVerification) DeepCopy() *ManifestVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceConfiguration) DeepCopyInto(out *NamespaceConfiguration) {
 	*out = *in
@@ -626,9 +1219,51 @@ func (in *PrometheusSourceConfiguration) DeepCopy() *PrometheusSourceConfigurati
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqBrokerConfiguration) DeepCopyInto(out *RabbitmqBrokerConfiguration) {
+	*out = *in
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(RabbitmqClusterReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqBrokerConfiguration.
+func (in *RabbitmqBrokerConfiguration) DeepCopy() *RabbitmqBrokerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqBrokerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqClusterReference) DeepCopyInto(out *RabbitmqClusterReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqClusterReference.
+func (in *RabbitmqClusterReference) DeepCopy() *RabbitmqClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitmqSourceConfiguration) DeepCopyInto(out *RabbitmqSourceConfiguration) {
 	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -645,6 +1280,16 @@ func (in *RabbitmqSourceConfiguration) DeepCopy() *RabbitmqSourceConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisSourceConfiguration) DeepCopyInto(out *RedisSourceConfiguration) {
 	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(SourceWorkloadConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(RedisSourceDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -658,6 +1303,27 @@ func (in *RedisSourceConfiguration) DeepCopy() *RedisSourceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSourceDefaults) DeepCopyInto(out *RedisSourceDefaults) {
+	*out = *in
+	if in.NumConsumers != nil {
+		in, out := &in.NumConsumers, &out.NumConsumers
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSourceDefaults.
+func (in *RedisSourceDefaults) DeepCopy() *RedisSourceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSourceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -673,6 +1339,11 @@ func (in *Registry) DeepCopyInto(out *Registry) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryMirror, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -686,6 +1357,38 @@ func (in *Registry) DeepCopy() *Registry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedImage) DeepCopyInto(out *ResolvedImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedImage.
+func (in *ResolvedImage) DeepCopy() *ResolvedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRequirementsOverride) DeepCopyInto(out *ResourceRequirementsOverride) {
 	*out = *in
@@ -703,6 +1406,28 @@ func (in *ResourceRequirementsOverride) DeepCopy() *ResourceRequirementsOverride
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.UpgradeStartTime.DeepCopyInto(&out.UpgradeStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGuardConfiguration) DeepCopyInto(out *SecurityGuardConfiguration) {
 	*out = *in
@@ -756,6 +1481,92 @@ func (in *ServiceOverride) DeepCopy() *ServiceOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceConfiguration) DeepCopyInto(out *SourceConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceConfiguration.
+func (in *SourceConfiguration) DeepCopy() *SourceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceWorkloadConfiguration) DeepCopyInto(out *SourceWorkloadConfiguration) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceRequirementsOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceWorkloadConfiguration.
+func (in *SourceWorkloadConfiguration) DeepCopy() *SourceWorkloadConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceWorkloadConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadOverride) DeepCopyInto(out *WorkloadOverride) {
 	*out = *in
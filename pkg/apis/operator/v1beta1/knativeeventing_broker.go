@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+const (
+	// MTChannelBrokerClass is the class of the channel-based brokers of Knative Eventing.
+	MTChannelBrokerClass = "MTChannelBasedBroker"
+	// KafkaBrokerClass is the class of the brokers of eventing-kafka-broker.
+	KafkaBrokerClass = "Kafka"
+	// RabbitmqBrokerClass is the class of the brokers of eventing-rabbitmq.
+	RabbitmqBrokerClass = "RabbitMQBroker"
+)
+
+// KafkaBrokerEnabled returns true if the Kafka broker is installed.
+func (kes *KnativeEventingSpec) KafkaBrokerEnabled() bool {
+	return kes.Broker != nil && kes.Broker.Kafka.Enabled
+}
+
+// RabbitmqBrokerEnabled returns true if the RabbitMQ broker is installed.
+func (kes *KnativeEventingSpec) RabbitmqBrokerEnabled() bool {
+	return kes.Broker != nil && kes.Broker.Rabbitmq.Enabled
+}
+
+// KafkaChannelEnabled returns true if the KafkaChannel is installed.
+func (kes *KnativeEventingSpec) KafkaChannelEnabled() bool {
+	return kes.Channel != nil && kes.Channel.Kafka.Enabled
+}
+
+// InstalledBrokerClasses returns the broker classes installed by the KnativeEventing, which the
+// defaults of the brokers may refer to.
+func (kes *KnativeEventingSpec) InstalledBrokerClasses() []string {
+	classes := []string{MTChannelBrokerClass}
+	if kes.KafkaBrokerEnabled() {
+		classes = append(classes, KafkaBrokerClass)
+	}
+	if kes.RabbitmqBrokerEnabled() {
+		classes = append(classes, RabbitmqBrokerClass)
+	}
+	return classes
+}
+
+// InstalledChannels returns the API versions of the channel implementations installed by the
+// KnativeEventing, keyed by kind, which the default channel implementations may refer to.
+func (kes *KnativeEventingSpec) InstalledChannels() map[string]string {
+	channels := map[string]string{"InMemoryChannel": "messaging.knative.dev/v1"}
+	if kes.KafkaChannelEnabled() {
+		channels["KafkaChannel"] = "messaging.knative.dev/v1beta1"
+	}
+	return channels
+}
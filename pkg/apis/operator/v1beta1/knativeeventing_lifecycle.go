@@ -155,3 +155,74 @@ func (es *KnativeEventingStatus) GetManifests() []string {
 func (es *KnativeEventingStatus) SetManifests(manifests []string) {
 	es.Manifests = manifests
 }
+
+// GetUpgrade gets the progress of the upgrade across multiple minor versions.
+func (es *KnativeEventingStatus) GetUpgrade() *base.UpgradeStatus {
+	return es.Upgrade
+}
+
+// SetUpgrade sets the progress of the upgrade across multiple minor versions.
+func (es *KnativeEventingStatus) SetUpgrade(upgrade *base.UpgradeStatus) {
+	es.Upgrade = upgrade
+}
+
+// GetRollback gets the release to roll back to, if the upgrade does not become ready.
+func (es *KnativeEventingStatus) GetRollback() *base.RollbackStatus {
+	return es.Rollback
+}
+
+// SetRollback sets the release to roll back to, if the upgrade does not become ready.
+func (es *KnativeEventingStatus) SetRollback(rollback *base.RollbackStatus) {
+	es.Rollback = rollback
+}
+
+// GetHistory gets the releases successfully installed, the most recent first.
+func (es *KnativeEventingStatus) GetHistory() []base.InstallRecord {
+	return es.History
+}
+
+// SetHistory sets the releases successfully installed, the most recent first.
+func (es *KnativeEventingStatus) SetHistory(history []base.InstallRecord) {
+	es.History = history
+}
+
+// GetResolvedImages gets the digests the images have been pinned to.
+func (es *KnativeEventingStatus) GetResolvedImages() []base.ResolvedImage {
+	return es.ResolvedImages
+}
+
+// SetResolvedImages sets the digests the images have been pinned to.
+func (es *KnativeEventingStatus) SetResolvedImages(images []base.ResolvedImage) {
+	es.ResolvedImages = images
+}
+
+// MarkRolledBack marks the RolledBack status as true with the given message.
+func (es *KnativeEventingStatus) MarkRolledBack(msg string) {
+	eventingCondSet.Manage(es).MarkTrueWithReason(base.RolledBack, "ProgressDeadlineExceeded", "%s", msg)
+}
+
+// ClearRolledBack removes the RolledBack status.
+func (es *KnativeEventingStatus) ClearRolledBack() {
+	_ = eventingCondSet.Manage(es).ClearCondition(base.RolledBack)
+}
+
+// MarkResourcesInSync marks the ResourcesInSync status as true.
+func (es *KnativeEventingStatus) MarkResourcesInSync() {
+	eventingCondSet.Manage(es).MarkTrue(base.ResourcesInSync)
+}
+
+// MarkResourcesDriftCorrected marks the ResourcesInSync status as true, after the drifted
+// resources have been applied again.
+func (es *KnativeEventingStatus) MarkResourcesDriftCorrected(msg string) {
+	eventingCondSet.Manage(es).MarkTrueWithReason(base.ResourcesInSync, "DriftCorrected", "%s", msg)
+}
+
+// MarkResourcesDrifted marks the ResourcesInSync status as false with the given message.
+func (es *KnativeEventingStatus) MarkResourcesDrifted(msg string) {
+	eventingCondSet.Manage(es).MarkFalse(base.ResourcesInSync, "ResourcesDrifted", "%s", msg)
+}
+
+// ClearResourcesInSync removes the ResourcesInSync status.
+func (es *KnativeEventingStatus) ClearResourcesInSync() {
+	_ = eventingCondSet.Manage(es).ClearCondition(base.ResourcesInSync)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// typedSources are the names of the sources with typed fields in SourceConfigs.
+var typedSources = []string{"ceph", "github", "gitlab", "kafka", "rabbitmq", "redis"}
+
+// sourceConfigs has the fields of SourceConfigs without its JSON methods.
+type sourceConfigs SourceConfigs
+
+// UnmarshalJSON reads the typed sources into their fields, and the others into Catalog.
+func (sc *SourceConfigs) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*sourceConfigs)(sc)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, name := range typedSources {
+		delete(all, name)
+	}
+	sc.Catalog = nil
+	for name, raw := range all {
+		var config base.SourceConfiguration
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return fmt.Errorf("source %s: %w", name, err)
+		}
+		if sc.Catalog == nil {
+			sc.Catalog = make(map[string]base.SourceConfiguration, len(all))
+		}
+		sc.Catalog[name] = config
+	}
+	return nil
+}
+
+// MarshalJSON writes the sources of Catalog next to the typed sources.
+func (sc SourceConfigs) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(sourceConfigs(sc))
+	if err != nil || len(sc.Catalog) == 0 {
+		return data, err
+	}
+	all := map[string]interface{}{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name, config := range sc.Catalog {
+		if _, ok := all[name]; !ok {
+			all[name] = config
+		}
+	}
+	return json.Marshal(all)
+}
+
+// EnabledSources returns the sorted names of the enabled sources, typed or of the catalog.
+func (sc *SourceConfigs) EnabledSources() []string {
+	if sc == nil {
+		return nil
+	}
+	var names []string
+	for name, enabled := range map[string]bool{
+		"ceph":     sc.Ceph.Enabled,
+		"github":   sc.Github.Enabled,
+		"gitlab":   sc.Gitlab.Enabled,
+		"kafka":    sc.Kafka.Enabled,
+		"rabbitmq": sc.Rabbitmq.Enabled,
+		"redis":    sc.Redis.Enabled,
+	} {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	for name, config := range sc.Catalog {
+		if config.Enabled && !isTypedSource(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isTypedSource(name string) bool {
+	for _, n := range typedSources {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
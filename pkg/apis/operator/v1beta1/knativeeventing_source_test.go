@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestSourceConfigsJSON(t *testing.T) {
+	data := []byte(`{"kafka":{"enabled":true,"features":{"dispatcher-rate-limiter":"enabled"}},"prometheus":{"enabled":true},"awssqs":{"enabled":false}}`)
+	sc := &SourceConfigs{}
+	if err := json.Unmarshal(data, sc); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, sc.Kafka.Enabled, true)
+	util.AssertDeepEqual(t, sc.Kafka.Features, map[string]string{"dispatcher-rate-limiter": "enabled"})
+	util.AssertDeepEqual(t, sc.Catalog, map[string]base.SourceConfiguration{
+		"prometheus": {Enabled: true},
+		"awssqs":     {Enabled: false},
+	})
+	util.AssertDeepEqual(t, sc.EnabledSources(), []string{"kafka", "prometheus"})
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip := &SourceConfigs{}
+	if err := json.Unmarshal(data, roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertDeepEqual(t, roundTrip, sc)
+
+	if err := json.Unmarshal([]byte(`{"natss":{"enabled":"yes"}}`), &SourceConfigs{}); err == nil {
+		t.Error("Unmarshal() of an invalid catalog source succeeded")
+	}
+}
+
+func TestEnabledSourcesWithoutSource(t *testing.T) {
+	var sc *SourceConfigs
+	util.AssertEqual(t, len(sc.EnabledSources()), 0)
+}
@@ -59,7 +59,9 @@ type KnativeEventingSpec struct {
 	base.CommonSpec `json:",inline"`
 
 	// The default broker type to use for the brokers Knative creates.
-	// If no value is provided, MTChannelBasedBroker will be used.
+	// If no value is provided, Kafka will be used when spec.broker.kafka is enabled,
+	// RabbitMQBroker when spec.broker.rabbitmq is enabled with a cluster reference, and
+	// MTChannelBasedBroker otherwise. The class must be installed by the KnativeEventing.
 	// +optional
 	DefaultBrokerClass string `json:"defaultBrokerClass,omitempty"`
 
@@ -76,7 +78,16 @@ type KnativeEventingSpec struct {
 
 	// Source allows configuration of different eventing sources to be shipped.
 	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Source *SourceConfigs `json:"source,omitempty"`
+
+	// Broker allows installing broker implementations, besides the channel based broker.
+	// +optional
+	Broker *BrokerConfigs `json:"broker,omitempty"`
+
+	// Channel allows installing channel implementations, besides the in-memory channel.
+	// +optional
+	Channel *ChannelConfigs `json:"channel,omitempty"`
 }
 
 // KnativeEventingStatus defines the observed state of KnativeEventing
@@ -90,6 +101,22 @@ type KnativeEventingStatus struct {
 	// The url links of the manifests, separated by comma
 	// +optional
 	Manifests []string `json:"manifests,omitempty"`
+
+	// The progress of an upgrade across multiple minor versions
+	// +optional
+	Upgrade *base.UpgradeStatus `json:"upgrade,omitempty"`
+
+	// The release to roll back to, if an upgrade does not become ready
+	// +optional
+	Rollback *base.RollbackStatus `json:"rollback,omitempty"`
+
+	// The releases successfully installed, the most recent first
+	// +optional
+	History []base.InstallRecord `json:"history,omitempty"`
+
+	// The digests the images have been pinned to, when spec.registry.pinDigests is set
+	// +optional
+	ResolvedImages []base.ResolvedImage `json:"resolvedImages,omitempty"`
 }
 
 // KnativeEventingList contains a list of KnativeEventing
@@ -101,7 +128,28 @@ type KnativeEventingList struct {
 	Items           []KnativeEventing `json:"items"`
 }
 
-// SourceConfigs specifies options for the eventing sources.
+// BrokerConfigs specifies options for the broker implementations.
+type BrokerConfigs struct {
+	Kafka    base.KafkaBrokerConfiguration    `json:"kafka"`
+	Rabbitmq base.RabbitmqBrokerConfiguration `json:"rabbitmq"`
+
+	// Defaults are the defaults of the brokers in config-br-defaults.
+	// +optional
+	Defaults *base.BrokerDefaults `json:"defaults,omitempty"`
+}
+
+// ChannelConfigs specifies options for the channel implementations.
+type ChannelConfigs struct {
+	Kafka base.KafkaChannelConfiguration `json:"kafka"`
+
+	// Defaults are the default channel implementations in default-ch-webhook.
+	// +optional
+	Defaults *base.ChannelDefaults `json:"defaults,omitempty"`
+}
+
+// SourceConfigs specifies options for the eventing sources. Besides the sources with typed options,
+// every source of the catalog, kodata/eventing-source/<version>/<name>, is enabled by
+// <name>.enabled.
 type SourceConfigs struct {
 	Ceph     base.CephSourceConfiguration     `json:"ceph"`
 	Github   base.GithubSourceConfiguration   `json:"github"`
@@ -109,4 +157,8 @@ type SourceConfigs struct {
 	Kafka    base.KafkaSourceConfiguration    `json:"kafka"`
 	Rabbitmq base.RabbitmqSourceConfiguration `json:"rabbitmq"`
 	Redis    base.RedisSourceConfiguration    `json:"redis"`
+
+	// Catalog holds the sources of the catalog without typed options, keyed by name. They are
+	// (un)marshalled as the other fields of spec.source.
+	Catalog map[string]base.SourceConfiguration `json:"-"`
 }
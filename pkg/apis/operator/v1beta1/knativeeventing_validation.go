@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+var _ apis.Validatable = (*KnativeEventing)(nil)
+
+// Validate implements apis.Validatable
+func (ke *KnativeEventing) Validate(ctx context.Context) *apis.FieldError {
+	if !ke.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if apis.IsInUpdate(ctx) {
+		// Updates that leave the spec untouched, e.g. finalizer changes, are always allowed, so that
+		// objects created before a check was introduced can still be reconciled and deleted.
+		if old, ok := apis.GetBaseline(ctx).(*KnativeEventing); ok && equality.Semantic.DeepEqual(old.Spec, ke.Spec) {
+			return nil
+		}
+	}
+	return ke.Spec.Validate(ctx, ke).ViaField("spec")
+}
+
+// Validate validates the spec of the given KnativeEventing.
+func (kes *KnativeEventingSpec) Validate(ctx context.Context, ke *KnativeEventing) *apis.FieldError {
+	switch kes.SinkBindingSelectionMode {
+	case "", "inclusion", "exclusion":
+	default:
+		return apis.ErrInvalidValue(kes.SinkBindingSelectionMode, "sinkBindingSelectionMode",
+			"must be inclusion or exclusion")
+	}
+	var errs *apis.FieldError
+	if kes.DefaultBrokerClass != "" {
+		errs = kes.validateBrokerClass(kes.DefaultBrokerClass).ViaField("defaultBrokerClass")
+	}
+	if kes.Source != nil {
+		errs = errs.Also(kes.Source.validate().ViaField("source"))
+	}
+	if kes.Broker != nil {
+		errs = errs.Also(kes.Broker.validate().ViaField("broker"))
+		errs = errs.Also(kes.validateBrokerDefaults(ctx).ViaField("broker", "defaults"))
+	}
+	if kes.Channel != nil {
+		errs = errs.Also(validateKafka(&kes.Channel.Kafka.KafkaConfiguration).ViaField("channel", "kafka"))
+		errs = errs.Also(kes.validateChannelDefaults().ViaField("channel", "defaults"))
+	}
+	return errs.Also(kes.ValidateCommonSpec(ctx, ke))
+}
+
+func (sc *SourceConfigs) validate() *apis.FieldError {
+	var errs *apis.FieldError
+	for _, w := range []struct {
+		path     []string
+		workload *base.SourceWorkloadConfiguration
+	}{
+		{[]string{"ceph", "controller"}, sc.Ceph.Controller},
+		{[]string{"github", "controller"}, sc.Github.Controller},
+		{[]string{"gitlab", "controller"}, sc.Gitlab.Controller},
+		{[]string{"kafka", "controller"}, sc.Kafka.Controller},
+		{[]string{"kafka", "dispatcher"}, sc.Kafka.Dispatcher},
+		{[]string{"rabbitmq", "controller"}, sc.Rabbitmq.Controller},
+		{[]string{"redis", "controller"}, sc.Redis.Controller},
+	} {
+		if w.workload != nil && w.workload.Replicas != nil && *w.workload.Replicas < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*w.workload.Replicas, "replicas", "must not be negative").ViaField(w.path...))
+		}
+	}
+	if d := sc.Kafka.Defaults; d != nil && d.MinScale != nil && d.MaxScale != nil && *d.MinScale > *d.MaxScale {
+		errs = errs.Also(apis.ErrGeneric("minScale must not be greater than maxScale",
+			"minScale", "maxScale").ViaField("kafka", "defaults"))
+	}
+	for name := range sc.Catalog {
+		// The name is the name of a kodata directory of the catalog.
+		if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, apis.CurrentField, msgs...))
+		} else if isTypedSource(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, apis.CurrentField, "is a source with typed options"))
+		}
+	}
+	return errs
+}
+
+func (bc *BrokerConfigs) validate() *apis.FieldError {
+	errs := validateKafka(&bc.Kafka.KafkaConfiguration)
+	for field, value := range map[string]*int32{
+		"numPartitions":     bc.Kafka.NumPartitions,
+		"replicationFactor": bc.Kafka.ReplicationFactor,
+	} {
+		if value != nil && *value < 1 {
+			errs = errs.Also(apis.ErrInvalidValue(*value, field, "must be positive"))
+		}
+	}
+	errs = errs.ViaField("kafka")
+	if ref := bc.Rabbitmq.ClusterRef; ref != nil {
+		switch {
+		case ref.Name == "" && ref.ConnectionSecret == "":
+			errs = errs.Also(apis.ErrMissingOneOf("name", "connectionSecret").ViaField("rabbitmq", "clusterRef"))
+		case ref.Name != "" && ref.ConnectionSecret != "":
+			errs = errs.Also(apis.ErrMultipleOneOf("name", "connectionSecret").ViaField("rabbitmq", "clusterRef"))
+		}
+	}
+	switch bc.Rabbitmq.QueueType {
+	case "", "quorum", "classic":
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(bc.Rabbitmq.QueueType, "queueType", "must be quorum or classic").
+			ViaField("rabbitmq"))
+	}
+	return errs
+}
+
+// validateBrokerDefaults validates the delivery of the brokers, and that the namespaces default to
+// installed broker classes.
+func (kes *KnativeEventingSpec) validateBrokerDefaults(ctx context.Context) *apis.FieldError {
+	d := kes.Broker.Defaults
+	if d == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if delivery, err := d.Delivery.DeliverySpec(); err != nil {
+		errs = apis.ErrInvalidValue(d.Delivery.DeadLetterSink.URI, "uri", err.Error()).
+			ViaField("delivery", "deadLetterSink")
+	} else {
+		errs = delivery.Validate(ctx).ViaField("delivery")
+	}
+	for namespace, class := range d.NamespaceBrokerClasses {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(namespace, "namespaceBrokerClasses", msgs...))
+		} else {
+			errs = errs.Also(kes.validateBrokerClass(class).ViaFieldKey("namespaceBrokerClasses", namespace))
+		}
+	}
+	return errs
+}
+
+// validateBrokerClass validates that the broker class is installed.
+func (kes *KnativeEventingSpec) validateBrokerClass(class string) *apis.FieldError {
+	classes := kes.InstalledBrokerClasses()
+	if slices.Contains(classes, class) {
+		return nil
+	}
+	return apis.ErrInvalidValue(class, apis.CurrentField, "must be an installed broker class: "+strings.Join(classes, ", "))
+}
+
+// validateChannelDefaults validates that the default channel implementations are installed.
+func (kes *KnativeEventingSpec) validateChannelDefaults() *apis.FieldError {
+	d := kes.Channel.Defaults
+	if d == nil {
+		return nil
+	}
+	channels := kes.InstalledChannels()
+	kinds := make([]string, 0, len(channels))
+	for kind := range channels {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	validate := func(template base.ChannelTemplate) *apis.FieldError {
+		if _, ok := channels[template.Kind]; !ok {
+			return apis.ErrInvalidValue(template.Kind, "kind",
+				"must be an installed channel implementation: "+strings.Join(kinds, ", "))
+		}
+		return nil
+	}
+	var errs *apis.FieldError
+	if d.Cluster != nil {
+		errs = validate(*d.Cluster).ViaField("cluster")
+	}
+	for namespace, template := range d.Namespaces {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(namespace, "namespaces", msgs...))
+		} else {
+			errs = errs.Also(validate(template).ViaFieldKey("namespaces", namespace))
+		}
+	}
+	return errs
+}
+
+// validateKafka validates the options shared by the Kafka broker and channel.
+func validateKafka(kc *base.KafkaConfiguration) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, server := range kc.BootstrapServers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(server, apis.CurrentField, "must be host:port").
+				ViaFieldIndex("bootstrapServers", i))
+		}
+	}
+	return errs
+}
+
+// SetDefaults implements apis.Defaultable
+func (ke *KnativeEventing) SetDefaults(context.Context) {}
+
+// SupportedSubResources limits the validation to the main resource, so that the status
+// updates of the reconciler are never rejected.
+func (ke *KnativeEventing) SupportedSubResources() []string {
+	return []string{""}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestKnativeEventingValidate(t *testing.T) {
+	resources := &base.TargetResources{
+		ConfigMaps: sets.NewString("config-br-defaults"),
+		Workloads:  sets.NewString("eventing-controller", "eventing-webhook"),
+	}
+	tests := []struct {
+		name     string
+		spec     KnativeEventingSpec
+		expected string
+	}{{
+		name: "valid spec",
+		spec: KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Config: base.ConfigMapData{
+					"br-defaults": {"default-br-config": ""},
+				},
+				Workloads: []base.WorkloadOverride{{Name: "eventing-webhook"}},
+			},
+			SinkBindingSelectionMode: "inclusion",
+		},
+	}, {
+		name: "invalid sink binding selection mode",
+		spec: KnativeEventingSpec{
+			SinkBindingSelectionMode: "all",
+		},
+		expected: "invalid value: all: spec.sinkBindingSelectionMode\nmust be inclusion or exclusion",
+	}, {
+		name: "invalid registry override",
+		spec: KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Registry: base.Registry{
+					Override: map[string]string{
+						"eventing-webhook": "Registry.example.com/Webhook:latest",
+					},
+				},
+			},
+		},
+		expected: "invalid value: Registry.example.com/Webhook:latest: spec.registry.override[eventing-webhook]\n" +
+			"could not parse reference: Registry.example.com/Webhook:latest",
+	}, {
+		name: "unknown workload",
+		spec: KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Workloads: []base.WorkloadOverride{{Name: "eventing-webhook"}, {Name: "imc-controller"}},
+			},
+		},
+		expected: "invalid value: imc-controller: spec.workloads[1].name\nno workload with this name exists in the target manifest",
+	}, {
+		name: "invalid source options",
+		spec: KnativeEventingSpec{
+			Source: &SourceConfigs{
+				Kafka: base.KafkaSourceConfiguration{
+					Enabled:    true,
+					Dispatcher: &base.SourceWorkloadConfiguration{Replicas: ptr.To(int32(-1))},
+					Defaults:   &base.KafkaSourceDefaults{MinScale: ptr.To(int32(5)), MaxScale: ptr.To(int32(1))},
+				},
+			},
+		},
+		expected: "invalid value: -1: spec.source.kafka.dispatcher.replicas\nmust not be negative\n" +
+			"minScale must not be greater than maxScale: spec.source.kafka.defaults.maxScale, spec.source.kafka.defaults.minScale",
+	}, {
+		name: "invalid kafka broker and channel options",
+		spec: KnativeEventingSpec{
+			Broker: &BrokerConfigs{
+				Kafka: base.KafkaBrokerConfiguration{
+					Enabled:            true,
+					KafkaConfiguration: base.KafkaConfiguration{BootstrapServers: []string{"kafka:9092"}},
+					ReplicationFactor:  ptr.To(int32(0)),
+				},
+			},
+			Channel: &ChannelConfigs{
+				Kafka: base.KafkaChannelConfiguration{
+					Enabled:            true,
+					KafkaConfiguration: base.KafkaConfiguration{BootstrapServers: []string{"kafka:9092", "kafka"}},
+				},
+			},
+		},
+		expected: "invalid value: 0: spec.broker.kafka.replicationFactor\nmust be positive\n" +
+			"invalid value: kafka: spec.channel.kafka.bootstrapServers[1]\nmust be host:port",
+	}, {
+		name: "invalid rabbitmq broker options",
+		spec: KnativeEventingSpec{
+			Broker: &BrokerConfigs{
+				Rabbitmq: base.RabbitmqBrokerConfiguration{
+					Enabled:    true,
+					ClusterRef: &base.RabbitmqClusterReference{Namespace: "rabbitmq"},
+					QueueType:  "stream",
+				},
+			},
+		},
+		expected: "expected exactly one, got neither: spec.broker.rabbitmq.clusterRef.connectionSecret, spec.broker.rabbitmq.clusterRef.name\n" +
+			"invalid value: stream: spec.broker.rabbitmq.queueType\nmust be quorum or classic",
+	}, {
+		name: "invalid broker defaults",
+		spec: KnativeEventingSpec{
+			Broker: &BrokerConfigs{
+				Kafka: base.KafkaBrokerConfiguration{Enabled: true},
+				Defaults: &base.BrokerDefaults{
+					Delivery: &base.BrokerDelivery{
+						Retry:          ptr.To(int32(-1)),
+						BackoffDelay:   "200ms",
+						DeadLetterSink: &base.DeadLetterSink{URI: "/dead-letters"},
+					},
+					NamespaceBrokerClasses: map[string]string{"kafka": "Kafka", "rabbitmq": "RabbitMQBroker"},
+				},
+			},
+		},
+		expected: "invalid value: -1: spec.broker.defaults.delivery.retry\n" +
+			"invalid value: 200ms: spec.broker.defaults.delivery.backoffDelay\n" +
+			"invalid value: RabbitMQBroker: spec.broker.defaults.namespaceBrokerClasses[rabbitmq]\n" +
+			"must be an installed broker class: MTChannelBasedBroker, Kafka\n" +
+			"invalid value: Relative URI is not allowed when Ref and [apiVersion, kind, name] is absent: " +
+			"spec.broker.defaults.delivery.deadLetterSink.uri",
+	}, {
+		name: "default broker class of a broker, which is not installed",
+		spec: KnativeEventingSpec{
+			DefaultBrokerClass: "Kafka",
+		},
+		expected: "invalid value: Kafka: spec.defaultBrokerClass\n" +
+			"must be an installed broker class: MTChannelBasedBroker",
+	}, {
+		name: "default broker class of an installed broker",
+		spec: KnativeEventingSpec{
+			DefaultBrokerClass: "RabbitMQBroker",
+			Broker:             &BrokerConfigs{Rabbitmq: base.RabbitmqBrokerConfiguration{Enabled: true}},
+		},
+	}, {
+		name: "invalid channel defaults",
+		spec: KnativeEventingSpec{
+			Channel: &ChannelConfigs{
+				Defaults: &base.ChannelDefaults{
+					Cluster: &base.ChannelTemplate{Kind: "InMemoryChannel"},
+					Namespaces: map[string]base.ChannelTemplate{
+						"kafka":   {Kind: "KafkaChannel"},
+						"Default": {Kind: "InMemoryChannel"},
+					},
+				},
+			},
+		},
+		expected: `invalid key name "Default": spec.channel.defaults.namespaces` + "\n" +
+			"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')\n" +
+			"invalid value: KafkaChannel: spec.channel.defaults.namespaces[kafka].kind\n" +
+			"must be an installed channel implementation: InMemoryChannel",
+	}, {
+		name: "invalid catalog source",
+		spec: KnativeEventingSpec{
+			Source: &SourceConfigs{
+				Catalog: map[string]base.SourceConfiguration{"AWS_SQS": {Enabled: true}},
+			},
+		},
+		expected: `invalid key name "AWS_SQS": spec.source` + "\n" +
+			"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := base.WithReleaseInspector(context.Background(), fakeInspector{resources: resources})
+			ke := &KnativeEventing{Spec: tt.spec}
+			err := ke.Validate(ctx)
+			util.AssertEqual(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -155,3 +155,74 @@ func (is *KnativeServingStatus) GetManifests() []string {
 func (is *KnativeServingStatus) SetManifests(manifests []string) {
 	is.Manifests = manifests
 }
+
+// GetUpgrade gets the progress of the upgrade across multiple minor versions.
+func (is *KnativeServingStatus) GetUpgrade() *base.UpgradeStatus {
+	return is.Upgrade
+}
+
+// SetUpgrade sets the progress of the upgrade across multiple minor versions.
+func (is *KnativeServingStatus) SetUpgrade(upgrade *base.UpgradeStatus) {
+	is.Upgrade = upgrade
+}
+
+// GetRollback gets the release to roll back to, if the upgrade does not become ready.
+func (is *KnativeServingStatus) GetRollback() *base.RollbackStatus {
+	return is.Rollback
+}
+
+// SetRollback sets the release to roll back to, if the upgrade does not become ready.
+func (is *KnativeServingStatus) SetRollback(rollback *base.RollbackStatus) {
+	is.Rollback = rollback
+}
+
+// GetHistory gets the releases successfully installed, the most recent first.
+func (is *KnativeServingStatus) GetHistory() []base.InstallRecord {
+	return is.History
+}
+
+// SetHistory sets the releases successfully installed, the most recent first.
+func (is *KnativeServingStatus) SetHistory(history []base.InstallRecord) {
+	is.History = history
+}
+
+// GetResolvedImages gets the digests the images have been pinned to.
+func (is *KnativeServingStatus) GetResolvedImages() []base.ResolvedImage {
+	return is.ResolvedImages
+}
+
+// SetResolvedImages sets the digests the images have been pinned to.
+func (is *KnativeServingStatus) SetResolvedImages(images []base.ResolvedImage) {
+	is.ResolvedImages = images
+}
+
+// MarkRolledBack marks the RolledBack status as true with the given message.
+func (is *KnativeServingStatus) MarkRolledBack(msg string) {
+	servingCondSet.Manage(is).MarkTrueWithReason(base.RolledBack, "ProgressDeadlineExceeded", "%s", msg)
+}
+
+// ClearRolledBack removes the RolledBack status.
+func (is *KnativeServingStatus) ClearRolledBack() {
+	_ = servingCondSet.Manage(is).ClearCondition(base.RolledBack)
+}
+
+// MarkResourcesInSync marks the ResourcesInSync status as true.
+func (is *KnativeServingStatus) MarkResourcesInSync() {
+	servingCondSet.Manage(is).MarkTrue(base.ResourcesInSync)
+}
+
+// MarkResourcesDriftCorrected marks the ResourcesInSync status as true, after the drifted
+// resources have been applied again.
+func (is *KnativeServingStatus) MarkResourcesDriftCorrected(msg string) {
+	servingCondSet.Manage(is).MarkTrueWithReason(base.ResourcesInSync, "DriftCorrected", "%s", msg)
+}
+
+// MarkResourcesDrifted marks the ResourcesInSync status as false with the given message.
+func (is *KnativeServingStatus) MarkResourcesDrifted(msg string) {
+	servingCondSet.Manage(is).MarkFalse(base.ResourcesInSync, "ResourcesDrifted", "%s", msg)
+}
+
+// ClearResourcesInSync removes the ResourcesInSync status.
+func (is *KnativeServingStatus) ClearResourcesInSync() {
+	_ = servingCondSet.Manage(is).ClearCondition(base.ResourcesInSync)
+}
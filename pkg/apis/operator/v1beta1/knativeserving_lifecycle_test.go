@@ -154,6 +154,31 @@ func TestKnativeServingVersionMigrationNotEligible(t *testing.T) {
 	apistest.CheckConditionFailed(ks, base.VersionMigrationEligible, t)
 }
 
+func TestKnativeServingResourcesInSync(t *testing.T) {
+	ks := &KnativeServingStatus{}
+	ks.InitializeConditions()
+	ks.MarkVersionMigrationEligible()
+	ks.MarkDependenciesInstalled()
+	ks.MarkInstallSucceeded()
+	ks.MarkDeploymentsAvailable()
+	ks.MarkTargetClusterResolved()
+
+	ks.MarkResourcesDrifted("Drifted resources: Deployment knative-serving/controller")
+	apistest.CheckConditionFailed(ks, base.ResourcesInSync, t)
+	// Drifted resources are reported without affecting the readiness.
+	if ready := ks.IsReady(); !ready {
+		t.Errorf("ks.IsReady() = %v, want true", ready)
+	}
+
+	ks.MarkResourcesDriftCorrected("Reapplied drifted resources: Deployment knative-serving/controller")
+	apistest.CheckConditionSucceeded(ks, base.ResourcesInSync, t)
+
+	ks.ClearResourcesInSync()
+	if cond := ks.GetCondition(base.ResourcesInSync); cond != nil {
+		t.Errorf("ResourcesInSync = %v, want nil", cond)
+	}
+}
+
 func TestKnativeServingTargetClusterTransitions(t *testing.T) {
 	t.Run("PreservesInstallSucceeded", func(t *testing.T) {
 		ks := &KnativeServingStatus{}
@@ -79,6 +79,22 @@ type KnativeServingStatus struct {
 	// The url links of the manifests, separated by comma
 	// +optional
 	Manifests []string `json:"manifests,omitempty"`
+
+	// The progress of an upgrade across multiple minor versions
+	// +optional
+	Upgrade *base.UpgradeStatus `json:"upgrade,omitempty"`
+
+	// The release to roll back to, if an upgrade does not become ready
+	// +optional
+	Rollback *base.RollbackStatus `json:"rollback,omitempty"`
+
+	// The releases successfully installed, the most recent first
+	// +optional
+	History []base.InstallRecord `json:"history,omitempty"`
+
+	// The digests the images have been pinned to, when spec.registry.pinDigests is set
+	// +optional
+	ResolvedImages []base.ResolvedImage `json:"resolvedImages,omitempty"`
 }
 
 // KnativeServingList contains a list of KnativeServing
@@ -100,6 +116,8 @@ type IngressConfigs struct {
 	Contour base.ContourIngressConfiguration `json:"contour,omitempty"`
 	// +optional
 	GatewayAPI base.GatewayAPIIngressConfiguration `json:"gateway-api,omitempty"`
+	// +optional
+	Custom base.CustomIngressConfiguration `json:"custom,omitempty"`
 }
 
 // SecurityConfigs specifies options for the security
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+var _ apis.Validatable = (*KnativeServing)(nil)
+
+// Validate implements apis.Validatable
+func (ks *KnativeServing) Validate(ctx context.Context) *apis.FieldError {
+	if !ks.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if apis.IsInUpdate(ctx) {
+		// Updates that leave the spec untouched, e.g. finalizer changes, are always allowed, so that
+		// objects created before a check was introduced can still be reconciled and deleted.
+		if old, ok := apis.GetBaseline(ctx).(*KnativeServing); ok && equality.Semantic.DeepEqual(old.Spec, ks.Spec) {
+			return nil
+		}
+	}
+	return ks.Spec.Validate(ctx, ks).ViaField("spec")
+}
+
+// Validate validates the spec of the given KnativeServing.
+func (kss *KnativeServingSpec) Validate(ctx context.Context, ks *KnativeServing) *apis.FieldError {
+	var errs *apis.FieldError
+	switch kss.ControllerCustomCerts.Type {
+	case "":
+	case "ConfigMap", "Secret":
+		if kss.ControllerCustomCerts.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaField("controller-custom-certs"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(kss.ControllerCustomCerts.Type, "type",
+			"must be ConfigMap or Secret").ViaField("controller-custom-certs"))
+	}
+	if kss.Ingress != nil {
+		errs = errs.Also(kss.Ingress.validate().ViaField("ingress"))
+	}
+	if errs != nil {
+		return errs
+	}
+	return kss.ValidateCommonSpec(ctx, ks)
+}
+
+func (ic *IngressConfigs) validate() *apis.FieldError {
+	var errs *apis.FieldError
+	if ic.Custom.Enabled {
+		if len(ic.Custom.Manifests) == 0 {
+			errs = errs.Also(apis.ErrMissingField("manifests").ViaField("custom"))
+		}
+		for i, m := range ic.Custom.Manifests {
+			if m.Url == "" {
+				errs = errs.Also(apis.ErrMissingField("URL").ViaFieldIndex("manifests", i).ViaField("custom"))
+			}
+		}
+	}
+	errs = errs.Also(validateIstioGateways(ic.Istio.Gateways).ViaField("istio"))
+	errs = errs.Also(validateKourier(&ic.Kourier).ViaField("kourier"))
+	errs = errs.Also(validateContour(&ic.Contour).ViaField("contour"))
+	if g := ic.GatewayAPI.ExternalGateway; g != nil {
+		errs = errs.Also(validateGateway(g).ViaField("gateway-api", "external-gateway"))
+	}
+	if g := ic.GatewayAPI.LocalGateway; g != nil {
+		errs = errs.Also(validateGateway(g).ViaField("gateway-api", "local-gateway"))
+	}
+	return errs
+}
+
+// builtInIstioGateways are the Gateways of net-istio, which the additional Gateways cannot replace.
+var builtInIstioGateways = sets.New("knative-ingress-gateway", "knative-local-gateway", "cluster-local-gateway")
+
+func validateIstioGateways(gateways []base.IstioGateway) *apis.FieldError {
+	var errs *apis.FieldError
+	names := sets.New[string]()
+	for i, g := range gateways {
+		var gErrs *apis.FieldError
+		switch {
+		case g.Name == "":
+			gErrs = gErrs.Also(apis.ErrMissingField("name"))
+		case builtInIstioGateways.Has(g.Name):
+			gErrs = gErrs.Also(apis.ErrInvalidValue(g.Name, "name", "must not be the name of a built-in gateway"))
+		case names.Has(g.Namespace + "/" + g.Name):
+			gErrs = gErrs.Also(apis.ErrGeneric("duplicate gateway "+g.Name, "name"))
+		}
+		names.Insert(g.Namespace + "/" + g.Name)
+		if g.Service == "" {
+			gErrs = gErrs.Also(apis.ErrMissingField("service"))
+		}
+		if g.LabelSelector == nil {
+			gErrs = gErrs.Also(apis.ErrMissingField("labelSelector"))
+		}
+		errs = errs.Also(gErrs.ViaFieldIndex("gateways", i))
+	}
+	return errs
+}
+
+func validateKourier(k *base.KourierIngressConfiguration) *apis.FieldError {
+	errs := validateNamespacedName(k.CertsSecret, "certs-secret", false)
+	if k.ExtAuthz != nil && k.ExtAuthz.Host == "" {
+		errs = errs.Also(apis.ErrMissingField("host").ViaField("extauthz"))
+	}
+	if t := k.Tracing; t != nil {
+		if t.Endpoint == "" {
+			errs = errs.Also(apis.ErrMissingField("endpoint").ViaField("tracing"))
+		}
+		if t.SamplingRate != "" {
+			if rate, err := strconv.ParseFloat(t.SamplingRate, 64); err != nil || rate < 0 || rate > 1 {
+				errs = errs.Also(apis.ErrInvalidValue(t.SamplingRate, "sampling-rate",
+					"must be a number between 0.0 and 1.0").ViaField("tracing"))
+			}
+		}
+	}
+	return errs
+}
+
+func validateContour(c *base.ContourIngressConfiguration) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, f := range []struct {
+		field string
+		v     *base.ContourVisibilityConfiguration
+	}{{"external", c.External}, {"internal", c.Internal}} {
+		field, v := f.field, f.v
+		if v == nil {
+			continue
+		}
+		errs = errs.Also(validateNamespacedName(v.Service, "service", false).ViaField(field))
+		switch v.ServiceType {
+		case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(v.ServiceType, "service-type",
+				"must be ClusterIP, NodePort or LoadBalancer").ViaField(field))
+		}
+	}
+	// The Envoy Services are customized by name, as they are in the namespace of the KnativeServing
+	// until the transformers of the plugin run.
+	if customized(c.External) && customized(c.Internal) &&
+		envoyServiceName(c.External, "contour-external/envoy") == envoyServiceName(c.Internal, "contour-internal/envoy") {
+		errs = errs.Also(apis.ErrGeneric("the Envoy Services of external and internal must have different names to be customized",
+			"external.service", "internal.service"))
+	}
+	errs = errs.Also(validateNamespacedName(c.DefaultTLSSecret, "default-tls-secret", false))
+	errs = errs.Also(validateTimeout(c.TimeoutPolicyIdle, "timeout-policy-idle"))
+	errs = errs.Also(validateTimeout(c.TimeoutPolicyResponse, "timeout-policy-response"))
+	return errs
+}
+
+// customized returns true if the visibility overrides the Envoy Service.
+func customized(v *base.ContourVisibilityConfiguration) bool {
+	return v != nil && (v.ServiceType != "" || len(v.ServiceAnnotations) > 0)
+}
+
+func envoyServiceName(v *base.ContourVisibilityConfiguration, def string) string {
+	service := def
+	if v.Service != "" {
+		service = v.Service
+	}
+	_, name, _ := strings.Cut(service, "/")
+	return name
+}
+
+func validateTimeout(value, field string) *apis.FieldError {
+	if value == "" || value == "infinity" {
+		return nil
+	}
+	if _, err := time.ParseDuration(value); err != nil {
+		return apis.ErrInvalidValue(value, field, "must be a duration or infinity")
+	}
+	return nil
+}
+
+func validateGateway(g *base.GatewayAPIGateway) *apis.FieldError {
+	var errs *apis.FieldError
+	if g.Class == "" {
+		errs = errs.Also(apis.ErrMissingField("class"))
+	}
+	errs = errs.Also(validateNamespacedName(g.Gateway, "gateway", true))
+	errs = errs.Also(validateNamespacedName(g.Service, "service", false))
+	if len(g.Listeners) > 0 && !g.Create {
+		errs = errs.Also(apis.ErrGeneric("listeners require create", "listeners"))
+	}
+	names := sets.New[string]()
+	for i, l := range g.Listeners {
+		if l.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("listeners", i))
+		} else if names.Has(l.Name) {
+			errs = errs.Also(apis.ErrInvalidValue(l.Name, "name", "must be unique").ViaFieldIndex("listeners", i))
+		}
+		names.Insert(l.Name)
+		if l.Port < 1 || l.Port > 65535 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(l.Port, 1, 65535, "port").ViaFieldIndex("listeners", i))
+		}
+		switch l.Protocol {
+		case "", "HTTP":
+			if len(l.CertificateRefs) > 0 {
+				errs = errs.Also(apis.ErrGeneric("certificate-refs require the HTTPS protocol", "certificate-refs").ViaFieldIndex("listeners", i))
+			}
+		case "HTTPS":
+			if len(l.CertificateRefs) == 0 {
+				errs = errs.Also(apis.ErrMissingField("certificate-refs").ViaFieldIndex("listeners", i))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(l.Protocol, "protocol", "must be HTTP or HTTPS").ViaFieldIndex("listeners", i))
+		}
+		for j, ref := range l.CertificateRefs {
+			if ref.Name == "" {
+				errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("certificate-refs", j).ViaFieldIndex("listeners", i))
+			}
+		}
+	}
+	return errs
+}
+
+// validateNamespacedName validates a namespace/name reference.
+func validateNamespacedName(value, field string, required bool) *apis.FieldError {
+	if value == "" {
+		if required {
+			return apis.ErrMissingField(field)
+		}
+		return nil
+	}
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return apis.ErrInvalidValue(value, field, "must be namespace/name")
+	}
+	return nil
+}
+
+// SetDefaults implements apis.Defaultable
+func (ks *KnativeServing) SetDefaults(context.Context) {}
+
+// SupportedSubResources limits the validation to the main resource, so that the status
+// updates of the reconciler are never rejected.
+func (ks *KnativeServing) SupportedSubResources() []string {
+	return []string{""}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+type fakeInspector struct {
+	versionErr error
+	resources  *base.TargetResources
+}
+
+func (f fakeInspector) CheckVersion(base.KComponent) error {
+	return f.versionErr
+}
+
+func (f fakeInspector) TargetResources(context.Context, base.KComponent) (*base.TargetResources, error) {
+	return f.resources, nil
+}
+
+var servingResources = &base.TargetResources{
+	ConfigMaps: sets.NewString("config-network", "config-domain"),
+	Workloads:  sets.NewString("activator", "controller", "webhook"),
+}
+
+func TestKnativeServingValidate(t *testing.T) {
+	replicas := int32(-1)
+	tests := []struct {
+		name      string
+		spec      KnativeServingSpec
+		inspector base.ReleaseInspector
+		expected  string
+	}{{
+		name: "valid spec",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version: "1.23",
+				Config: base.ConfigMapData{
+					"network":       {"ingress-class": "kourier.ingress.networking.knative.dev"},
+					"config-domain": {"example.com": ""},
+				},
+				Registry: base.Registry{
+					Default: "registry.example.com/knative/${NAME}:v1.23.0",
+				},
+				Workloads: []base.WorkloadOverride{{Name: "activator"}},
+			},
+		},
+		inspector: fakeInspector{resources: servingResources},
+	}, {
+		name: "invalid version",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version: "one.two",
+			},
+		},
+		expected: "invalid value: one.two: spec.version\nmust be a semantic version, a major.minor version or latest",
+	}, {
+		name: "registry default without placeholder",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Registry: base.Registry{
+					Default: "registry.example.com/knative/activator:v1.23.0",
+				},
+			},
+		},
+		expected: "invalid value: registry.example.com/knative/activator:v1.23.0: spec.registry.default\nmust contain the ${NAME} placeholder",
+	}, {
+		name: "workload without name and negative replicas",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Workloads: []base.WorkloadOverride{{Replicas: &replicas}},
+			},
+		},
+		expected: "invalid value: -1: spec.workloads[0].replicas\nmissing field(s): spec.workloads[0].name",
+	}, {
+		name: "non-positive progress deadline",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				UpgradeStrategy: &base.UpgradeStrategy{
+					ProgressDeadline: &metav1.Duration{},
+				},
+			},
+		},
+		expected: "invalid value: 0s: spec.upgradeStrategy.progressDeadline\nmust be positive",
+	}, {
+		name: "unknown drift policy",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				DriftPolicy: "revert",
+			},
+		},
+		expected: "invalid value: revert: spec.driftPolicy\nmust be report, correct or ignore",
+	}, {
+		name: "force conflicts without server-side apply",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				ApplyStrategy: &base.ApplyStrategy{ForceConflicts: true},
+			},
+		},
+		expected: "forceConflicts requires the ServerSide type: spec.applyStrategy.forceConflicts",
+	}, {
+		name: "unknown apply type",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				ApplyStrategy: &base.ApplyStrategy{Type: "Replace"},
+			},
+		},
+		expected: "invalid value: Replace: spec.applyStrategy.type\nmust be ClientSide or ServerSide",
+	}, {
+		name: "invalid manifest digest",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version:   "1.0.0",
+				Manifests: []base.Manifest{{Url: "https://example.com/serving.yaml", Digest: "md5:abc"}},
+			},
+		},
+		expected: "invalid value: md5:abc: spec.manifests[0].digest\nmust be sha256:<64 hex digits>",
+	}, {
+		name: "manifest verification without key",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				ManifestVerification: &base.ManifestVerification{},
+			},
+		},
+		expected: "expected exactly one, got neither: spec.manifestVerification.keyless, spec.manifestVerification.publicKey",
+	}, {
+		name: "keyless manifest verification without identity",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				ManifestVerification: &base.ManifestVerification{Keyless: &base.KeylessVerification{}},
+			},
+		},
+		expected: "expected exactly one, got neither: spec.manifestVerification.keyless.subject, spec.manifestVerification.keyless.subjectRegExp\n" +
+			"missing field(s): spec.manifestVerification.keyless.issuer, spec.manifestVerification.keyless.trustedRoots",
+	}, {
+		name: "signed oci manifests",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version:              "1.0.0",
+				Manifests:            []base.Manifest{{Url: "oci://ghcr.io/example/serving:1.0.0"}},
+				ManifestVerification: &base.ManifestVerification{PublicKey: "-----BEGIN PUBLIC KEY-----\nAA==\n-----END PUBLIC KEY-----\n"},
+			},
+		},
+		expected: "signatures of oci:// manifests are not supported, pin them with digest instead: spec.manifests[0].URL",
+	}, {
+		name: "invalid registry mirrors",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Registry: base.Registry{
+					Mirrors: []base.RegistryMirror{
+						{Prefix: "https://gcr.io", Mirror: "mirror.example.com"},
+						{Prefix: "gcr.io"},
+					},
+				},
+			},
+		},
+		expected: "invalid value: https://gcr.io: spec.registry.mirrors[0].prefix\nmust not contain a scheme\n" +
+			"missing field(s): spec.registry.mirrors[1].mirror",
+	}, {
+		name: "invalid custom certs type",
+		spec: KnativeServingSpec{
+			ControllerCustomCerts: base.CustomCerts{
+				Type: "Volume",
+				Name: "certs",
+			},
+		},
+		expected: "invalid value: Volume: spec.controller-custom-certs.type\nmust be ConfigMap or Secret",
+	}, {
+		name: "custom ingress without manifests",
+		spec: KnativeServingSpec{
+			Ingress: &IngressConfigs{
+				Custom: base.CustomIngressConfiguration{Enabled: true},
+			},
+		},
+		expected: "missing field(s): spec.ingress.custom.manifests",
+	}, {
+		name: "invalid gateway-api gateways",
+		spec: KnativeServingSpec{
+			Ingress: &IngressConfigs{
+				GatewayAPI: base.GatewayAPIIngressConfiguration{
+					Enabled: true,
+					ExternalGateway: &base.GatewayAPIGateway{
+						Class:   "envoy",
+						Gateway: "knative-external",
+					},
+					LocalGateway: &base.GatewayAPIGateway{
+						Class:   "envoy",
+						Gateway: "knative-serving/knative-local",
+						Create:  true,
+						Listeners: []base.GatewayAPIListener{{
+							Name:     "https",
+							Port:     443,
+							Protocol: "HTTPS",
+						}},
+					},
+				},
+			},
+		},
+		expected: "invalid value: knative-external: spec.ingress.gateway-api.external-gateway.gateway\nmust be namespace/name\n" +
+			"missing field(s): spec.ingress.gateway-api.local-gateway.listeners[0].certificate-refs",
+	}, {
+		name: "invalid istio gateways",
+		spec: KnativeServingSpec{
+			Ingress: &IngressConfigs{
+				Istio: base.IstioIngressConfiguration{
+					Enabled: true,
+					Gateways: []base.IstioGateway{{
+						Name:    "knative-ingress-gateway",
+						Service: "istio-ingressgateway.istio-system.svc.cluster.local",
+					}, {
+						Name:          "tenant-a",
+						LabelSelector: &metav1.LabelSelector{},
+					}},
+				},
+			},
+		},
+		expected: "invalid value: knative-ingress-gateway: spec.ingress.istio.gateways[0].name\nmust not be the name of a built-in gateway\n" +
+			"missing field(s): spec.ingress.istio.gateways[0].labelSelector, spec.ingress.istio.gateways[1].service",
+	}, {
+		name: "invalid kourier configuration",
+		spec: KnativeServingSpec{
+			Ingress: &IngressConfigs{
+				Kourier: base.KourierIngressConfiguration{
+					Enabled:     true,
+					CertsSecret: "wildcard",
+					ExtAuthz:    &base.KourierExtAuthzConfiguration{Protocol: "grpc"},
+					Tracing: &base.KourierTracingConfiguration{
+						Endpoint:     "http://otel-collector.observability.svc:4317",
+						SamplingRate: "2",
+					},
+				},
+			},
+		},
+		expected: "invalid value: 2: spec.ingress.kourier.tracing.sampling-rate\nmust be a number between 0.0 and 1.0\n" +
+			"invalid value: wildcard: spec.ingress.kourier.certs-secret\nmust be namespace/name\n" +
+			"missing field(s): spec.ingress.kourier.extauthz.host",
+	}, {
+		name: "invalid contour configuration",
+		spec: KnativeServingSpec{
+			Ingress: &IngressConfigs{
+				Contour: base.ContourIngressConfiguration{
+					Enabled:           true,
+					External:          &base.ContourVisibilityConfiguration{ServiceType: corev1.ServiceTypeExternalName},
+					Internal:          &base.ContourVisibilityConfiguration{ServiceAnnotations: map[string]string{"a": "b"}},
+					DefaultTLSSecret:  "wildcard",
+					TimeoutPolicyIdle: "forever",
+				},
+			},
+		},
+		expected: "invalid value: ExternalName: spec.ingress.contour.external.service-type\nmust be ClusterIP, NodePort or LoadBalancer\n" +
+			"invalid value: forever: spec.ingress.contour.timeout-policy-idle\nmust be a duration or infinity\n" +
+			"invalid value: wildcard: spec.ingress.contour.default-tls-secret\nmust be namespace/name\n" +
+			"the Envoy Services of external and internal must have different names to be customized: spec.ingress.contour.external.service, spec.ingress.contour.internal.service",
+	}, {
+		name: "version not eligible for migration",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version: "1.23",
+			},
+		},
+		inspector: fakeInspector{versionErr: errors.New("not supported")},
+		expected:  "invalid value: 1.23: spec.version\nnot supported",
+	}, {
+		name: "unknown workload and config",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Config: base.ConfigMapData{
+					"netwrok": {"ingress-class": "kourier.ingress.networking.knative.dev"},
+				},
+				DeploymentOverride: []base.WorkloadOverride{{Name: "controller"}},
+				Workloads:          []base.WorkloadOverride{{Name: "activater"}},
+			},
+		},
+		inspector: fakeInspector{resources: servingResources},
+		expected: "invalid key name \"netwrok\": spec.config\nno ConfigMap with this name exists in the target manifest\n" +
+			"invalid value: activater: spec.workloads[0].name\nno workload with this name exists in the target manifest",
+	}, {
+		name: "target resources cannot be determined",
+		spec: KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Workloads: []base.WorkloadOverride{{Name: "activater"}},
+			},
+		},
+		inspector: fakeInspector{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.inspector != nil {
+				ctx = base.WithReleaseInspector(ctx, tt.inspector)
+			}
+			ks := &KnativeServing{Spec: tt.spec}
+			err := ks.Validate(ctx)
+			util.AssertEqual(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestKnativeServingValidateSkipped(t *testing.T) {
+	invalid := KnativeServingSpec{
+		CommonSpec: base.CommonSpec{
+			Version: "one.two",
+		},
+	}
+
+	ks := &KnativeServing{Spec: invalid}
+	ctx := apis.WithinUpdate(context.Background(), ks.DeepCopy())
+	util.AssertEqual(t, ks.Validate(ctx).Error(), "")
+
+	now := metav1.Now()
+	ks.DeletionTimestamp = &now
+	util.AssertEqual(t, ks.Validate(context.Background()).Error(), "")
+}
@@ -23,15 +23,62 @@ package v1beta1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	base "knative.dev/operator/pkg/apis/operator/base"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigs) DeepCopyInto(out *BrokerConfigs) {
+	*out = *in
+	in.Kafka.DeepCopyInto(&out.Kafka)
+	in.Rabbitmq.DeepCopyInto(&out.Rabbitmq)
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(base.BrokerDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerConfigs.
+func (in *BrokerConfigs) DeepCopy() *BrokerConfigs {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelConfigs) DeepCopyInto(out *ChannelConfigs) {
+	*out = *in
+	in.Kafka.DeepCopyInto(&out.Kafka)
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(base.ChannelDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelConfigs.
+func (in *ChannelConfigs) DeepCopy() *ChannelConfigs {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelConfigs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressConfigs) DeepCopyInto(out *IngressConfigs) {
 	*out = *in
 	in.Istio.DeepCopyInto(&out.Istio)
-	out.Kourier = in.Kourier
-	out.Contour = in.Contour
-	out.GatewayAPI = in.GatewayAPI
+	in.Kourier.DeepCopyInto(&out.Kourier)
+	in.Contour.DeepCopyInto(&out.Contour)
+	in.GatewayAPI.DeepCopyInto(&out.GatewayAPI)
+	in.Custom.DeepCopyInto(&out.Custom)
 	return
 }
 
@@ -113,7 +160,17 @@ func (in *KnativeEventingSpec) DeepCopyInto(out *KnativeEventingSpec) {
 	if in.Source != nil {
 		in, out := &in.Source, &out.Source
 		*out = new(SourceConfigs)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Broker != nil {
+		in, out := &in.Broker, &out.Broker
+		*out = new(BrokerConfigs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Channel != nil {
+		in, out := &in.Channel, &out.Channel
+		*out = new(ChannelConfigs)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -137,6 +194,28 @@ func (in *KnativeEventingStatus) DeepCopyInto(out *KnativeEventingStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(base.UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(base.RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]base.InstallRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]base.ResolvedImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -248,6 +327,28 @@ func (in *KnativeServingStatus) DeepCopyInto(out *KnativeServingStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(base.UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(base.RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]base.InstallRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]base.ResolvedImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -281,12 +382,19 @@ func (in *SecurityConfigs) DeepCopy() *SecurityConfigs {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceConfigs) DeepCopyInto(out *SourceConfigs) {
 	*out = *in
-	out.Ceph = in.Ceph
-	out.Github = in.Github
-	out.Gitlab = in.Gitlab
-	out.Kafka = in.Kafka
-	out.Rabbitmq = in.Rabbitmq
-	out.Redis = in.Redis
+	in.Ceph.DeepCopyInto(&out.Ceph)
+	in.Github.DeepCopyInto(&out.Github)
+	in.Gitlab.DeepCopyInto(&out.Gitlab)
+	in.Kafka.DeepCopyInto(&out.Kafka)
+	in.Rabbitmq.DeepCopyInto(&out.Rabbitmq)
+	in.Redis.DeepCopyInto(&out.Redis)
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make(map[string]base.SourceConfiguration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle packages the manifests and the images of a KnativeServing or a KnativeEventing
+// into a tarball, to install them at disconnected sites. The tarball contains an index.yaml, the
+// manifests from the kodata directory of the operator under kodata/, with the same layout, and
+// the manifests fetched from remote URLs under remote/.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/broker"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/source"
+	servingcommon "knative.dev/operator/pkg/reconciler/knativeserving/common"
+	"knative.dev/operator/pkg/reconciler/knativeserving/ingress"
+	"knative.dev/operator/pkg/reconciler/knativeserving/security"
+)
+
+const (
+	// IndexFile is the name of the index in the tarball.
+	IndexFile = "index.yaml"
+	// KoDataDir is the directory of the manifests from the kodata directory in the tarball.
+	KoDataDir = "kodata"
+	// RemoteDir is the directory of the manifests fetched from remote URLs in the tarball.
+	RemoteDir = "remote"
+)
+
+// The components of the manifests.
+const (
+	ComponentCore           = "core"
+	ComponentAdditional     = "additional"
+	ComponentIngress        = "ingress"
+	ComponentEventingSource = "eventing-source"
+	ComponentEventingBroker = "eventing-broker"
+	ComponentSecurityGuard  = "security-guard"
+)
+
+// Index describes the content of a bundle.
+type Index struct {
+	// Kind is the kind of the component, KnativeServing or KnativeEventing.
+	Kind string `json:"kind"`
+	// Version is the version of the bundled manifests.
+	Version string `json:"version"`
+	// Manifests are the bundled manifests.
+	Manifests []Manifest `json:"manifests"`
+	// Images are the images referenced by the bundled manifests.
+	Images []Image `json:"images"`
+}
+
+// Manifest is a bundled manifest.
+type Manifest struct {
+	// Component is the part of the installation the manifest belongs to, e.g. core or ingress.
+	Component string `json:"component"`
+	// Source is the path of the manifest relative to the kodata directory, or its remote URL.
+	Source string `json:"source"`
+	// Files are the paths of the files of the manifest in the tarball.
+	Files []string `json:"files"`
+}
+
+// Image is an image referenced by the bundled manifests.
+type Image struct {
+	// Image is the reference of the image.
+	Image string `json:"image"`
+	// Overrides are the keys of spec.registry.override, which select the image.
+	Overrides []string `json:"overrides"`
+}
+
+// Export writes the bundle of the instance to w. The manifest is the rendered manifest of the
+// instance, from which the images are collected. The manifests are read from the kodata directory
+// and the URLs of the instance.
+func Export(ctx context.Context, w io.Writer, instance base.KComponent, rendered mf.Manifest) (*Index, error) {
+	index := &Index{
+		Kind:    kindOf(instance),
+		Version: common.TargetVersion(instance),
+		Images:  Images(rendered),
+	}
+	paths, err := manifestPaths(instance, index.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	koData := os.Getenv(common.KoEnvKey)
+	remotes := 0
+	for _, p := range paths {
+		for _, url := range strings.Split(p.path, common.COMMA) {
+			m := Manifest{Component: p.component, Source: url}
+			if rel, ok := relativeTo(koData, url); ok {
+				m.Source = rel
+				m.Files, err = addLocal(tw, url, path.Join(KoDataDir, rel))
+			} else {
+				remotes++
+				m.Files, err = addRemote(ctx, tw, url, path.Join(RemoteDir, fmt.Sprintf("%02d-%s.yaml", remotes, p.component)))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to bundle the manifest %s: %w", url, err)
+			}
+			index.Manifests = append(index.Manifests, m)
+		}
+	}
+
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	if err := addFile(tw, IndexFile, data); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return index, gz.Close()
+}
+
+// Read reads the index of the bundle from r. If dir is not empty, the files of the bundle are
+// extracted into it, so that its kodata subdirectory can be used as the kodata directory of the
+// operator.
+func Read(r io.Reader, dir string) (*Index, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the bundle: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	var index *Index
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid file %q in the bundle", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if name == IndexFile {
+			index = &Index{}
+			if err := yaml.UnmarshalStrict(data, index); err != nil {
+				return nil, fmt.Errorf("failed to parse the index of the bundle: %w", err)
+			}
+		}
+		if dir != "" {
+			target := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(target, data, 0644); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if index == nil {
+		return nil, fmt.Errorf("the bundle contains no %s", IndexFile)
+	}
+	return index, nil
+}
+
+// manifestPath is a comma separated list of manifest paths of a component.
+type manifestPath struct {
+	component string
+	path      string
+}
+
+// manifestPaths returns the paths of all the manifests the operator installs for the instance.
+func manifestPaths(instance base.KComponent, version string) ([]manifestPath, error) {
+	var paths []manifestPath
+	add := func(component, p string) {
+		if p != "" {
+			paths = append(paths, manifestPath{component: component, path: p})
+		}
+	}
+	targetPaths := common.TargetManifestPathArray(instance)
+	if len(targetPaths) == 0 || targetPaths[0] == "" {
+		return nil, fmt.Errorf("the manifests of version %s are not available", version)
+	}
+	add(ComponentCore, targetPaths[0])
+	if len(targetPaths) > 1 {
+		add(ComponentAdditional, targetPaths[1])
+	}
+
+	switch instance.(type) {
+	case *v1beta1.KnativeServing:
+		ks := servingcommon.ConvertToKS(instance)
+		add(ComponentIngress, ingress.GetIngressPath(version, ks))
+		sgPath, err := security.GetSecurityPath(version, ks)
+		if err != nil {
+			return nil, err
+		}
+		add(ComponentSecurityGuard, sgPath)
+	case *v1beta1.KnativeEventing:
+		ke := source.ConvertToKE(instance)
+		add(ComponentEventingSource, source.GetSourcePath(version, ke))
+		add(ComponentEventingBroker, broker.GetBrokerPath(version, ke))
+	}
+	return paths, nil
+}
+
+// relativeTo returns the path relative to the kodata directory, if it is in it.
+func relativeTo(koData, p string) (string, bool) {
+	if koData == "" || strings.Contains(p, "://") {
+		return "", false
+	}
+	rel, err := filepath.Rel(koData, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// addLocal adds the file, or the files of the directory, to the tarball under the given name.
+func addLocal(tw *tar.Writer, p, name string) ([]string, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{name: p}
+	if info.IsDir() {
+		files = map[string]string{}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, err
+		}
+		// Like manifestival, only the files directly in the directory are part of the manifest.
+		for _, e := range entries {
+			if e.Type().IsRegular() {
+				files[path.Join(name, e.Name())] = filepath.Join(p, e.Name())
+			}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		data, err := os.ReadFile(files[n])
+		if err != nil {
+			return nil, err
+		}
+		if err := addFile(tw, n, data); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// addRemote fetches the manifest from the URL, and adds it to the tarball under the given name.
+func addRemote(ctx context.Context, tw *tar.Writer, url, name string) ([]string, error) {
+	m, err := common.FetchManifest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	for i, u := range m.Resources() {
+		data, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(data)
+	}
+	return []string{name}, addFile(tw, name, []byte(b.String()))
+}
+
+func addFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
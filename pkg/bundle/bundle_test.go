@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+const (
+	controllerImage = "gcr.io/knative-releases/knative.dev/serving/cmd/controller@sha256:ca5062ece0329d002a81940cf4268d7898866434d70049bb93f27d3a786d3292"
+	queueImage      = "gcr.io/knative-releases/knative.dev/serving/cmd/queue:v1.0.0"
+	adapterImage    = "gcr.io/knative-releases/knative.dev/eventing/cmd/adapter:v1.0.0"
+
+	core = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: knative-serving
+spec:
+  template:
+    spec:
+      containers:
+      - name: controller
+        image: ` + controllerImage + `
+        env:
+        - name: ADAPTER_IMAGE
+          value: ` + adapterImage + `
+        - name: CONFIG_PATH
+          value: /etc/config
+---
+apiVersion: caching.internal.knative.dev/v1alpha1
+kind: Image
+metadata:
+  name: queue-proxy
+  namespace: knative-serving
+spec:
+  image: ` + queueImage + `
+`
+	istio = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config-istio
+  namespace: knative-serving
+`
+	additional = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+  namespace: knative-serving
+`
+)
+
+func TestExportImport(t *testing.T) {
+	koData := t.TempDir()
+	writeFile(t, filepath.Join(koData, "knative-serving", "1.0.0", "1-core.yaml"), core)
+	writeFile(t, filepath.Join(koData, "ingress", "1.0", "istio", "istio.yaml"), istio)
+	os.Setenv(common.KoEnvKey, koData)
+	defer os.Unsetenv(common.KoEnvKey)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(additional))
+	}))
+	defer server.Close()
+
+	instance := &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				AdditionalManifests: []base.Manifest{{Url: server.URL + "/extra.yaml"}},
+			},
+		},
+	}
+	rendered, err := mf.NewManifest(filepath.Join(koData, "knative-serving", "1.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	index, err := Export(context.Background(), &buf, instance, rendered)
+	if err != nil {
+		t.Fatalf("Export() = %v", err)
+	}
+	util.AssertEqual(t, index.Version, "1.0.0")
+	util.AssertDeepEqual(t, index.Manifests, []Manifest{{
+		Component: ComponentCore,
+		Source:    "knative-serving/1.0.0",
+		Files:     []string{"kodata/knative-serving/1.0.0/1-core.yaml"},
+	}, {
+		Component: ComponentAdditional,
+		Source:    server.URL + "/extra.yaml",
+		Files:     []string{"remote/01-additional.yaml"},
+	}, {
+		Component: ComponentIngress,
+		Source:    "ingress/1.0/istio",
+		Files:     []string{"kodata/ingress/1.0/istio/istio.yaml"},
+	}})
+	util.AssertDeepEqual(t, index.Images, []Image{
+		{Image: adapterImage, Overrides: []string{"ADAPTER_IMAGE"}},
+		{Image: controllerImage, Overrides: []string{"controller/controller"}},
+		{Image: queueImage, Overrides: []string{"queue-proxy"}},
+	})
+
+	dir := t.TempDir()
+	read, err := Read(bytes.NewReader(buf.Bytes()), dir)
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	util.AssertDeepEqual(t, read, index)
+	data, err := os.ReadFile(filepath.Join(dir, KoDataDir, "ingress", "1.0", "istio", "istio.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, string(data), istio)
+
+	target := &v1beta1.KnativeServing{}
+	copies, err := Import(read, target, "registry.example.com/mirror/")
+	if err != nil {
+		t.Fatalf("Import() = %v", err)
+	}
+	util.AssertEqual(t, target.Spec.Version, "1.0.0")
+	util.AssertDeepEqual(t, target.Spec.Registry.Override, map[string]string{
+		"ADAPTER_IMAGE":         "registry.example.com/mirror/knative-releases/knative.dev/eventing/cmd/adapter:v1.0.0",
+		"controller/controller": "registry.example.com/mirror/knative-releases/knative.dev/serving/cmd/controller@sha256:ca5062ece0329d002a81940cf4268d7898866434d70049bb93f27d3a786d3292",
+		"queue-proxy":           "registry.example.com/mirror/knative-releases/knative.dev/serving/cmd/queue:v1.0.0",
+	})
+	util.AssertEqual(t, len(copies), 3)
+	util.AssertDeepEqual(t, copies[2], Copy{
+		Source:      queueImage,
+		Destination: "registry.example.com/mirror/knative-releases/knative.dev/serving/cmd/queue:v1.0.0",
+	})
+
+	if _, err := Import(read, &v1beta1.KnativeEventing{}, "registry.example.com/mirror"); err == nil {
+		t.Error("Import() of a KnativeServing bundle into a KnativeEventing succeeded")
+	}
+
+	for version, ok := range map[string]bool{"1.0": true, "1.0.0": true, "v1.0.0": true, "latest": true, "1.1.0": false, "1.1": false} {
+		target := &v1beta1.KnativeServing{Spec: v1beta1.KnativeServingSpec{CommonSpec: base.CommonSpec{Version: version}}}
+		_, err := Import(read, target, "registry.example.com/mirror")
+		if got := err == nil; got != ok {
+			t.Errorf("Import() into spec.version %s succeeded = %v, want %v", version, got, ok)
+		} else if ok {
+			util.AssertEqual(t, target.Spec.Version, "1.0.0")
+		}
+	}
+}
+
+func TestIsImage(t *testing.T) {
+	for value, want := range map[string]bool{
+		adapterImage:                  true,
+		"localhost:5000/adapter:v1":   true,
+		"/etc/config":                 false,
+		"https://example.com/adapter": false,
+		"knative-serving/controller":  false,
+		"info":                        false,
+	} {
+		if got := isImage(value); got != want {
+			t.Errorf("isImage(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
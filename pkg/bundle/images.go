@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	mf "github.com/manifestival/manifestival"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// podTemplateKinds are the kinds of the workloads, whose pod template is at spec.template.
+var podTemplateKinds = map[string]bool{
+	"Deployment":  true,
+	"DaemonSet":   true,
+	"StatefulSet": true,
+	"Job":         true,
+}
+
+// Images returns the images referenced by the manifest, with the keys of spec.registry.override
+// selecting them: <workload>/<container> for the containers of the workloads, the name of the
+// environment variables referencing images, and the name of the caching.internal.knative.dev Images.
+func Images(manifest mf.Manifest) []Image {
+	keys := map[string]map[string]bool{}
+	add := func(image, key string) {
+		if keys[image] == nil {
+			keys[image] = map[string]bool{}
+		}
+		keys[image][key] = true
+	}
+
+	for _, u := range manifest.Resources() {
+		if u.GetKind() == "Image" && u.GetAPIVersion() == "caching.internal.knative.dev/v1alpha1" {
+			if image, _, _ := unstructured.NestedString(u.Object, "spec", "image"); image != "" {
+				add(image, u.GetName())
+			}
+			continue
+		}
+		if !podTemplateKinds[u.GetKind()] {
+			continue
+		}
+		// The overrides of the containers are keyed by the generate name of the workloads, if any.
+		objName := u.GetName()
+		if u.GetGenerateName() != "" {
+			objName = u.GetGenerateName()
+		}
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				containerName, _ := container["name"].(string)
+				if image, _ := container["image"].(string); image != "" {
+					add(image, objName+"/"+containerName)
+				}
+				env, _ := container["env"].([]interface{})
+				for _, e := range env {
+					envVar, ok := e.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					envName, _ := envVar["name"].(string)
+					if value, _ := envVar["value"].(string); isImage(value) {
+						add(value, envName)
+					}
+				}
+			}
+		}
+	}
+
+	images := make([]Image, 0, len(keys))
+	for image, k := range keys {
+		overrides := make([]string, 0, len(k))
+		for key := range k {
+			overrides = append(overrides, key)
+		}
+		sort.Strings(overrides)
+		images = append(images, Image{Image: image, Overrides: overrides})
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Image < images[j].Image })
+	return images
+}
+
+// isImage returns true if the value of an environment variable is the reference of an image in a
+// registry, e.g. gcr.io/knative-releases/knative.dev/eventing/cmd/apiserver_receive_adapter@sha256:...
+func isImage(value string) bool {
+	if strings.Contains(value, "://") || !strings.Contains(value, "/") {
+		return false
+	}
+	// The first segment of the reference must be a registry host.
+	if host := value[:strings.Index(value, "/")]; !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return false
+	}
+	_, err := name.ParseReference(value, name.StrictValidation)
+	return err == nil
+}
+
+// Copy is an image to copy to the private mirror.
+type Copy struct {
+	Source      string
+	Destination string
+}
+
+// Import rewrites spec.registry.override of the instance, so that the images of the bundle are
+// pulled from the mirror, and pins spec.version to the version of the bundle. It returns an error, if
+// spec.version names another release than the bundle, and otherwise the images to copy to the mirror. The repositories of the images keep their paths under the mirror, e.g.
+// gcr.io/knative-releases/knative.dev/serving/cmd/controller@sha256:... becomes
+// <mirror>/knative-releases/knative.dev/serving/cmd/controller@sha256:...
+func Import(index *Index, instance base.KComponent, mirror string) ([]Copy, error) {
+	if kind := kindOf(instance); kind != index.Kind {
+		return nil, fmt.Errorf("the bundle contains a %s, not a %s", index.Kind, kind)
+	}
+	mirror = strings.TrimSuffix(mirror, "/")
+	if _, err := name.NewRepository(mirror + "/name"); err != nil {
+		return nil, fmt.Errorf("invalid mirror %q: %w", mirror, err)
+	}
+
+	spec := instance.GetSpec()
+	if !bundledVersion(spec.GetVersion(), index.Version) {
+		return nil, fmt.Errorf("spec.version %s is not the version %s of the bundle", spec.GetVersion(), index.Version)
+	}
+	registry := spec.GetRegistry()
+	if registry.Override == nil {
+		registry.Override = map[string]string{}
+	}
+	copies := make([]Copy, 0, len(index.Images))
+	for _, image := range index.Images {
+		ref, err := name.ParseReference(image.Image)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image %q in the bundle: %w", image.Image, err)
+		}
+		separator := ":"
+		if _, ok := ref.(name.Digest); ok {
+			separator = "@"
+		}
+		destination := mirror + "/" + ref.Context().RepositoryStr() + separator + ref.Identifier()
+		for _, key := range image.Overrides {
+			registry.Override[key] = destination
+		}
+		copies = append(copies, Copy{Source: image.Image, Destination: destination})
+	}
+	setVersion(instance, index.Version)
+	return copies, nil
+}
+
+// bundledVersion returns true if the version of a spec resolves to the version of the bundle: it is
+// empty, latest, the version itself or its minor version.
+func bundledVersion(version, bundle string) bool {
+	if version == "" || version == common.LATEST_VERSION {
+		return true
+	}
+	version, bundle = common.SanitizeSemver(version), common.SanitizeSemver(bundle)
+	return version == bundle || version == semver.MajorMinor(bundle)
+}
+
+func setVersion(instance base.KComponent, version string) {
+	switch i := instance.(type) {
+	case *v1beta1.KnativeServing:
+		i.Spec.Version = version
+	case *v1beta1.KnativeEventing:
+		i.Spec.Version = version
+	}
+}
+
+// kindOf returns the kind of the component.
+func kindOf(instance base.KComponent) string {
+	if _, ok := instance.(*v1beta1.KnativeEventing); ok {
+		return "KnativeEventing"
+	}
+	return "KnativeServing"
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oci pulls manifest bundles stored as OCI artifacts in a container registry. Every layer
+// of an artifact is a file of the bundle, named by its org.opencontainers.image.title annotation,
+// as pushed by `oras push`. It also resolves the tags of images to their digests.
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+const (
+	// Scheme is the prefix of the URLs referencing OCI artifacts, e.g.
+	// oci://registry.example.com/knative/serving-manifests:1.23.0.
+	Scheme = "oci://"
+
+	// MediaTypeImageManifest is the media type of OCI image manifests.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeDockerManifest is the media type of Docker image manifests.
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeImageIndex is the media type of OCI image indexes of multi-platform images.
+	MediaTypeImageIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerManifestList is the media type of Docker manifest lists of multi-platform images.
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// AnnotationTitle names the file stored in a layer.
+	AnnotationTitle = "org.opencontainers.image.title"
+	// AnnotationCreated is the creation time of an artifact.
+	AnnotationCreated = "org.opencontainers.image.created"
+
+	// maxManifestSize bounds the size of the manifests read from registries.
+	maxManifestSize = 4 * 1024 * 1024
+	// maxBlobSize bounds the size of the blobs read from registries, i.e. of the files of a bundle.
+	maxBlobSize = 64 * 1024 * 1024
+
+	// clientTimeout bounds the requests of the clients returned by NewClient, including reading the
+	// response bodies.
+	clientTimeout = time.Minute
+)
+
+// Descriptor references a blob of an artifact.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// File is a file of an artifact.
+type File struct {
+	Name   string
+	Digest string
+	Data   []byte
+}
+
+// Artifact is a pulled OCI artifact.
+type Artifact struct {
+	// Digest is the digest of the manifest of the artifact.
+	Digest string
+	// Created is the creation time recorded in the annotations of the artifact, if any.
+	Created time.Time
+	Files   []File
+}
+
+// IsReference returns true if the URL references an OCI artifact.
+func IsReference(url string) bool {
+	return strings.HasPrefix(url, Scheme)
+}
+
+// Client pulls artifacts from registries.
+type Client struct {
+	HTTP *http.Client
+}
+
+// NewClient returns a Client, which authenticates with the token services of the registries.
+func NewClient() *Client {
+	return &Client{HTTP: &http.Client{Transport: NewTransport(http.DefaultTransport), Timeout: clientTimeout}}
+}
+
+// ParseReference parses the reference of an artifact, with or without the oci:// prefix.
+func ParseReference(url string) (name.Reference, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(url, Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %q: %w", url, err)
+	}
+	return ref, nil
+}
+
+// Pin returns the oci:// URL referencing the artifact by the given digest.
+func Pin(ref name.Reference, digest string) string {
+	return Scheme + ref.Context().Name() + "@" + digest
+}
+
+// Pull pulls the artifact referenced by the URL. If the reference contains a digest, the digest of
+// the manifest is verified against it. The digests of all the files are verified.
+func (c *Client) Pull(ctx context.Context, url string) (*Artifact, error) {
+	ref, err := ParseReference(url)
+	if err != nil {
+		return nil, err
+	}
+	manifest, digest, err := c.Manifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	artifact := &Artifact{Digest: digest}
+	if created, ok := manifest.Annotations[AnnotationCreated]; ok {
+		artifact.Created, _ = time.Parse(time.RFC3339, created)
+	}
+	for i, layer := range manifest.Layers {
+		data, err := c.Blob(ctx, ref.Context(), layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		fileName := layer.Annotations[AnnotationTitle]
+		if fileName == "" {
+			fileName = fmt.Sprintf("layer-%d.yaml", i)
+		}
+		artifact.Files = append(artifact.Files, File{Name: fileName, Digest: layer.Digest, Data: data})
+	}
+	return artifact, nil
+}
+
+// Manifest returns the manifest of the referenced artifact, and its digest.
+func (c *Client) Manifest(ctx context.Context, ref name.Reference) (*Manifest, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL(ref.Context(), "manifests", ref.Identifier()), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", MediaTypeImageManifest+", "+MediaTypeDockerManifest)
+	data, err := c.get(req, maxManifestSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch the manifest of %s: %w", ref, err)
+	}
+	digest := Digest(data)
+	if d, ok := ref.(name.Digest); ok && d.DigestStr() != digest {
+		return nil, "", fmt.Errorf("the digest %s of %s does not match the pinned digest", digest, ref)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse the manifest of %s: %w", ref, err)
+	}
+	if manifest.MediaType != "" && manifest.MediaType != MediaTypeImageManifest && manifest.MediaType != MediaTypeDockerManifest {
+		return nil, "", fmt.Errorf("unsupported media type %s of %s", manifest.MediaType, ref)
+	}
+	return manifest, digest, nil
+}
+
+// Resolve returns the digest of the referenced image. For multi-platform images, it is the digest of
+// their index, so that the pinned image can still be pulled on every platform.
+func (c *Client) Resolve(ctx context.Context, ref name.Reference) (string, error) {
+	if d, ok := ref.(name.Digest); ok {
+		return d.DigestStr(), nil
+	}
+	accept := strings.Join([]string{MediaTypeImageIndex, MediaTypeDockerManifestList, MediaTypeImageManifest, MediaTypeDockerManifest}, ", ")
+	url := registryURL(ref.Context(), "manifests", ref.Identifier())
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", accept)
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if digest := resp.Header.Get("Docker-Content-Digest"); strings.HasPrefix(digest, "sha256:") {
+			return digest, nil
+		}
+	}
+
+	// Some registries do not support HEAD requests, or do not return the digest.
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", accept)
+	data, err := c.get(req, maxManifestSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return Digest(data), nil
+}
+
+// Blob returns the content of a blob of the repository, after verifying its digest.
+func (c *Client) Blob(ctx context.Context, repo name.Repository, digest string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, BlobURL(repo, digest), nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.get(req, maxBlobSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the blob %s of %s: %w", digest, repo, err)
+	}
+	if actual := Digest(data); actual != digest {
+		return nil, fmt.Errorf("the blob %s of %s has the digest %s", digest, repo, actual)
+	}
+	return data, nil
+}
+
+// Tags returns the tags of the repository.
+func (c *Client) Tags(ctx context.Context, repo name.Repository) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL(repo, "tags", "list"), nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.get(req, maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tags of %s: %w", repo, err)
+	}
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of %s: %w", repo, err)
+	}
+	return list.Tags, nil
+}
+
+func (c *Client) client() *http.Client {
+	if c.HTTP == nil {
+		return http.DefaultClient
+	}
+	return c.HTTP
+}
+
+func (c *Client) get(req *http.Request, limit int64) ([]byte, error) {
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got HTTP %d from %s", resp.StatusCode, req.URL)
+	}
+	// One more byte is read to tell a body of the maximum size from a larger one.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("the response from %s exceeds %d bytes", req.URL, limit)
+	}
+	return data, nil
+}
+
+// BlobURL returns the URL of a blob of the repository.
+func BlobURL(repo name.Repository, digest string) string {
+	return registryURL(repo, "blobs", digest)
+}
+
+func registryURL(repo name.Repository, kind, identifier string) string {
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", repo.Registry.Scheme(), repo.RegistryStr(), repo.RepositoryStr(), kind, identifier)
+}
+
+// Digest returns the sha256 digest of the data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"knative.dev/operator/pkg/oci"
+	ocitesting "knative.dev/operator/pkg/oci/testing"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+var (
+	crds    = oci.File{Name: "serving-crds.yaml", Data: []byte("kind: CustomResourceDefinition\n")}
+	core    = oci.File{Name: "serving-core.yaml", Data: []byte("kind: Deployment\n")}
+	readme  = oci.File{Name: "README.md", Data: []byte("# Serving\n")}
+	pulled  = []string{"serving-crds.yaml", "serving-core.yaml", "README.md"}
+	wrongID = "sha256:" + strings.Repeat("0", 64)
+)
+
+func TestPull(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	registry.Token = "secret"
+	digest := registry.Push("knative/serving", "1.23.0", crds, core, readme)
+	repo := registry.Host() + "/knative/serving"
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{{
+		name: "tag",
+		url:  oci.Scheme + repo + ":1.23.0",
+	}, {
+		name: "digest",
+		url:  oci.Scheme + repo + "@" + digest,
+	}, {
+		name: "without scheme",
+		url:  repo + ":1.23.0",
+	}, {
+		name:    "mismatching digest",
+		url:     oci.Scheme + repo + "@" + wrongID,
+		wantErr: "got HTTP 404",
+	}, {
+		name:    "unknown tag",
+		url:     oci.Scheme + repo + ":1.24.0",
+		wantErr: "got HTTP 404",
+	}, {
+		name:    "invalid reference",
+		url:     oci.Scheme + "Invalid Reference",
+		wantErr: "invalid OCI reference",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			artifact, err := oci.NewClient().Pull(context.Background(), test.url)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("Pull() = %v, want an error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pull() = %v", err)
+			}
+			util.AssertEqual(t, artifact.Digest, digest)
+			names := make([]string, 0, len(artifact.Files))
+			for _, f := range artifact.Files {
+				names = append(names, f.Name)
+			}
+			util.AssertDeepEqual(t, names, pulled)
+			util.AssertEqual(t, string(artifact.Files[1].Data), string(core.Data))
+		})
+	}
+}
+
+func TestPullTampered(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	digest := registry.Push("knative/serving", "1.23.0", core)
+	registry.Tamper("knative/serving", digest, crds)
+
+	_, err := oci.NewClient().Pull(context.Background(), oci.Scheme+registry.Host()+"/knative/serving@"+digest)
+	if err == nil || !strings.Contains(err.Error(), "does not match the pinned digest") {
+		t.Fatalf("Pull() = %v, want a digest mismatch", err)
+	}
+}
+
+func TestPullTooLarge(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	// The title of the layer makes the manifest larger than the manifests read from registries.
+	large := oci.File{Name: strings.Repeat("a", 4*1024*1024) + ".yaml", Data: core.Data}
+	registry.Push("knative/serving", "1.23.0", large)
+
+	_, err := oci.NewClient().Pull(context.Background(), oci.Scheme+registry.Host()+"/knative/serving:1.23.0")
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("Pull() = %v, want a size error", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	registry.Push("knative/serving", "1.22.0", core)
+	registry.Push("knative/serving", "1.23.0", core)
+
+	repo, err := name.NewRepository(registry.Host() + "/knative/serving")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err := oci.NewClient().Tags(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("Tags() = %v", err)
+	}
+	util.AssertDeepEqual(t, tags, []string{"1.22.0", "1.23.0"})
+}
+
+func TestResolve(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	digest := registry.Push("knative/controller", "v1.23.0", core)
+
+	for _, image := range []string{
+		registry.Host() + "/knative/controller:v1.23.0",
+		registry.Host() + "/knative/controller@" + digest,
+	} {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := oci.NewClient().Resolve(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("Resolve(%s) = %v", image, err)
+		}
+		util.AssertEqual(t, got, digest)
+	}
+	unknown, err := name.ParseReference(registry.Host() + "/knative/controller:v9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oci.NewClient().Resolve(context.Background(), unknown); err == nil {
+		t.Error("Resolve() = nil, want an error for an unknown tag")
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides a fake OCI registry for tests.
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"knative.dev/operator/pkg/oci"
+)
+
+// Registry is an in-memory registry serving the pull API of the OCI distribution spec. If Token is
+// set, the registry challenges the requests without it to fetch it from its token service.
+type Registry struct {
+	*httptest.Server
+	Token string
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string][]string
+	requests  int
+}
+
+// NewRegistry starts a Registry, which must be closed by the caller.
+func NewRegistry() *Registry {
+	r := &Registry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		tags:      map[string][]string{},
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+// Host returns the host of the registry, to be used in references.
+func (r *Registry) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+// Push stores an artifact with the files under the tag of the repository, and returns the digest of
+// its manifest.
+func (r *Registry) Push(repo, tag string, files ...oci.File) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data := r.manifest(files)
+	digest := oci.Digest(data)
+	r.manifests[repo+"@"+digest] = data
+	r.manifests[repo+":"+tag] = data
+	r.tags[repo] = append(r.tags[repo], tag)
+	return digest
+}
+
+// Requests returns the number of requests served so far, including the challenged ones.
+func (r *Registry) Requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+// Tamper serves an artifact with the files under the digest of the repository, as a compromised
+// registry would.
+func (r *Registry) Tamper(repo, digest string, files ...oci.File) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.manifests[repo+"@"+digest] = r.manifest(files)
+}
+
+func (r *Registry) manifest(files []oci.File) []byte {
+	manifest := oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageManifest,
+		Config:        oci.Descriptor{MediaType: "application/vnd.oci.empty.v1+json", Digest: oci.Digest([]byte("{}")), Size: 2},
+	}
+	r.blobs[manifest.Config.Digest] = []byte("{}")
+	for _, f := range files {
+		digest := oci.Digest(f.Data)
+		r.blobs[digest] = f.Data
+		manifest.Layers = append(manifest.Layers, oci.Descriptor{
+			MediaType:   "application/yaml",
+			Digest:      digest,
+			Size:        int64(len(f.Data)),
+			Annotations: map[string]string{oci.AnnotationTitle: f.Name},
+		})
+	}
+	data, _ := json.Marshal(manifest)
+	return data
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	r.requests++
+	r.mu.Unlock()
+	if req.URL.Path == "/token" {
+		json.NewEncoder(w).Encode(map[string]string{"token": r.Token})
+		return
+	}
+	if r.Token != "" && req.Header.Get("Authorization") != "Bearer "+r.Token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.URL+`/token",service="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		repo, ref := path[:i], path[i+len("/manifests/"):]
+		sep := ":"
+		if strings.HasPrefix(ref, "sha256:") {
+			sep = "@"
+		}
+		if data, ok := r.manifests[repo+sep+ref]; ok {
+			w.Header().Set("Content-Type", oci.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", oci.Digest(data))
+			w.Write(data)
+			return
+		}
+	case strings.Contains(path, "/blobs/"):
+		if data, ok := r.blobs[path[strings.LastIndex(path, "/")+1:]]; ok {
+			w.Write(data)
+			return
+		}
+	case strings.HasSuffix(path, "/tags/list"):
+		repo := strings.TrimSuffix(path, "/tags/list")
+		if tags, ok := r.tags[repo]; ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{"name": repo, "tags": tags})
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
@@ -61,6 +61,9 @@ type Source struct {
 	// S3 represents software manifests stored in an blob storage service under
 	// a specified prefix. The blob paths should end with "vX.Y.Z/<asset name>"
 	S3 S3Source `json:"s3,omitempty"`
+	// OCI represents software manifests pushed as OCI artifacts to a container
+	// registry, one artifact per semver-tagged release.
+	OCI OCISource `json:"oci,omitempty"`
 	// EventingService represents the name of the service for the eventing source
 	EventingService string `json:"eventingService,omitempty"`
 	// IngressService represents the name of the network plugin for the ingress
@@ -87,6 +90,15 @@ type S3Source struct {
 	Prefix string
 }
 
+// OCISource represents a set of yaml documents published as OCI artifacts to a
+// repository of a container registry. Every semver tag of the repository is a
+// release, and every layer of its artifact an asset.
+type OCISource struct {
+	// Repository is the repository of the artifacts, e.g.
+	// "ghcr.io/knative/serving-manifests".
+	Repository string `json:"repository"`
+}
+
 // AssetFilter provides an interface for selecting and managing assets within a
 // release.
 type AssetFilter struct {
@@ -136,6 +148,9 @@ func (s *Source) String() string {
 	if s.S3 != (S3Source{}) {
 		return s.S3.Bucket + "/" + s.S3.Prefix
 	}
+	if s.OCI != (OCISource{}) {
+		return "oci://" + s.OCI.Repository
+	}
 	return "~~error~~"
 }
 
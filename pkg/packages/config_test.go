@@ -50,6 +50,10 @@ b:
       repo: quick/package
     include:
     - small.yaml
+c:
+  primary:
+    oci:
+      repository: "ghcr.io/knative/serving-manifests"
 `
 	want := map[string]*Package{
 		"a": {
@@ -86,6 +90,12 @@ b:
 				},
 			},
 		},
+		"c": {
+			Name: "c",
+			Primary: Source{
+				OCI: OCISource{"ghcr.io/knative/serving-manifests"},
+			},
+		},
 	}
 	yamlFile, err := os.CreateTemp("", "config-yaml")
 	if err != nil {
@@ -18,6 +18,8 @@ limitations under the License.
 package packages
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -34,8 +36,13 @@ import (
 
 // Asset provides an abstract interface for describing a resource which should be stored on the disk.
 type Asset struct {
-	Name      string
-	URL       string
+	Name string
+	URL  string
+	// Digest is the expected "sha256:<hex>" digest of the content of the asset, if known.
+	Digest string
+	// OCI is true if the asset is a layer of an OCI artifact, which is downloaded with the client
+	// authenticating with the registries.
+	OCI       bool
 	secondary bool
 }
 
@@ -188,17 +195,33 @@ func CollectReleaseAssets(p Package, r Release, allReleases map[string][]Release
 	return assets
 }
 
+// Clients are the HTTP clients, which download the assets.
+type Clients struct {
+	// HTTP downloads the assets of the GitHub and S3 sources.
+	HTTP *http.Client
+	// OCI downloads the layers of the OCI artifacts.
+	OCI *http.Client
+}
+
+// forAsset returns the client, which downloads the asset.
+func (c Clients) forAsset(asset Asset) *http.Client {
+	if asset.OCI {
+		return c.OCI
+	}
+	return c.HTTP
+}
+
 // HandleRelease processes the files for a given release of the specified
 // Package.
-func HandleRelease(base string, client *http.Client, p Package, r Release, allReleases map[string][]Release) error {
+func HandleRelease(base string, clients Clients, p Package, r Release, allReleases map[string][]Release) error {
 	if p.Alternatives {
-		return handleAlternatives(base, client, p, r, allReleases)
+		return handleAlternatives(base, clients, p, r, allReleases)
 	}
-	return handlePrimary(base, client, p, r, allReleases)
+	return handlePrimary(base, clients, p, r, allReleases)
 }
 
 // handlePrimary handles the files for a primary-style package.
-func handlePrimary(base string, client *http.Client, p Package, r Release, allReleases map[string][]Release) error {
+func handlePrimary(base string, clients Clients, p Package, r Release, allReleases map[string][]Release) error {
 	assets := CollectReleaseAssets(p, r, allReleases)
 
 	shortName := strings.TrimPrefix(r.TagName, "v")
@@ -217,20 +240,14 @@ func handlePrimary(base string, client *http.Client, p Package, r Release, allRe
 		}
 		defer file.Close()
 		log.Print(asset.URL)
-		fetch, err := client.Get(asset.URL)
-		if err != nil {
-			return fmt.Errorf("unable to fetch %s: %w", asset.URL, err)
-		}
-		defer fetch.Body.Close()
-		_, err = io.Copy(file, fetch.Body)
-		if err != nil {
-			return fmt.Errorf("unable to write to %s: %w", fileName, err)
+		if err := download(clients.forAsset(asset), asset, file, fileName); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func handleAlternatives(base string, client *http.Client, p Package, r Release, allReleases map[string][]Release) error {
+func handleAlternatives(base string, clients Clients, p Package, r Release, allReleases map[string][]Release) error {
 	minor := semver.MajorMinor(r.TagName)
 	if lm := latestMinor(minor, allReleases[p.Primary.String()]); lm.TagName != r.TagName {
 		log.Printf("Skipping %q, %q is newer", r.TagName, lm.TagName)
@@ -272,20 +289,32 @@ func handleAlternatives(base string, client *http.Client, p Package, r Release,
 			}
 			defer file.Close()
 			log.Print(a.URL)
-			fetch, err := client.Get(a.URL)
-			if err != nil {
-				return fmt.Errorf("unable to fetch %s: %w", a.URL, err)
-			}
-			defer fetch.Body.Close()
-			_, err = io.Copy(file, fetch.Body)
-			if err != nil {
-				return fmt.Errorf("unable to write to %s: %w", fileName, err)
+			if err := download(clients.forAsset(a), a, file, fileName); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// download writes the content of the asset to the file, and verifies its digest if the asset has one.
+func download(client *http.Client, asset Asset, file io.Writer, fileName string) error {
+	fetch, err := client.Get(asset.URL)
+	if err != nil {
+		return fmt.Errorf("unable to fetch %s: %w", asset.URL, err)
+	}
+	defer fetch.Body.Close()
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), fetch.Body)
+	if err != nil {
+		return fmt.Errorf("unable to write to %s: %w", fileName, err)
+	}
+	if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); asset.Digest != "" && asset.Digest != digest {
+		return fmt.Errorf("the digest %s of %s does not match %s", digest, asset.URL, asset.Digest)
+	}
+	return nil
+}
+
 // LastN selects the last N minor releases (including all patch releases) for a
 // given sequence of releases, which need not be sorted.
 func LastN(latestVersion string, minors int, allReleases []Release) []Release {
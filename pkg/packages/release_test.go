@@ -18,7 +18,10 @@ limitations under the License.
 package packages
 
 import (
+	"bytes"
+	"io"
 	"math/rand"
+	"net/http"
 	"sort"
 	"strings"
 	"testing"
@@ -379,3 +382,32 @@ func TestCollectReleaseAssets(t *testing.T) {
 		})
 	}
 }
+
+// recordingTransport records the URLs it serves, with an empty body.
+type recordingTransport struct {
+	urls []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.urls = append(t.urls, req.URL.String())
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&bytes.Buffer{}), Request: req}, nil
+}
+
+func TestClientsForAsset(t *testing.T) {
+	plain, registry := &recordingTransport{}, &recordingTransport{}
+	clients := Clients{HTTP: &http.Client{Transport: plain}, OCI: &http.Client{Transport: registry}}
+	for _, asset := range []Asset{
+		{Name: "serving.yaml", URL: "https://github.com/serving.yaml"},
+		{Name: "eventing.yaml", URL: "https://registry.example.com/v2/eventing/blobs/sha256:00", OCI: true},
+	} {
+		if err := download(clients.forAsset(asset), asset, io.Discard, asset.Name); err != nil {
+			t.Fatalf("download() = %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"https://github.com/serving.yaml"}, plain.urls); diff != "" {
+		t.Errorf("Wrong plain downloads (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"https://registry.example.com/v2/eventing/blobs/sha256:00"}, registry.urls); diff != "" {
+		t.Errorf("Wrong OCI downloads (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
+	mf "github.com/manifestival/manifestival"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// ConflictError lists the fields of the manifest, which server-side apply could not set, because
+// other managers own them with a different value.
+type ConflictError struct {
+	Conflicts []string
+}
+
+func (e *ConflictError) Error() string {
+	return "conflicts with other field managers: " + strings.Join(e.Conflicts, "; ")
+}
+
+// serverSideApplier applies manifests with server-side apply.
+type serverSideApplier struct {
+	getter mfdynamic.ResourceGetter
+	force  bool
+}
+
+type applierKey struct{}
+
+func withApplier(ctx context.Context, a *serverSideApplier) context.Context {
+	return context.WithValue(ctx, applierKey{}, a)
+}
+
+func applierFrom(ctx context.Context) *serverSideApplier {
+	a, _ := ctx.Value(applierKey{}).(*serverSideApplier)
+	return a
+}
+
+// DynamicClients provides the dynamic clients of server-side apply and of the plans. Building a
+// dynamic client discovers the API of its cluster, so it is built once for the cluster of the
+// operator, on first use, and once per remote cluster, by the clients of the cluster.
+type DynamicClients struct {
+	config *rest.Config
+	once   sync.Once
+	getter mfdynamic.ResourceGetter
+	err    error
+}
+
+// NewDynamicClients returns the DynamicClients of the cluster of the operator with the config.
+func NewDynamicClients(config *rest.Config) *DynamicClients {
+	return &DynamicClients{config: config}
+}
+
+// ResourceGetter returns the dynamic client of the target cluster of the reconciliation.
+func (c *DynamicClients) ResourceGetter(state *ReconcileState) (mfdynamic.ResourceGetter, error) {
+	if state.IsRemote() {
+		return state.RemoteClients.ResourceGetter()
+	}
+	c.once.Do(func() {
+		if c.getter == nil {
+			c.getter, c.err = mfdynamic.NewForConfig(c.config)
+		}
+	})
+	return c.getter, c.err
+}
+
+// ServerSideApply wraps a Stage installing the manifest, so that it applies the manifest with
+// server-side apply, when the apply strategy of the component asks for it.
+func ServerSideApply(clients *DynamicClients, state *ReconcileState, stage Stage) Stage {
+	return func(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+		strategy := instance.GetSpec().GetApplyStrategy()
+		if !strategy.IsServerSide() {
+			return stage(ctx, manifest, instance)
+		}
+		getter, err := clients.ResourceGetter(state)
+		if err != nil {
+			return fmt.Errorf("failed to create the client for server-side apply: %w", err)
+		}
+		a := &serverSideApplier{getter: getter, force: strategy.ForceConflicts}
+		return stage(withApplier(ctx, a), manifest, instance)
+	}
+}
+
+// apply applies the manifest with server-side apply, if ServerSideApply enabled it, or with the
+// client-side apply of manifestival otherwise.
+func apply(ctx context.Context, manifest mf.Manifest, instance base.KComponent) error {
+	var err error
+	if a := applierFrom(ctx); a != nil {
+		err = a.apply(ctx, manifest)
+	} else {
+		err = manifest.Apply()
+	}
+	if err == nil {
+		recordResources(ctx, resourcesApplied, instance, manifest)
+	}
+	return err
+}
+
+// apply applies every resource of the manifest. Conflicts do not stop the other resources from
+// being applied, and are returned together as a ConflictError.
+func (a *serverSideApplier) apply(ctx context.Context, manifest mf.Manifest) error {
+	var conflicts []string
+	for _, u := range manifest.Resources() {
+		err := a.applyResource(ctx, &u)
+		if err == nil {
+			continue
+		}
+		if apierrors.IsConflict(err) {
+			conflicts = append(conflicts, describeConflict(&u, err))
+			continue
+		}
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+func (a *serverSideApplier) applyResource(ctx context.Context, u *unstructured.Unstructured) error {
+	ri, err := a.getter.ResourceInterface(u)
+	if err != nil {
+		return err
+	}
+	obj := u.DeepCopy()
+	if isWorkload(obj) && hasHorizontalPodOrCustomAutoscaler(obj.GetName()) {
+		// Leave the replicas to the autoscaler owning them.
+		unstructured.RemoveNestedField(obj.Object, "spec", "replicas")
+	}
+	if err := setLastApplied(obj); err != nil {
+		return err
+	}
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Debugw("Applying server-side", "kind", u.GetKind(), "name", resourceKey(u))
+	_, err = ri.Patch(ctx, u.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &a.force,
+	})
+	if err != nil && !apierrors.IsConflict(err) {
+		return fmt.Errorf("failed to apply %s %s: %w", u.GetKind(), resourceKey(u), err)
+	}
+	return err
+}
+
+func isWorkload(u *unstructured.Unstructured) bool {
+	return u.GetKind() == "Deployment" || u.GetKind() == "StatefulSet"
+}
+
+// describeConflict lists the conflicting fields of the resource and their managers.
+func describeConflict(u *unstructured.Unstructured, err error) string {
+	var fields []string
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				fields = append(fields, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Sprintf("%s %s: %v", u.GetKind(), resourceKey(u), err)
+	}
+	return fmt.Sprintf("%s %s: %s", u.GetKind(), resourceKey(u), strings.Join(fields, ", "))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+// applyGetter records the server-side applies, and reports a conflict for the resources named in
+// conflicts, unless the apply is forced.
+type applyGetter struct {
+	applied   map[string]map[string]interface{}
+	conflicts map[string]bool
+}
+
+func (g *applyGetter) ResourceInterface(*unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	return &applyResource{getter: g}, nil
+}
+
+type applyResource struct {
+	dynamic.ResourceInterface
+	getter *applyGetter
+}
+
+func (r *applyResource) Patch(_ context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if pt != types.ApplyPatchType || opts.FieldManager != FieldManager || opts.Force == nil {
+		return nil, apierrors.NewBadRequest("unexpected patch")
+	}
+	if r.getter.conflicts[name] && !*opts.Force {
+		return nil, apierrors.NewApplyConflict([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.template.spec.containers[name=\"controller\"].image",
+		}}, "Apply failed with 1 conflict")
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	r.getter.applied[name] = obj
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+func TestServerSideApply(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+	controller := driftDeployment("gcr.io/controller:v1", 1)
+	webhook := driftDeployment("gcr.io/webhook:v1", 1)
+	webhook.SetName("webhook")
+
+	tests := []struct {
+		name             string
+		strategy         *base.ApplyStrategy
+		conflicts        map[string]bool
+		expectedApplied  []string
+		expectedConflict bool
+	}{{
+		name:            "client-side apply",
+		strategy:        nil,
+		expectedApplied: nil,
+	}, {
+		name:            "server-side apply",
+		strategy:        &base.ApplyStrategy{Type: base.ServerSideApply},
+		expectedApplied: []string{"controller", "webhook"},
+	}, {
+		name:             "conflicts",
+		strategy:         &base.ApplyStrategy{Type: base.ServerSideApply},
+		conflicts:        map[string]bool{"controller": true},
+		expectedApplied:  []string{"webhook"},
+		expectedConflict: true,
+	}, {
+		name:            "forced conflicts",
+		strategy:        &base.ApplyStrategy{Type: base.ServerSideApply, ForceConflicts: true},
+		conflicts:       map[string]bool{"controller": true},
+		expectedApplied: []string{"controller", "webhook"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.New()
+			manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*controller, *webhook}), mf.UseClient(client))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ks := &v1beta1.KnativeServing{
+				Spec: v1beta1.KnativeServingSpec{
+					CommonSpec: base.CommonSpec{ApplyStrategy: test.strategy},
+				},
+			}
+			ks.Status.InitializeConditions()
+			getter := &applyGetter{applied: map[string]map[string]interface{}{}, conflicts: test.conflicts}
+
+			clients := &DynamicClients{getter: getter}
+			stage := ServerSideApply(clients, &ReconcileState{}, Install)
+			err = stage(context.Background(), &manifest, ks)
+
+			var conflict *ConflictError
+			util.AssertEqual(t, errors.As(err, &conflict), test.expectedConflict)
+			if test.expectedConflict {
+				cond := ks.Status.GetCondition(base.InstallSucceeded)
+				util.AssertEqual(t, cond.Message, `Install failed with message: conflicts with other field managers: Deployment knative-serving/controller: `+
+					`.spec.template.spec.containers[name="controller"].image (conflict with "kubectl-edit" using apps/v1)`)
+			} else if err != nil {
+				t.Fatalf("Install() = %v", err)
+			}
+
+			var applied []string
+			for _, name := range []string{"controller", "webhook"} {
+				if _, ok := getter.applied[name]; ok {
+					applied = append(applied, name)
+				}
+			}
+			util.AssertDeepEqual(t, applied, test.expectedApplied)
+			if obj, ok := getter.applied["webhook"]; ok {
+				// The replicas of the webhook are owned by its autoscaler.
+				_, found, _ := unstructured.NestedFieldNoCopy(obj, "spec", "replicas")
+				util.AssertEqual(t, found, false)
+			}
+			if obj, ok := getter.applied["controller"]; ok {
+				// The applied configuration is recorded for the drift detection.
+				_, found, _ := unstructured.NestedString(obj, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+				util.AssertEqual(t, found, true)
+			}
+			if test.strategy == nil {
+				if _, err := client.Get(controller); err != nil {
+					t.Errorf("Expected the client-side apply to create the controller, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestServerSideApplyDisabled(t *testing.T) {
+	called := false
+	stage := ServerSideApply(nil, &ReconcileState{}, func(ctx context.Context, _ *mf.Manifest, _ base.KComponent) error {
+		called = true
+		if applierFrom(ctx) != nil {
+			t.Error("Expected no server-side applier")
+		}
+		return nil
+	})
+	manifest, _ := mf.ManifestFrom(mf.Slice{})
+	if err := stage(context.Background(), &manifest, &v1beta1.KnativeServing{}); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, called, true)
+}
+
+func TestDynamicClientsReused(t *testing.T) {
+	clients := NewDynamicClients(&rest.Config{Host: "https://example.com"})
+	first, err := clients.ResourceGetter(&ReconcileState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := clients.ResourceGetter(&ReconcileState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("Expected the dynamic client to be built once")
+	}
+}
@@ -75,6 +75,8 @@ func CheckWebhookDeployment(ctx context.Context, manifest *mf.Manifest, instance
 		}
 		if !isDeploymentAvailable(deployment) {
 			status.MarkDeploymentsNotReady([]string{"webhook"})
+			RecordEvent(ctx, instance, corev1.EventTypeNormal, ReasonWebhookNotReady,
+				"Waiting for the webhook deployment to become available")
 			return deploymentsNotReadyError{}
 		}
 	}
@@ -91,6 +93,7 @@ func CheckDeployments(ctx context.Context, manifest *mf.Manifest, instance base.
 		resource, err := manifest.Client.Get(&u)
 		if err != nil {
 			status.MarkDeploymentsNotReady([]string{"all"})
+			RecordDeploymentsNotReady(ctx, instance, len(manifest.Filter(mf.ByKind("Deployment")).Resources()))
 			if apierrors.IsNotFound(err) {
 				return nil
 			}
@@ -105,6 +108,7 @@ func CheckDeployments(ctx context.Context, manifest *mf.Manifest, instance base.
 		}
 	}
 
+	RecordDeploymentsNotReady(ctx, instance, len(nonReadyDeployments))
 	if len(nonReadyDeployments) > 0 {
 		status.MarkDeploymentsNotReady(nonReadyDeployments)
 		return deploymentsNotReadyError{}
@@ -114,6 +118,29 @@ func CheckDeployments(ctx context.Context, manifest *mf.Manifest, instance base.
 	return nil
 }
 
+// NotReadyDeployments returns the names of the deployments in the given manifest, which are not available.
+func NotReadyDeployments(manifest *mf.Manifest) ([]string, error) {
+	var names []string
+	for _, u := range manifest.Filter(mf.ByKind("Deployment")).Resources() {
+		resource, err := manifest.Client.Get(&u)
+		if apierrors.IsNotFound(err) {
+			names = append(names, u.GetName())
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		deployment := &appsv1.Deployment{}
+		if err := scheme.Scheme.Convert(resource, deployment, nil); err != nil {
+			return nil, err
+		}
+		if !isDeploymentAvailable(deployment) {
+			names = append(names, deployment.Name)
+		}
+	}
+	return names, nil
+}
+
 func isDeploymentAvailable(d *appsv1.Deployment) bool {
 	for _, c := range d.Status.Conditions {
 		if c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionTrue {
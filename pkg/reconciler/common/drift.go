@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// maxDriftMessageResources bounds the number of resources listed in the ResourcesInSync condition.
+const maxDriftMessageResources = 5
+
+// DriftedResource is a resource of the manifest, whose live object no longer matches it.
+type DriftedResource struct {
+	Resource *unstructured.Unstructured
+	// Fields are the paths of the fields, whose live values differ from the manifest.
+	Fields []string
+}
+
+func (d DriftedResource) String() string {
+	return fmt.Sprintf("%s %s", d.Resource.GetKind(), resourceKey(d.Resource))
+}
+
+// DetectDrift returns a Stage, which compares the live objects of the resources of the manifest with
+// the configuration last applied, according to the drift policy of the component. Drifted resources
+// are reported with an event and the drifted resources metric, and recorded in the state, so that
+// ExcludeDrifted can leave them as they are when the policy is "report".
+func DetectDrift(state *ReconcileState) Stage {
+	return func(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+		state.Drifted = nil
+		policy := instance.GetSpec().GetDriftPolicy()
+		if policy == base.DriftPolicyIgnore {
+			instance.GetStatus().ClearResourcesInSync()
+			RecordDriftedResources(ctx, instance, nil)
+			return nil
+		}
+		drifted, err := FindDrift(manifest)
+		if err != nil {
+			return err
+		}
+		recordDrift(ctx, instance, drifted)
+
+		if len(drifted) == 0 {
+			instance.GetStatus().MarkResourcesInSync()
+			return nil
+		}
+		names := make([]string, 0, len(drifted))
+		for _, d := range drifted {
+			names = append(names, d.String())
+		}
+		msg := strings.Join(names, ", ")
+		if len(names) > maxDriftMessageResources {
+			msg = fmt.Sprintf("%s and %d more", strings.Join(names[:maxDriftMessageResources], ", "),
+				len(names)-maxDriftMessageResources)
+		}
+		if policy == base.DriftPolicyCorrect {
+			instance.GetStatus().MarkResourcesDriftCorrected("Reapplied drifted resources: " + msg)
+			return nil
+		}
+		state.Drifted = drifted
+		instance.GetStatus().MarkResourcesDrifted("Drifted resources: " + msg)
+		return nil
+	}
+}
+
+// ExcludeDrifted wraps a Stage applying the manifest, so that the resources found drifted by
+// DetectDrift are left as they are. The manifest passed to the later stages is not affected, so
+// the drifted resources are not mistaken for obsolete ones.
+func ExcludeDrifted(state *ReconcileState, stage Stage) Stage {
+	return func(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+		if len(state.Drifted) == 0 {
+			return stage(ctx, manifest, instance)
+		}
+		drifted := make([]unstructured.Unstructured, 0, len(state.Drifted))
+		for _, d := range state.Drifted {
+			drifted = append(drifted, *d.Resource)
+		}
+		excluded, err := mf.ManifestFrom(mf.Slice(drifted))
+		if err != nil {
+			return err
+		}
+		filtered := manifest.Filter(mf.Not(mf.In(excluded)))
+		return stage(ctx, &filtered, instance)
+	}
+}
+
+// FindDrift returns the resources of the manifest, whose live objects differ from the configuration the
+// operator last applied, as recorded in their last-applied-configuration annotation. Changes of the
+// manifest itself, like an upgrade or a change of the spec, are therefore not drift. Only the fields set
+// in the last applied configuration are compared, and fields owned by other appliers or through a
+// subresource, like the replicas scaled by an autoscaler, are ignored. Resources, which do not exist yet
+// or have no last applied configuration, are not considered drifted.
+func FindDrift(manifest *mf.Manifest) ([]DriftedResource, error) {
+	var drifted []DriftedResource
+	for _, u := range manifest.Filter(mf.NoCRDs).Resources() {
+		live, err := manifest.Client.Get(&u)
+		if err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", resourceKey(&u), err)
+		}
+		applied, err := lastApplied(live)
+		if err != nil {
+			return nil, err
+		}
+		if applied == nil {
+			continue
+		}
+		fields, err := driftedFields(applied, live)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			drifted = append(drifted, DriftedResource{Resource: u.DeepCopy(), Fields: fields})
+		}
+	}
+	return drifted, nil
+}
+
+// lastApplied returns the configuration recorded in the last-applied-configuration annotation of the
+// live object, or nil if it has none.
+func lastApplied(live *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data, ok := live.GetAnnotations()[corev1.LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	applied := &unstructured.Unstructured{}
+	if err := applied.UnmarshalJSON([]byte(data)); err != nil {
+		return nil, fmt.Errorf("failed to parse the last applied configuration of %s: %w", resourceKey(live), err)
+	}
+	return applied, nil
+}
+
+// setLastApplied records the resource in its last-applied-configuration annotation, as the client-side
+// apply does, so that FindDrift compares its live object with it. CRDs are not recorded, as they are
+// not compared and may exceed the size of the annotations.
+func setLastApplied(u *unstructured.Unstructured) error {
+	if u.GetKind() == "CustomResourceDefinition" {
+		return nil
+	}
+	annotations := u.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	u.SetAnnotations(annotations)
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1.LastAppliedConfigAnnotation] = string(data)
+	u.SetAnnotations(annotations)
+	return nil
+}
+
+func driftedFields(desired, live *unstructured.Unstructured) ([]string, error) {
+	// Normalize the numbers of both objects to the same types.
+	d, err := normalize(desired.Object)
+	if err != nil {
+		return nil, err
+	}
+	l, err := normalize(live.Object)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := othersFields(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths [][]interface{}
+	for key, value := range d {
+		switch key {
+		case "status", "apiVersion", "kind":
+			continue
+		case "metadata":
+			md, _ := value.(map[string]interface{})
+			lmd, _ := l["metadata"].(map[string]interface{})
+			for _, field := range []string{"labels", "annotations"} {
+				compareFields(md[field], lmd[field], []interface{}{"metadata", field}, &paths)
+			}
+		default:
+			compareFields(value, l[key], []interface{}{key}, &paths)
+		}
+	}
+
+	fields := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if isOwnedByOthers(owned, path) {
+			continue
+		}
+		fields = append(fields, fieldPathString(path))
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// compareFields appends the paths of the fields set in desired, which differ in live.
+func compareFields(desired, live interface{}, path []interface{}, paths *[][]interface{}) {
+	switch d := desired.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			if len(d) > 0 {
+				*paths = append(*paths, path)
+			}
+			return
+		}
+		for key, value := range d {
+			compareFields(value, l[key], appendPath(path, key), paths)
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			if len(d) > 0 {
+				*paths = append(*paths, path)
+			}
+			return
+		}
+		if len(d) != len(l) {
+			*paths = append(*paths, path)
+			return
+		}
+		for i := range d {
+			compareFields(d[i], l[i], appendPath(path, i), paths)
+		}
+	default:
+		if !equalScalars(desired, live) {
+			*paths = append(*paths, path)
+		}
+	}
+}
+
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	p := make([]interface{}, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
+
+func equalScalars(desired, live interface{}) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	// The API server stores quantities in their canonical form, e.g. "1000m" as "1".
+	ds, ok := desired.(string)
+	if !ok {
+		return false
+	}
+	ls, ok := live.(string)
+	if !ok {
+		return false
+	}
+	dq, err := resource.ParseQuantity(ds)
+	if err != nil {
+		return false
+	}
+	lq, err := resource.ParseQuantity(ls)
+	if err != nil {
+		return false
+	}
+	return dq.Cmp(lq) == 0
+}
+
+func normalize(obj map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// othersFields returns the field sets of the live object, which are owned by other appliers or have
+// been set through a subresource.
+func othersFields(live *unstructured.Unstructured) ([]map[string]interface{}, error) {
+	var owned []map[string]interface{}
+	for _, entry := range live.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		byOtherApplier := entry.Operation == metav1.ManagedFieldsOperationApply && entry.Manager != FieldManager
+		if !byOtherApplier && entry.Subresource == "" {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse the managed fields of %s: %w", resourceKey(live), err)
+		}
+		owned = append(owned, fields)
+	}
+	return owned, nil
+}
+
+// isOwnedByOthers returns true if one of the field sets contains the path. List items are identified
+// by their keys in field sets, so paths are only followed up to the first list index.
+func isOwnedByOthers(owned []map[string]interface{}, path []interface{}) bool {
+	for _, fields := range owned {
+		node := fields
+		found := true
+		for _, segment := range path {
+			key, ok := segment.(string)
+			if !ok {
+				break
+			}
+			next, ok := node["f:"+key].(map[string]interface{})
+			if !ok {
+				found = false
+				break
+			}
+			node = next
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldPathString(path []interface{}) string {
+	var b strings.Builder
+	for _, segment := range path {
+		switch s := segment.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(s) + "]")
+		default:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			fmt.Fprint(&b, s)
+		}
+	}
+	return b.String()
+}
+
+// recordDrift updates the drifted resources metric of the component, and emits an event for each
+// drifted resource.
+func recordDrift(ctx context.Context, instance base.KComponent, drifted []DriftedResource) {
+	RecordDriftedResources(ctx, instance, drifted)
+	for _, d := range drifted {
+		RecordEvent(ctx, instance, corev1.EventTypeWarning, ReasonResourceDrifted, "%s has drifted from the manifest: %s",
+			d.String(), strings.Join(d.Fields, ", "))
+	}
+}
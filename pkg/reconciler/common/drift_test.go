@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func driftDeployment(image string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "controller",
+			"namespace": "knative-serving",
+			"labels":    map[string]interface{}{"app": "controller"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "controller",
+							"image": image,
+							"resources": map[string]interface{}{
+								"requests": map[string]interface{}{"cpu": "1000m"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+// lastAppliedBy records the applied configuration in the last-applied-configuration annotation of the
+// live object.
+func lastAppliedBy(live, applied *unstructured.Unstructured) *unstructured.Unstructured {
+	configuration := applied.DeepCopy()
+	if err := setLastApplied(configuration); err != nil {
+		panic(err)
+	}
+	annotations := live.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1.LastAppliedConfigAnnotation] = configuration.GetAnnotations()[corev1.LastAppliedConfigAnnotation]
+	live.SetAnnotations(annotations)
+	return live
+}
+
+func TestFindDrift(t *testing.T) {
+	applied := driftDeployment("gcr.io/controller:v1", 1)
+
+	tests := []struct {
+		name     string
+		desired  *unstructured.Unstructured
+		live     func() *unstructured.Unstructured
+		expected []string
+	}{{
+		name:     "resource does not exist",
+		live:     nil,
+		expected: nil,
+	}, {
+		name: "resource not applied by the operator",
+		live: func() *unstructured.Unstructured {
+			return driftDeployment("gcr.io/controller:edited", 3)
+		},
+		expected: nil,
+	}, {
+		name:    "manifest changed",
+		desired: driftDeployment("gcr.io/controller:v2", 2),
+		live: func() *unstructured.Unstructured {
+			return lastAppliedBy(driftDeployment("gcr.io/controller:v1", 1), applied)
+		},
+		expected: nil,
+	}, {
+		name: "fields set by others",
+		live: func() *unstructured.Unstructured {
+			u := lastAppliedBy(driftDeployment("gcr.io/controller:v1", 1), applied)
+			u.GetAnnotations()["deployment.kubernetes.io/revision"] = "1"
+			unstructured.SetNestedField(u.Object, "ClusterFirst", "spec", "template", "spec", "dnsPolicy")
+			unstructured.SetNestedField(u.Object, int64(1), "status", "replicas")
+			// The API server stores the canonical form of quantities.
+			containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+			unstructured.SetNestedField(containers[0].(map[string]interface{}), "1", "resources", "requests", "cpu")
+			unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", "containers")
+			return u
+		},
+		expected: nil,
+	}, {
+		name: "fields changed",
+		live: func() *unstructured.Unstructured {
+			u := lastAppliedBy(driftDeployment("gcr.io/controller:edited", 3), applied)
+			u.SetLabels(map[string]string{"app": "edited"})
+			return u
+		},
+		expected: []string{"metadata.labels.app", "spec.replicas", "spec.template.spec.containers[0].image"},
+	}, {
+		name: "fields owned by other managers",
+		live: func() *unstructured.Unstructured {
+			u := lastAppliedBy(driftDeployment("gcr.io/controller:edited", 3), applied)
+			u.SetManagedFields([]metav1.ManagedFieldsEntry{{
+				Manager:     "kube-controller-manager",
+				Operation:   metav1.ManagedFieldsOperationUpdate,
+				Subresource: "scale",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			}, {
+				Manager:   "kubectl-edit",
+				Operation: metav1.ManagedFieldsOperationUpdate,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`)},
+			}})
+			return u
+		},
+		expected: []string{"spec.template.spec.containers[0].image"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.New()
+			if test.live != nil {
+				if err := client.Create(test.live()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			desired := applied
+			if test.desired != nil {
+				desired = test.desired
+			}
+			manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*desired}), mf.UseClient(client))
+			if err != nil {
+				t.Fatal(err)
+			}
+			drifted, err := FindDrift(&manifest)
+			if err != nil {
+				t.Fatalf("FindDrift() = %v", err)
+			}
+			var fields []string
+			for _, d := range drifted {
+				fields = append(fields, d.Fields...)
+			}
+			util.AssertDeepEqual(t, fields, test.expected)
+		})
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	desired := driftDeployment("gcr.io/controller:v1", 1)
+	configMap := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "config-logging", "namespace": "knative-serving"},
+		"data":       map[string]interface{}{"loglevel": "info"},
+	}}
+
+	tests := []struct {
+		name            string
+		policy          base.DriftPolicy
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedImage   string
+		expectedDrifted int
+	}{{
+		name:           "ignore",
+		policy:         "",
+		expectedStatus: "",
+		expectedImage:  "gcr.io/controller:v1",
+	}, {
+		name:            "report",
+		policy:          base.DriftPolicyReport,
+		expectedStatus:  corev1.ConditionFalse,
+		expectedReason:  "ResourcesDrifted",
+		expectedImage:   "gcr.io/controller:edited",
+		expectedDrifted: 1,
+	}, {
+		name:           "correct",
+		policy:         base.DriftPolicyCorrect,
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: "DriftCorrected",
+		expectedImage:  "gcr.io/controller:v1",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.New(lastAppliedBy(driftDeployment("gcr.io/controller:edited", 1), desired))
+			manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*desired, configMap}), mf.UseClient(client))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ks := &v1beta1.KnativeServing{
+				ObjectMeta: metav1.ObjectMeta{Name: "knative-serving", Namespace: "knative-serving"},
+				Spec: v1beta1.KnativeServingSpec{
+					CommonSpec: base.CommonSpec{DriftPolicy: test.policy},
+				},
+			}
+			ks.Status.InitializeConditions()
+
+			var state ReconcileState
+			stages := Stages{
+				DetectDrift(&state),
+				ExcludeDrifted(&state, func(_ context.Context, manifest *mf.Manifest, _ base.KComponent) error {
+					return manifest.Apply()
+				}),
+			}
+			if _, err := stages.Execute(context.Background(), &manifest, ks); err != nil {
+				t.Fatalf("Execute() = %v", err)
+			}
+
+			util.AssertEqual(t, len(state.Drifted), test.expectedDrifted)
+			cond := ks.Status.GetCondition(base.ResourcesInSync)
+			if test.expectedStatus == "" {
+				if cond != nil {
+					t.Errorf("Expected no ResourcesInSync condition, got %v", cond)
+				}
+			} else {
+				util.AssertEqual(t, cond.Status, test.expectedStatus)
+				util.AssertEqual(t, cond.Reason, test.expectedReason)
+			}
+			// The manifest still contains the drifted resource, so that it is not deleted as obsolete.
+			util.AssertEqual(t, len(manifest.Resources()), 2)
+
+			live, err := client.Get(desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+			containers, _, _ := unstructured.NestedSlice(live.Object, "spec", "template", "spec", "containers")
+			util.AssertEqual(t, containers[0].(map[string]interface{})["image"], test.expectedImage)
+			if _, err := client.Get(&configMap); err != nil {
+				t.Errorf("Expected the ConfigMap to be applied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDetectDriftManifestChanged(t *testing.T) {
+	// The live object is as last applied, and the spec of the KnativeServing changed the image since.
+	applied := driftDeployment("gcr.io/controller:v1", 1)
+	client := fake.New(lastAppliedBy(driftDeployment("gcr.io/controller:v1", 1), applied))
+	desired := driftDeployment("gcr.io/controller:v2", 1)
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*desired}), mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := &v1beta1.KnativeServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "knative-serving", Namespace: "knative-serving"},
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{DriftPolicy: base.DriftPolicyReport},
+		},
+	}
+	ks.Status.InitializeConditions()
+
+	var state ReconcileState
+	stages := Stages{
+		DetectDrift(&state),
+		ExcludeDrifted(&state, func(_ context.Context, manifest *mf.Manifest, _ base.KComponent) error {
+			return manifest.Apply()
+		}),
+	}
+	if _, err := stages.Execute(context.Background(), &manifest, ks); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+
+	util.AssertEqual(t, len(state.Drifted), 0)
+	util.AssertEqual(t, ks.Status.GetCondition(base.ResourcesInSync).Status, corev1.ConditionTrue)
+	live, err := client.Get(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	containers, _, _ := unstructured.NestedSlice(live.Object, "spec", "template", "spec", "containers")
+	util.AssertEqual(t, containers[0].(map[string]interface{})["image"], "gcr.io/controller:v2")
+
+	// The change is recorded as the last applied configuration, so it is not drift either.
+	drifted, err := FindDrift(&manifest)
+	if err != nil {
+		t.Fatalf("FindDrift() = %v", err)
+	}
+	util.AssertEqual(t, len(drifted), 0)
+}
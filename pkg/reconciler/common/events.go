@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// The reasons of the events emitted on the components.
+const (
+	// ReasonInstallStarted is emitted when a version, which is not installed yet, is applied.
+	ReasonInstallStarted = "InstallStarted"
+	// ReasonVersionUpgraded is emitted when a new version has become ready.
+	ReasonVersionUpgraded = "VersionUpgraded"
+	// ReasonObsoleteResourceDeleted is emitted for every resource deleted, because it is no longer
+	// part of the manifest.
+	ReasonObsoleteResourceDeleted = "ObsoleteResourceDeleted"
+	// ReasonWebhookNotReady is emitted while the resources depending on the webhook wait for it.
+	ReasonWebhookNotReady = "WebhookNotReady"
+	// ReasonTLSResourcesRemoved is emitted when the TLS resources are deleted, because transport
+	// encryption is disabled.
+	ReasonTLSResourcesRemoved = "TLSResourcesRemoved"
+	// ReasonRemoteClusterResolved is emitted when the cluster referenced by spec.clusterProfileRef
+	// has been resolved.
+	ReasonRemoteClusterResolved = "RemoteClusterResolved"
+	// ReasonResourceDrifted is emitted for every resource, which has drifted from the manifest.
+	ReasonResourceDrifted = "ResourceDrifted"
+)
+
+// RecordEvent emits an event on the component, if the context carries an event recorder.
+func RecordEvent(ctx context.Context, instance base.KComponent, eventtype, reason, messageFmt string, args ...interface{}) {
+	recorder := controller.GetEventRecorder(ctx)
+	obj, ok := instance.(runtime.Object)
+	if recorder == nil || !ok {
+		return
+	}
+	recorder.Eventf(obj, eventtype, reason, messageFmt, args...)
+}
+
+// isConditionTrue returns true if the condition of the status is true.
+func isConditionTrue(status base.KComponentStatus, t apis.ConditionType) bool {
+	s, ok := status.(interface {
+		GetCondition(apis.ConditionType) *apis.Condition
+	})
+	if !ok {
+		return false
+	}
+	return s.GetCondition(t).IsTrue()
+}
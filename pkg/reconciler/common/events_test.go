@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestInstallEvents(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+	client := fake.New()
+	current := configMap("config-current", nil)
+	obsolete := configMap("config-obsolete", nil)
+	installed, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*current, *obsolete}), mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := installed.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*current}), mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{Version: "0.26.0"},
+		},
+		Status: v1beta1.KnativeServingStatus{
+			Version:   "0.25.0",
+			Manifests: []string{"testdata/kodata/knative-serving/0.25.0"},
+		},
+	}
+	ks.Status.InitializeConditions()
+
+	stages := Stages{
+		Install,
+		MarkStatusSuccess,
+		DeleteObsoleteResources(ctx, ks, func(context.Context, base.KComponent) (*mf.Manifest, error) {
+			return &installed, nil
+		}),
+	}
+	if _, err := stages.Execute(ctx, &manifest, ks); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+	util.AssertDeepEqual(t, drainEvents(recorder), []string{
+		"Normal InstallStarted Installing version 0.26.0",
+		"Normal VersionUpgraded Upgraded from version 0.25.0 to 0.26.0",
+		"Normal ObsoleteResourceDeleted Deleted obsolete ConfigMap knative-serving/config-obsolete",
+	})
+
+	// Nothing happens when the installed version is reconciled again.
+	if _, err := stages.Execute(ctx, &manifest, ks); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+	util.AssertEqual(t, len(drainEvents(recorder)), 0)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// MaxHistory is the maximum number of releases recorded in status.history.
+const MaxHistory = 10
+
+// ManifestDigest returns the sha256 digest of the resources in the manifest.
+func ManifestDigest(manifest *mf.Manifest) (string, error) {
+	h := sha256.New()
+	for _, u := range manifest.Resources() {
+		// The keys of the maps are sorted, so the serialization is stable.
+		data, err := json.Marshal(u.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		h.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RecordHistory records the installed release in the status history, unless it is already the most
+// recent entry. It is meant to run right after MarkStatusSuccess.
+func RecordHistory(_ context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	status := instance.GetStatus()
+	digest, err := ManifestDigest(manifest)
+	if err != nil {
+		return err
+	}
+	history := status.GetHistory()
+	if len(history) != 0 && history[0].Digest == digest && history[0].Version == status.GetVersion() {
+		return nil
+	}
+
+	installTime := metav1.NewTime(now())
+	record := base.InstallRecord{
+		Version:     status.GetVersion(),
+		Manifests:   status.GetManifests(),
+		Digest:      digest,
+		InstallTime: installTime,
+	}
+	updated := make([]base.InstallRecord, 0, MaxHistory)
+	updated = append(updated, record)
+	for i := range history {
+		if len(updated) == MaxHistory {
+			break
+		}
+		previous := *history[i].DeepCopy()
+		if i == 0 {
+			previous.SupersededTime = &installTime
+		}
+		updated = append(updated, previous)
+	}
+	status.SetHistory(updated)
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestManifestDigest(t *testing.T) {
+	first, _ := mf.ManifestFrom(mf.Slice{*NamespacedResource("v1", "ConfigMap", "test", "config")})
+	second, _ := mf.ManifestFrom(mf.Slice{*NamespacedResource("v1", "ConfigMap", "test", "config")})
+	other, _ := mf.ManifestFrom(mf.Slice{*NamespacedResource("v1", "ConfigMap", "test", "other")})
+
+	digest, err := ManifestDigest(&first)
+	if err != nil {
+		t.Fatalf("ManifestDigest() = %v", err)
+	}
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Errorf("ManifestDigest() = %s, want a sha256 digest", digest)
+	}
+	secondDigest, _ := ManifestDigest(&second)
+	util.AssertEqual(t, secondDigest, digest)
+	otherDigest, _ := ManifestDigest(&other)
+	if otherDigest == digest {
+		t.Errorf("ManifestDigest() = %s for different manifests", digest)
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+
+	ks := &v1beta1.KnativeServing{}
+	install := func(version string, resources ...unstructured.Unstructured) {
+		now = func() time.Time { return start.Add(time.Duration(len(ks.Status.History)) * time.Hour) }
+		ks.Status.Version = version
+		ks.Status.Manifests = []string{"knative-serving/" + version}
+		manifest, _ := mf.ManifestFrom(mf.Slice(resources))
+		if err := RecordHistory(context.TODO(), &manifest, ks); err != nil {
+			t.Fatalf("RecordHistory() = %v", err)
+		}
+	}
+
+	install("1.22.0", *NamespacedResource("v1", "ConfigMap", "test", "config"))
+	install("1.22.0", *NamespacedResource("v1", "ConfigMap", "test", "config"))
+	util.AssertEqual(t, len(ks.Status.History), 1)
+	util.AssertEqual(t, ks.Status.History[0].Version, "1.22.0")
+	util.AssertDeepEqual(t, ks.Status.History[0].Manifests, []string{"knative-serving/1.22.0"})
+	if ks.Status.History[0].SupersededTime != nil {
+		t.Errorf("SupersededTime = %v, want nil", ks.Status.History[0].SupersededTime)
+	}
+
+	install("1.23.0", *NamespacedResource("v1", "ConfigMap", "test", "config"))
+	util.AssertEqual(t, len(ks.Status.History), 2)
+	util.AssertEqual(t, ks.Status.History[0].Version, "1.23.0")
+	util.AssertEqual(t, ks.Status.History[1].Version, "1.22.0")
+	util.AssertEqual(t, ks.Status.History[1].SupersededTime.Time, ks.Status.History[0].InstallTime.Time)
+
+	// A change of the applied manifest is recorded, even if the version is the same.
+	install("1.23.0", *NamespacedResource("v1", "ConfigMap", "test", "other"))
+	util.AssertEqual(t, len(ks.Status.History), 3)
+	if ks.Status.History[0].Digest == ks.Status.History[1].Digest {
+		t.Errorf("Digest = %s, want a new digest", ks.Status.History[0].Digest)
+	}
+
+	for i := 0; i < MaxHistory; i++ {
+		install("1.23.0", *NamespacedResource("v1", "ConfigMap", "test", fmt.Sprintf("config-%d", i)))
+	}
+	util.AssertEqual(t, len(ks.Status.History), MaxHistory)
+	util.AssertEqual(t, ks.Status.History[MaxHistory-1].SupersededTime != nil, true)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/oci"
+)
+
+// imageResolver resolves the tags of the images to their digests.
+var imageResolver = oci.NewClient()
+
+// podTemplateKinds are the kinds of the workloads, whose pod template is at spec.template.
+var podTemplateKinds = map[string]bool{
+	"Deployment":  true,
+	"DaemonSet":   true,
+	"StatefulSet": true,
+	"Job":         true,
+}
+
+// ImageDigestTransform pins the images of the workloads and of the caching.internal.knative.dev
+// Images to their digests, when spec.registry.pinDigests is set. It must run after ImageTransform.
+// The digests are recorded in status.resolvedImages, and reused as long as the images do not change,
+// so that the registry is only asked for the digests of new images.
+func ImageDigestTransform(ctx context.Context, instance base.KComponent) mf.Transformer {
+	status := instance.GetStatus()
+	if !instance.GetSpec().GetRegistry().PinDigests {
+		if len(status.GetResolvedImages()) > 0 {
+			status.SetResolvedImages(nil)
+		}
+		return func(*unstructured.Unstructured) error { return nil }
+	}
+
+	known := make(map[string]string, len(status.GetResolvedImages()))
+	for _, r := range status.GetResolvedImages() {
+		known[r.Image] = r.Digest
+	}
+	resolved := map[string]string{}
+	pin := func(image string) (string, error) {
+		if image == "" || strings.Contains(image, "@") {
+			return image, nil
+		}
+		digest, ok := resolved[image]
+		if !ok {
+			if digest, ok = known[image]; !ok {
+				ref, err := name.ParseReference(image)
+				if err != nil {
+					return "", fmt.Errorf("invalid image %q: %w", image, err)
+				}
+				if digest, err = imageResolver.Resolve(ctx, ref); err != nil {
+					return "", err
+				}
+			}
+			resolved[image] = digest
+		}
+		return image + "@" + digest, nil
+	}
+
+	return func(u *unstructured.Unstructured) error {
+		if err := updateImages(u, pin); err != nil {
+			return fmt.Errorf("failed to pin the images of %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		images := make([]base.ResolvedImage, 0, len(resolved))
+		for image, digest := range resolved {
+			images = append(images, base.ResolvedImage{Image: image, Digest: digest})
+		}
+		sort.Slice(images, func(i, j int) bool { return images[i].Image < images[j].Image })
+		status.SetResolvedImages(images)
+		return nil
+	}
+}
+
+// updateImages replaces the images of the containers and init containers of the workload, or the
+// image of the caching.internal.knative.dev Image, with the results of the update function.
+func updateImages(u *unstructured.Unstructured, update func(string) (string, error)) error {
+	if u.GetKind() == "Image" && u.GetAPIVersion() == "caching.internal.knative.dev/v1alpha1" {
+		image, _, _ := unstructured.NestedString(u.Object, "spec", "image")
+		updated, err := update(image)
+		if err != nil {
+			return err
+		}
+		return unstructured.SetNestedField(u.Object, updated, "spec", "image")
+	}
+	if !podTemplateKinds[u.GetKind()] {
+		return nil
+	}
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, found, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+		if err != nil || !found {
+			continue
+		}
+		for i := range containers {
+			container, ok := containers[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			image, _ := container["image"].(string)
+			updated, err := update(image)
+			if err != nil {
+				return err
+			}
+			if updated != "" {
+				container["image"] = updated
+			}
+		}
+		if err := unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/oci"
+	ocitesting "knative.dev/operator/pkg/oci/testing"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestMirrorWorkloadImages(t *testing.T) {
+	registry := &base.Registry{
+		Mirrors: []base.RegistryMirror{{Prefix: "gcr.io/knative-releases", Mirror: "mirror.example.com/knative"}},
+	}
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: "gcr.io/knative-releases/init:v1"}},
+		Containers: []corev1.Container{{
+			Name:  "controller",
+			Image: "gcr.io/knative-releases/controller:v1",
+			Env: []corev1.EnvVar{
+				{Name: "APISERVER_RA_IMAGE", Value: "gcr.io/knative-releases/adapter:v1"},
+				{Name: "OTHER", Value: "gcr.io/other/image:v1"},
+			},
+		}},
+	}
+	for _, obj := range []interface{}{
+		util.MakeDeployment("deployment", podSpec),
+		util.MakeDaemonSet("daemonset", podSpec),
+		util.MakeStatefulSet("statefulset", podSpec),
+		util.MakeJob("job", podSpec),
+	} {
+		u := util.MakeUnstructured(t, obj)
+		if err := ImageTransform(registry, log)(&u); err != nil {
+			t.Fatalf("ImageTransform() = %v", err)
+		}
+		util.AssertEqual(t, containerImage(t, &u, "initContainers"), "mirror.example.com/knative/init:v1")
+		util.AssertEqual(t, containerImage(t, &u, "containers"), "mirror.example.com/knative/controller:v1")
+		containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		env := containers[0].(map[string]interface{})["env"].([]interface{})
+		util.AssertEqual(t, env[0].(map[string]interface{})["value"], "mirror.example.com/knative/adapter:v1")
+		util.AssertEqual(t, env[1].(map[string]interface{})["value"], "gcr.io/other/image:v1")
+	}
+}
+
+func TestImageDigestTransform(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	controller := registry.Host() + "/knative/controller:v1"
+	webhook := registry.Host() + "/knative/webhook:v1"
+	controllerDigest := registry.Push("knative/controller", "v1", oci.File{Name: "layer", Data: []byte("controller")})
+	webhookDigest := registry.Push("knative/webhook", "v1", oci.File{Name: "layer", Data: []byte("webhook")})
+	pinnedImage := registry.Host() + "/knative/queue@" + webhookDigest
+
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: pinnedImage}},
+		Containers:     []corev1.Container{{Name: "controller", Image: controller}},
+	}
+	objs := []interface{}{
+		util.MakeDeployment("deployment", podSpec),
+		util.MakeDaemonSet("daemonset", podSpec),
+		util.MakeStatefulSet("statefulset", podSpec),
+		util.MakeJob("job", podSpec),
+		util.MakeImage("webhook", webhook),
+	}
+	var resources []unstructured.Unstructured
+	for _, obj := range objs {
+		resources = append(resources, util.MakeUnstructured(t, obj))
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{Registry: base.Registry{PinDigests: true}},
+		},
+	}
+	transformed, err := manifest.Transform(ImageDigestTransform(context.Background(), instance))
+	if err != nil {
+		t.Fatalf("Transform() = %v", err)
+	}
+	for _, u := range transformed.Resources() {
+		if u.GetKind() == "Image" {
+			image, _, _ := unstructured.NestedString(u.Object, "spec", "image")
+			util.AssertEqual(t, image, webhook+"@"+webhookDigest)
+			continue
+		}
+		util.AssertEqual(t, containerImage(t, &u, "containers"), controller+"@"+controllerDigest)
+		util.AssertEqual(t, containerImage(t, &u, "initContainers"), pinnedImage)
+	}
+	util.AssertDeepEqual(t, instance.Status.ResolvedImages, []base.ResolvedImage{
+		{Image: controller, Digest: controllerDigest},
+		{Image: webhook, Digest: webhookDigest},
+	})
+
+	// The recorded digests are reused.
+	requests := registry.Requests()
+	if _, err := manifest.Transform(ImageDigestTransform(context.Background(), instance)); err != nil {
+		t.Fatalf("Transform() = %v", err)
+	}
+	util.AssertEqual(t, registry.Requests(), requests)
+
+	// The digests are not recorded anymore, when pinning is disabled.
+	instance.Spec.Registry.PinDigests = false
+	if _, err := manifest.Transform(ImageDigestTransform(context.Background(), instance)); err != nil {
+		t.Fatalf("Transform() = %v", err)
+	}
+	util.AssertEqual(t, len(instance.Status.ResolvedImages), 0)
+}
+
+func TestImageDigestTransformUnknownImage(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	u := util.MakeUnstructured(t, util.MakeImage("queue", registry.Host()+"/knative/queue:v1"))
+	instance := &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{Registry: base.Registry{PinDigests: true}},
+		},
+	}
+	if err := ImageDigestTransform(context.Background(), instance)(&u); err == nil {
+		t.Fatal("expected an error for an image missing from the registry")
+	}
+}
+
+func containerImage(t *testing.T, u *unstructured.Unstructured, field string) string {
+	t.Helper()
+	containers, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+	if err != nil || len(containers) == 0 {
+		t.Fatalf("no %s in %s %s", field, u.GetKind(), u.GetName())
+	}
+	return containers[0].(map[string]interface{})["image"].(string)
+}
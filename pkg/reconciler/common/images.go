@@ -116,6 +116,7 @@ func ImageTransform(registry *base.Registry, log *zap.SugaredLogger) mf.Transfor
 				}
 				container.Image = strings.ReplaceAll(registry.Default, containerNameVariable, imageName)
 			}
+			container.Image = mirrorImage(container.Image, registry.Mirrors)
 
 			for j := range container.Env {
 				env := &container.Env[j]
@@ -123,8 +124,14 @@ func ImageTransform(registry *base.Registry, log *zap.SugaredLogger) mf.Transfor
 					env.Value = image
 					env.ValueFrom = nil
 				}
+				// Environment variables like APISERVER_RA_IMAGE reference the images of the workloads
+				// created by the controllers.
+				env.Value = mirrorImage(env.Value, registry.Mirrors)
 			}
 		}
+		for i := range podSpec.InitContainers {
+			podSpec.InitContainers[i].Image = mirrorImage(podSpec.InitContainers[i].Image, registry.Mirrors)
+		}
 
 		// Add potential ImagePullSecrets
 		if len(registry.ImagePullSecrets) > 0 {
@@ -164,6 +171,7 @@ func updateCachingImage(registry *base.Registry, u *unstructured.Unstructured, l
 		}
 		img.Spec.Image = strings.ReplaceAll(registry.Default, containerNameVariable, imageName)
 	}
+	img.Spec.Image = mirrorImage(img.Spec.Image, registry.Mirrors)
 
 	// Add potential ImagePullSecrets
 	if len(registry.ImagePullSecrets) > 0 {
@@ -182,6 +190,28 @@ func updateCachingImage(registry *base.Registry, u *unstructured.Unstructured, l
 	return nil
 }
 
+// mirrorImage replaces the longest prefix of the image matching a mirror with the mirror. Prefixes
+// only match whole path segments, so gcr.io/knative does not match gcr.io/knative-releases/controller.
+func mirrorImage(image string, mirrors []base.RegistryMirror) string {
+	var best *base.RegistryMirror
+	for i := range mirrors {
+		prefix := strings.TrimSuffix(mirrors[i].Prefix, delimiter)
+		if prefix == "" || !strings.HasPrefix(image, prefix) {
+			continue
+		}
+		if rest := image[len(prefix):]; rest != "" && !strings.ContainsAny(rest[:1], "/:@") {
+			continue
+		}
+		if best == nil || len(prefix) > len(strings.TrimSuffix(best.Prefix, delimiter)) {
+			best = &mirrors[i]
+		}
+	}
+	if best == nil {
+		return image
+	}
+	return strings.TrimSuffix(best.Mirror, delimiter) + image[len(strings.TrimSuffix(best.Prefix, delimiter)):]
+}
+
 func getImageName(fullImageURL string) string {
 	if !strings.Contains(fullImageURL, "/") {
 		return ""
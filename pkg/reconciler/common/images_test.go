@@ -329,6 +329,37 @@ func TestImageTransform(t *testing.T) {
 		expected: caching.ImageSpec{
 			Image: "new-registry.io/test/path/queue:new-tag",
 		},
+	}, {
+		name: "UsesLongestMirror",
+		in:   "gcr.io/knative-releases/github.com/knative/serving/cmd/queue:v1.2.0",
+		registry: base.Registry{
+			Mirrors: []base.RegistryMirror{
+				{Prefix: "gcr.io", Mirror: "mirror.example.com/gcr"},
+				{Prefix: "gcr.io/knative-releases/", Mirror: "mirror.example.com/knative/"},
+			},
+		},
+		expected: caching.ImageSpec{
+			Image: "mirror.example.com/knative/github.com/knative/serving/cmd/queue:v1.2.0",
+		},
+	}, {
+		name: "MirrorsMatchWholeSegments",
+		in:   "gcr.io/knative-releases/github.com/knative/serving/cmd/queue:v1.2.0",
+		registry: base.Registry{
+			Mirrors: []base.RegistryMirror{{Prefix: "gcr.io/knative", Mirror: "mirror.example.com/knative"}},
+		},
+		expected: caching.ImageSpec{
+			Image: "gcr.io/knative-releases/github.com/knative/serving/cmd/queue:v1.2.0",
+		},
+	}, {
+		name: "MirrorsOverriddenImage",
+		in:   "gcr.io/knative-releases/github.com/knative/serving/cmd/queue:v1.2.0",
+		registry: base.Registry{
+			Default: "docker.io/knative/${NAME}:new-tag",
+			Mirrors: []base.RegistryMirror{{Prefix: "docker.io", Mirror: "mirror.example.com/docker"}},
+		},
+		expected: caching.ImageSpec{
+			Image: "mirror.example.com/docker/knative/queue:new-tag",
+		},
 	}, {
 		name: "AddsImagePullSecrets",
 		in:   "gcr.io/knative-releases/github.com/knative/serving/cmd/queue@sha256:1e40c99ff5977daa2d69873fff604c6d09651af1f9ff15aadf8849b3ee77ab45",
@@ -22,6 +22,8 @@ import (
 	"strings"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -44,14 +46,17 @@ func Install(ctx context.Context, manifest *mf.Manifest, instance base.KComponen
 	logger := logging.FromContext(ctx)
 	logger.Debug("Installing manifest")
 	status := instance.GetStatus()
+	if version := TargetVersion(instance); version != status.GetVersion() {
+		RecordEvent(ctx, instance, corev1.EventTypeNormal, ReasonInstallStarted, "Installing version %s", version)
+	}
 	// The Operator needs a higher level of permissions if it 'bind's non-existent roles.
 	// To avoid this, we strictly order the manifest application as (Cluster)Roles, then
 	// (Cluster)RoleBindings, then the rest of the manifest.
-	if err := manifest.Filter(role).Apply(); err != nil {
+	if err := apply(ctx, manifest.Filter(role), instance); err != nil {
 		status.MarkInstallFailed(err.Error())
 		return fmt.Errorf("failed to apply (cluster)roles: %w", err)
 	}
-	if err := manifest.Filter(rolebinding).Apply(); err != nil {
+	if err := apply(ctx, manifest.Filter(rolebinding), instance); err != nil {
 		status.MarkInstallFailed(err.Error())
 		return fmt.Errorf("failed to apply (cluster)rolebindings: %w", err)
 	}
@@ -63,7 +68,7 @@ func Install(ctx context.Context, manifest *mf.Manifest, instance base.KComponen
 	if err := InstallWebhookConfigs(ctx, manifest, instance); err != nil {
 		return err
 	}
-	if err := manifest.Filter(mf.Not(mf.Any(role, rolebinding, webhook, webhookDependentResources))).Apply(); err != nil {
+	if err := apply(ctx, manifest.Filter(mf.Not(mf.Any(role, rolebinding, webhook, webhookDependentResources))), instance); err != nil {
 		status.MarkInstallFailed(err.Error())
 		if ks, ok := instance.(*v1beta1.KnativeServing); ok && strings.Contains(err.Error(), gatewayNotMatch) &&
 			(ks.Spec.Ingress == nil || ks.Spec.Ingress.Istio.Enabled) {
@@ -81,7 +86,7 @@ func Install(ctx context.Context, manifest *mf.Manifest, instance base.KComponen
 func InstallWebhookConfigs(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	logging.FromContext(ctx).Debug("Installing webhook configurations")
 	status := instance.GetStatus()
-	if err := manifest.Filter(webhook).Apply(); err != nil {
+	if err := apply(ctx, manifest.Filter(webhook), instance); err != nil {
 		status.MarkInstallFailed(err.Error())
 		return fmt.Errorf("failed to apply webhooks: %w", err)
 	}
@@ -92,7 +97,7 @@ func InstallWebhookConfigs(ctx context.Context, manifest *mf.Manifest, instance
 func InstallWebhookDependentResources(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	logging.FromContext(ctx).Debug("Installing webhook dependent resources")
 	status := instance.GetStatus()
-	if err := manifest.Filter(webhookDependentResources).Apply(); err != nil {
+	if err := apply(ctx, manifest.Filter(webhookDependentResources), instance); err != nil {
 		status.MarkInstallFailed(err.Error())
 		return fmt.Errorf("failed to apply webhooks: %w", err)
 	}
@@ -103,7 +108,15 @@ func InstallWebhookDependentResources(ctx context.Context, manifest *mf.Manifest
 func MarkStatusSuccess(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	status := instance.GetStatus()
 	status.MarkInstallSucceeded()
-	status.SetVersion(TargetVersion(instance))
+	version := TargetVersion(instance)
+	if previous := status.GetVersion(); previous != "" && previous != version {
+		RecordEvent(ctx, instance, corev1.EventTypeNormal, ReasonVersionUpgraded, "Upgraded from version %s to %s", previous, version)
+	}
+	status.SetVersion(version)
+	if upgrade := status.GetUpgrade(); upgrade != nil && len(upgrade.Path) != 0 && upgrade.Path[len(upgrade.Path)-1] == version {
+		// The last step of the upgrade has been installed.
+		status.SetUpgrade(nil)
+	}
 	return nil
 }
 
@@ -124,3 +137,20 @@ func byGV(gk schema.GroupKind) mf.Predicate {
 		return u.GroupVersionKind().GroupKind() == gk
 	}
 }
+
+// CheckCRDs returns an error naming the first of the CRDs missing from the cluster of the manifest.
+func CheckCRDs(manifest *mf.Manifest, names ...string) error {
+	for _, name := range names {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName(name)
+		if _, err := manifest.Client.Get(crd); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("the CustomResourceDefinition %s is not installed", name)
+			}
+			return err
+		}
+	}
+	return nil
+}
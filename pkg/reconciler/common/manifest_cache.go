@@ -0,0 +1,385 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"go.opentelemetry.io/otel/metric"
+
+	"knative.dev/operator/pkg/oci"
+)
+
+const (
+	// CacheMemory labels the lookups of parsed manifests in memory.
+	CacheMemory = "memory"
+	// CacheHTTP labels the downloads of remote manifests, which are hits when the server
+	// confirms the cached copy with 304 Not Modified.
+	CacheHTTP = "http"
+
+	// maxDocumentSize bounds the size of the remote manifests, as the blobs of OCI artifacts are.
+	maxDocumentSize = 64 * 1024 * 1024
+)
+
+var (
+	cacheOnce sync.Once
+	cache     *manifestCache
+
+	documentsOnce sync.Once
+	documents     *documentStore
+)
+
+// sharedManifestCache returns the cache of the parsed manifests, configured by the flags.
+func sharedManifestCache() *manifestCache {
+	cacheOnce.Do(func() {
+		cache = newManifestCache(manifestCacheSizeValue(), manifestCacheTTLFlag)
+	})
+	return cache
+}
+
+// remoteDocuments returns the store of the remote manifests, configured by the flags.
+func remoteDocuments() *documentStore {
+	documentsOnce.Do(func() {
+		documents = newDocumentStore(&http.Client{Timeout: time.Minute}, manifestCacheSizeValue(), manifestCacheDirFlag)
+	})
+	return documents
+}
+
+// lru is a map bounded in size, which evicts its least recently used entries. It is not safe for
+// concurrent use.
+type lru[V any] struct {
+	max   int
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruItem[V any] struct {
+	key   string
+	value V
+}
+
+func newLRU[V any](max int) *lru[V] {
+	return &lru[V]{max: max, items: map[string]*list.Element{}, order: list.New()}
+}
+
+func (l *lru[V]) get(key string) (V, bool) {
+	if e, ok := l.items[key]; ok {
+		l.order.MoveToFront(e)
+		return e.Value.(*lruItem[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// add adds or replaces the entry, evicting the least recently used ones beyond the maximum size.
+func (l *lru[V]) add(key string, value V) {
+	if e, ok := l.items[key]; ok {
+		e.Value.(*lruItem[V]).value = value
+		l.order.MoveToFront(e)
+		return
+	}
+	l.items[key] = l.order.PushFront(&lruItem[V]{key: key, value: value})
+	for l.order.Len() > l.max {
+		item := l.order.Remove(l.order.Back()).(*lruItem[V])
+		delete(l.items, item.key)
+	}
+}
+
+func (l *lru[V]) remove(key string) {
+	if e, ok := l.items[key]; ok {
+		l.order.Remove(e)
+		delete(l.items, key)
+	}
+}
+
+func (l *lru[V]) len() int {
+	return l.order.Len()
+}
+
+// manifestCache keeps the manifests read from comma-separated paths. The manifests with remote
+// URLs expire after the TTL, and are then downloaded again with conditional requests. Local
+// manifests, which are part of the operator image, do not expire.
+type manifestCache struct {
+	mu      sync.Mutex
+	entries *lru[cachedManifest]
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+type cachedManifest struct {
+	manifest mf.Manifest
+	// expires is zero for manifests, which do not expire.
+	expires time.Time
+}
+
+func newManifestCache(size int, ttl time.Duration) *manifestCache {
+	return &manifestCache{entries: newLRU[cachedManifest](size), ttl: ttl, now: time.Now}
+}
+
+func (c *manifestCache) get(path string) (mf.Manifest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries.get(path)
+	if ok && !entry.expires.IsZero() && c.now().After(entry.expires) {
+		c.entries.remove(path)
+		ok = false
+	}
+	recordCacheLookup(CacheMemory, ok)
+	return entry.manifest, ok
+}
+
+func (c *manifestCache) add(path string, manifest mf.Manifest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := cachedManifest{manifest: manifest}
+	if hasRemoteURL(path) {
+		entry.expires = c.now().Add(c.ttl)
+	}
+	c.entries.add(path, entry)
+}
+
+func (c *manifestCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = newLRU[cachedManifest](c.entries.max)
+}
+
+func (c *manifestCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.len()
+}
+
+// documentStore downloads remote manifests, and keeps their content with their ETag and
+// Last-Modified headers, so that they are downloaded again only if they have changed. If dir is
+// set, the documents are persisted there, keyed by their URL and ETag, and survive restarts.
+type documentStore struct {
+	client *http.Client
+	dir    string
+	// limit bounds the size of the downloaded documents.
+	limit int64
+
+	mu   sync.Mutex
+	docs *lru[*document]
+}
+
+// document is a downloaded manifest. Its content is kept in memory, unless it is persisted.
+type document struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	File         string `json:"file,omitempty"`
+
+	data []byte
+}
+
+func newDocumentStore(client *http.Client, size int, dir string) *documentStore {
+	return &documentStore{client: client, dir: dir, limit: maxDocumentSize, docs: newLRU[*document](size)}
+}
+
+// fetch returns the content of the URL, from the store if the server confirms it has not changed.
+func (s *documentStore) fetch(ctx context.Context, url string) ([]byte, error) {
+	cached := s.lookup(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if data, err := s.content(cached); err == nil {
+			recordCacheLookup(CacheHTTP, true)
+			return data, nil
+		}
+		// The persisted content is gone, download it again.
+		s.forget(url)
+		return s.fetch(ctx, url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got HTTP %d from %s", resp.StatusCode, url)
+	}
+	// One more byte is read to tell a body of the maximum size from a larger one.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.limit {
+		return nil, fmt.Errorf("the response from %s exceeds %d bytes", url, s.limit)
+	}
+	recordCacheLookup(CacheHTTP, false)
+
+	doc := &document{URL: url, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	if doc.ETag == "" && doc.LastModified == "" {
+		// The content cannot be validated, so there is no point in keeping it.
+		s.forget(url)
+		return data, nil
+	}
+	s.store(doc, data)
+	return data, nil
+}
+
+// lookup returns the document of the URL in memory, or persisted from a previous run.
+func (s *documentStore) lookup(url string) *document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doc, ok := s.docs.get(url); ok {
+		return doc
+	}
+	if s.dir == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(s.indexPath(url))
+	if err != nil {
+		return nil
+	}
+	doc := &document{}
+	if err := json.Unmarshal(raw, doc); err != nil || doc.URL != url {
+		return nil
+	}
+	s.docs.add(url, doc)
+	return doc
+}
+
+func (s *documentStore) content(doc *document) ([]byte, error) {
+	if doc.File == "" {
+		return doc.data, nil
+	}
+	return os.ReadFile(filepath.Join(s.dir, doc.File))
+}
+
+func (s *documentStore) store(doc *document, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, _ := s.docs.get(doc.URL)
+	if s.dir == "" {
+		doc.data = data
+		s.docs.add(doc.URL, doc)
+		return
+	}
+
+	// Content files are keyed by the URL and the validators, so an update never overwrites the
+	// content of another version.
+	doc.File = hashKey(doc.URL, doc.ETag, doc.LastModified) + ".yaml"
+	index, err := json.Marshal(doc)
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(s.dir, doc.File), data)
+	}
+	if err == nil {
+		err = writeFileAtomic(s.indexPath(doc.URL), index)
+	}
+	if err != nil {
+		// Keep the content in memory, if it cannot be persisted.
+		doc.File, doc.data = "", data
+	}
+	if previous != nil && previous.File != "" && previous.File != doc.File {
+		os.Remove(filepath.Join(s.dir, previous.File))
+	}
+	// Evicted documents remain on disk, and are found again by lookup.
+	s.docs.add(doc.URL, doc)
+}
+
+func (s *documentStore) forget(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, ok := s.docs.get(url)
+	s.docs.remove(url)
+	if s.dir == "" {
+		return
+	}
+	os.Remove(s.indexPath(url))
+	if ok && doc.File != "" {
+		os.Remove(filepath.Join(s.dir, doc.File))
+	}
+}
+
+func (s *documentStore) indexPath(url string) string {
+	return filepath.Join(s.dir, hashKey(url)+".json")
+}
+
+func hashKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, bytes.NewReader(data)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// isRemoteURL returns true if the path is an http(s) URL.
+func isRemoteURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// hasRemoteURL returns true if the comma-separated path contains a remote URL, including OCI
+// references by tag.
+func hasRemoteURL(path string) bool {
+	for _, p := range strings.Split(path, COMMA) {
+		if isRemoteURL(p) || (oci.IsReference(p) && !strings.Contains(p, "@sha256:")) {
+			return true
+		}
+	}
+	return false
+}
+
+func recordCacheLookup(layer string, hit bool) {
+	counter := manifestCacheMisses
+	if hit {
+		counter = manifestCacheHits
+	}
+	counter.Add(context.Background(), 1, metric.WithAttributes(CacheAttr.With(layer)))
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"flag"
+	"time"
+)
+
+const (
+	defaultManifestCacheSize = 64
+	defaultManifestCacheTTL  = time.Hour
+)
+
+var (
+	manifestCacheSizeFlag int
+	manifestCacheTTLFlag  time.Duration
+	manifestCacheDirFlag  string
+)
+
+func init() {
+	flag.IntVar(&manifestCacheSizeFlag, "manifest-cache-size", defaultManifestCacheSize,
+		"Maximum number of manifests kept in memory. The least recently used ones are evicted first.")
+	flag.DurationVar(&manifestCacheTTLFlag, "manifest-cache-ttl", defaultManifestCacheTTL,
+		"Duration after which the cached manifests with remote URLs are revalidated with conditional requests.")
+	flag.StringVar(&manifestCacheDirFlag, "manifest-cache-dir", "",
+		"Directory where the remote manifests are persisted, keyed by their URL and ETag, so that they "+
+			"survive restarts. They are only kept in memory if empty.")
+}
+
+// manifestCacheSizeValue returns the configured cache size, falling back to the default for values below 1.
+func manifestCacheSizeValue() int {
+	if manifestCacheSizeFlag < 1 {
+		return defaultManifestCacheSize
+	}
+	return manifestCacheSizeFlag
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestLRU(t *testing.T) {
+	l := newLRU[int](2)
+	l.add("a", 1)
+	l.add("b", 2)
+	if _, ok := l.get("a"); !ok {
+		t.Fatal("a was evicted")
+	}
+	// b is the least recently used entry now.
+	l.add("c", 3)
+	_, ok := l.get("b")
+	util.AssertEqual(t, ok, false)
+	v, ok := l.get("a")
+	util.AssertEqual(t, ok, true)
+	util.AssertEqual(t, v, 1)
+	l.add("a", 4)
+	v, _ = l.get("a")
+	util.AssertEqual(t, v, 4)
+	util.AssertEqual(t, l.len(), 2)
+}
+
+func TestManifestCacheTTL(t *testing.T) {
+	reader := setupMetrics()
+	now := time.Now()
+	c := newManifestCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	local := "testdata/kodata/knative-serving/0.26.1"
+	remote := "https://example.com/serving-core.yaml"
+	pinnedOCI := "oci://example.com/serving@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	manifest, err := mf.NewManifest(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{local, local + COMMA + remote, pinnedOCI} {
+		c.add(path, manifest)
+	}
+
+	now = now.Add(2 * time.Minute)
+	_, ok := c.get(local)
+	util.AssertEqual(t, ok, true)
+	_, ok = c.get(pinnedOCI)
+	util.AssertEqual(t, ok, true)
+	// The manifest with a remote URL has expired.
+	_, ok = c.get(local + COMMA + remote)
+	util.AssertEqual(t, ok, false)
+	util.AssertEqual(t, c.len(), 2)
+
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.manifest_cache.hits", CacheAttr.With(CacheMemory))), 1)
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.manifest_cache.misses", CacheAttr.With(CacheMemory))), 1)
+}
+
+// manifestServer serves a manifest with an ETag, and counts the requests by their status.
+type manifestServer struct {
+	mu       sync.Mutex
+	etag     string
+	body     string
+	statuses []int
+}
+
+func (s *manifestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+		s.statuses = append(s.statuses, http.StatusNotModified)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.statuses = append(s.statuses, http.StatusOK)
+	w.Write([]byte(s.body))
+}
+
+func (s *manifestServer) update(etag, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.etag, s.body = etag, body
+}
+
+func (s *manifestServer) requests() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int{}, s.statuses...)
+}
+
+func TestDocumentStore(t *testing.T) {
+	server := &manifestServer{}
+	server.update(`"v1"`, "kind: ConfigMap\n")
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	url := ts.URL + "/serving-core.yaml"
+
+	dir := t.TempDir()
+	store := newDocumentStore(ts.Client(), 10, dir)
+	for i := 0; i < 2; i++ {
+		data, err := store.fetch(context.TODO(), url)
+		if err != nil {
+			t.Fatalf("fetch() = %v", err)
+		}
+		util.AssertEqual(t, string(data), "kind: ConfigMap\n")
+	}
+	util.AssertDeepEqual(t, server.requests(), []int{http.StatusOK, http.StatusNotModified})
+
+	// A new store, as after a restart, revalidates the persisted document.
+	restarted := newDocumentStore(ts.Client(), 10, dir)
+	data, err := restarted.fetch(context.TODO(), url)
+	if err != nil {
+		t.Fatalf("fetch() = %v", err)
+	}
+	util.AssertEqual(t, string(data), "kind: ConfigMap\n")
+	util.AssertDeepEqual(t, server.requests(), []int{http.StatusOK, http.StatusNotModified, http.StatusNotModified})
+
+	// A changed document replaces the persisted one.
+	server.update(`"v2"`, "kind: Secret\n")
+	data, err = restarted.fetch(context.TODO(), url)
+	if err != nil {
+		t.Fatalf("fetch() = %v", err)
+	}
+	util.AssertEqual(t, string(data), "kind: Secret\n")
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(files), 1)
+
+	// The persisted content is downloaded again, if it is gone.
+	os.Remove(files[0])
+	data, err = restarted.fetch(context.TODO(), url)
+	if err != nil {
+		t.Fatalf("fetch() = %v", err)
+	}
+	util.AssertEqual(t, string(data), "kind: Secret\n")
+}
+
+func TestDocumentStoreWithoutValidators(t *testing.T) {
+	server := &manifestServer{}
+	server.update("", "kind: ConfigMap\n")
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	store := newDocumentStore(ts.Client(), 10, "")
+	for i := 0; i < 2; i++ {
+		if _, err := store.fetch(context.TODO(), ts.URL); err != nil {
+			t.Fatalf("fetch() = %v", err)
+		}
+	}
+	util.AssertDeepEqual(t, server.requests(), []int{http.StatusOK, http.StatusOK})
+	util.AssertEqual(t, store.docs.len(), 0)
+}
+
+func TestDocumentStoreBounds(t *testing.T) {
+	server := &manifestServer{}
+	server.update(`"v1"`, "kind: ConfigMap\n")
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	store := newDocumentStore(ts.Client(), 10, "")
+	store.limit = 8
+	_, err := store.fetch(context.TODO(), ts.URL)
+	util.AssertEqual(t, err.Error(), "the response from "+ts.URL+" exceeds 8 bytes")
+	util.AssertEqual(t, store.docs.len(), 0)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	store.limit = maxDocumentSize
+	if _, err := store.fetch(ctx, ts.URL); !errors.Is(err, context.Canceled) {
+		t.Fatalf("fetch() = %v, want %v", err, context.Canceled)
+	}
+}
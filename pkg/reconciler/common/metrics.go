@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"knative.dev/pkg/observability/attributekey"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+const scopeName = "knative.dev/operator/pkg/reconciler/common"
+
+var (
+	// ComponentKindAttr is the kind of the Knative component, e.g. KnativeServing.
+	ComponentKindAttr = attributekey.String("kn.operator.component.kind")
+	// ComponentNamespaceAttr is the namespace of the Knative component.
+	ComponentNamespaceAttr = attributekey.String("kn.operator.component.namespace")
+	// ComponentNameAttr is the name of the Knative component.
+	ComponentNameAttr = attributekey.String("kn.operator.component.name")
+
+	// ResourceKindAttr is the kind of a resource of the manifest.
+	ResourceKindAttr = attributekey.String("kn.operator.resource.kind")
+	// ResourceNamespaceAttr is the namespace of a resource of the manifest, empty if it is cluster-scoped.
+	ResourceNamespaceAttr = attributekey.String("kn.operator.resource.namespace")
+	// ResourceNameAttr is the name of a resource of the manifest.
+	ResourceNameAttr = attributekey.String("kn.operator.resource.name")
+
+	// CacheAttr is the layer of the manifest cache, either memory or http.
+	CacheAttr = attributekey.String("kn.operator.cache")
+
+	// StageAttr is the name of a reconcile stage, e.g. common.Install.
+	StageAttr = attributekey.String("kn.operator.stage")
+)
+
+var (
+	stageDuration       metric.Float64Histogram
+	stageErrors         metric.Int64Counter
+	resourcesApplied    metric.Int64Counter
+	resourcesDeleted    metric.Int64Counter
+	deploymentsNotReady metric.Int64Gauge
+	driftedResources    metric.Int64Gauge
+	resourceDrifted     metric.Int64Gauge
+	manifestCacheHits   metric.Int64Counter
+	manifestCacheMisses metric.Int64Counter
+)
+
+func init() {
+	meter := otel.GetMeterProvider().Meter(scopeName)
+
+	var err error
+	stageDuration, err = meter.Float64Histogram(
+		"kn.operator.stage.duration",
+		metric.WithDescription("The duration of a reconcile stage."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	stageErrors, err = meter.Int64Counter(
+		"kn.operator.stage.errors",
+		metric.WithDescription("The number of reconcile stages, which failed."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resourcesApplied, err = meter.Int64Counter(
+		"kn.operator.resources.applied",
+		metric.WithDescription("The number of resources applied to the cluster."),
+		metric.WithUnit("{resource}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resourcesDeleted, err = meter.Int64Counter(
+		"kn.operator.resources.deleted",
+		metric.WithDescription("The number of obsolete resources deleted from the cluster."),
+		metric.WithUnit("{resource}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	deploymentsNotReady, err = meter.Int64Gauge(
+		"kn.operator.deployments.not_ready",
+		metric.WithDescription("The number of deployments of a component, which are not available."),
+		metric.WithUnit("{deployment}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	driftedResources, err = meter.Int64Gauge(
+		"kn.operator.resources.drifted",
+		metric.WithDescription("The number of resources of a component, whose live objects differ from the configuration last applied."),
+		metric.WithUnit("{resource}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resourceDrifted, err = meter.Int64Gauge(
+		"kn.operator.resource.drifted",
+		metric.WithDescription("1 if the live object of a resource differs from the configuration last applied, 0 once it is back in sync."),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	manifestCacheHits, err = meter.Int64Counter(
+		"kn.operator.manifest_cache.hits",
+		metric.WithDescription("The number of manifests served from the cache."),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	manifestCacheMisses, err = meter.Int64Counter(
+		"kn.operator.manifest_cache.misses",
+		metric.WithDescription("The number of manifests, which were read or downloaded, as they were not in the cache."),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// funcSuffix matches the suffixes the compiler gives to closures and method values.
+var funcSuffix = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+// stageName returns the name of the function implementing the stage, qualified by its package,
+// e.g. common.Install. Stages built by a function are named after it.
+func stageName(stage Stage) string {
+	name := runtime.FuncForPC(reflect.ValueOf(stage).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return funcSuffix.ReplaceAllString(name, "")
+}
+
+// recordStage records the duration and the failure of a stage.
+func recordStage(ctx context.Context, instance base.KComponent, name string, duration time.Duration, failed bool) {
+	attrs := metric.WithAttributes(
+		ComponentKindAttr.With(instance.GroupVersionKind().Kind),
+		StageAttr.With(name),
+	)
+	stageDuration.Record(ctx, duration.Seconds(), attrs)
+	if failed {
+		stageErrors.Add(ctx, 1, attrs)
+	}
+}
+
+// recordResources adds the resources of the manifest to the counter, by kind.
+func recordResources(ctx context.Context, counter metric.Int64Counter, instance base.KComponent, manifest mf.Manifest) {
+	counts := map[string]int64{}
+	for _, u := range manifest.Resources() {
+		counts[u.GetKind()]++
+	}
+	for kind, count := range counts {
+		counter.Add(ctx, count, metric.WithAttributes(
+			ComponentKindAttr.With(instance.GroupVersionKind().Kind),
+			ResourceKindAttr.With(kind),
+		))
+	}
+}
+
+// RecordDeploymentsNotReady records the number of deployments of the component, which are not available.
+func RecordDeploymentsNotReady(ctx context.Context, instance base.KComponent, count int) {
+	deploymentsNotReady.Record(ctx, int64(count), componentAttrs(instance))
+}
+
+func componentAttrs(instance base.KComponent) metric.MeasurementOption {
+	return metric.WithAttributes(
+		ComponentKindAttr.With(instance.GroupVersionKind().Kind),
+		ComponentNamespaceAttr.With(instance.GetNamespace()),
+		ComponentNameAttr.With(instance.GetName()),
+	)
+}
+
+var (
+	driftedMu sync.Mutex
+	// driftedSeries are the attributes of the resources last recorded drifted, keyed by component
+	// and by resource, so that they are reset once the resources are back in sync.
+	driftedSeries = map[string]map[string][]attribute.KeyValue{}
+)
+
+// RecordDriftedResources records the number of resources of the component, which have drifted, and
+// a series for each of them, labeled with its kind, namespace and name. The series of the resources,
+// which were drifted and are back in sync, are set to 0.
+func RecordDriftedResources(ctx context.Context, instance base.KComponent, resources []DriftedResource) {
+	driftedResources.Record(ctx, int64(len(resources)), componentAttrs(instance))
+
+	component := instance.GroupVersionKind().Kind + "/" + instance.GetNamespace() + "/" + instance.GetName()
+	current := make(map[string][]attribute.KeyValue, len(resources))
+	for _, d := range resources {
+		current[d.String()] = []attribute.KeyValue{
+			ComponentKindAttr.With(instance.GroupVersionKind().Kind),
+			ComponentNamespaceAttr.With(instance.GetNamespace()),
+			ComponentNameAttr.With(instance.GetName()),
+			ResourceKindAttr.With(d.Resource.GetKind()),
+			ResourceNamespaceAttr.With(d.Resource.GetNamespace()),
+			ResourceNameAttr.With(d.Resource.GetName()),
+		}
+	}
+
+	driftedMu.Lock()
+	defer driftedMu.Unlock()
+	for key, attrs := range driftedSeries[component] {
+		if _, ok := current[key]; !ok {
+			resourceDrifted.Record(ctx, 0, metric.WithAttributes(attrs...))
+		}
+	}
+	for _, attrs := range current {
+		resourceDrifted.Record(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	if len(current) == 0 {
+		delete(driftedSeries, component)
+	} else {
+		driftedSeries[component] = current
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+var (
+	metricsOnce   sync.Once
+	metricsReader *sdkmetric.ManualReader
+)
+
+// setupMetrics directs the instruments of the package, which delegate to the global meter provider,
+// to a reader shared by the tests.
+func setupMetrics() *sdkmetric.ManualReader {
+	metricsOnce.Do(func() {
+		metricsReader = sdkmetric.NewManualReader()
+		otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricsReader)))
+	})
+	return metricsReader
+}
+
+// collect returns the data points of the metric with the given name, whose attributes contain attrs.
+func collect(t *testing.T, reader *sdkmetric.ManualReader, name string, attrs ...attribute.KeyValue) []attribute.Set {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	var sets []attribute.Set
+	matches := func(set attribute.Set) bool {
+		for _, kv := range attrs {
+			if v, ok := set.Value(kv.Key); !ok || v != kv.Value {
+				return false
+			}
+		}
+		return true
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					if matches(dp.Attributes) {
+						sets = append(sets, dp.Attributes)
+					}
+				}
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					if matches(dp.Attributes) {
+						sets = append(sets, dp.Attributes)
+					}
+				}
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					if matches(dp.Attributes) {
+						sets = append(sets, dp.Attributes)
+					}
+				}
+			}
+		}
+	}
+	return sets
+}
+
+func TestStageName(t *testing.T) {
+	util.AssertEqual(t, stageName(Install), "common.Install")
+	util.AssertEqual(t, stageName(DetectDrift(&ReconcileState{})), "common.DetectDrift")
+	util.AssertEqual(t, stageName(func(context.Context, *mf.Manifest, base.KComponent) error { return nil }),
+		"common.TestStageName")
+}
+
+func TestExecuteRecordsMetrics(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+	reader := setupMetrics()
+	configMap := configMap("config-metrics", map[string]interface{}{"a": "b"})
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*configMap}), mf.UseClient(fake.New()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ke := &v1beta1.KnativeEventing{}
+	ke.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("KnativeEventing"))
+	ke.Status.InitializeConditions()
+
+	failed := errors.New("failed")
+	stages := Stages{
+		Install,
+		CheckDeployments,
+		func(context.Context, *mf.Manifest, base.KComponent) error { return failed },
+	}
+	if _, err := stages.Execute(context.Background(), &manifest, ke); !errors.Is(err, failed) {
+		t.Fatalf("Execute() = %v, want %v", err, failed)
+	}
+
+	kind := ComponentKindAttr.With("KnativeEventing")
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.stage.duration", kind, StageAttr.With("common.Install"))), 1)
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.stage.duration", kind, StageAttr.With("common.CheckDeployments"))), 1)
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.stage.errors", kind, StageAttr.With("common.Install"))), 0)
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.stage.errors", kind, StageAttr.With("common.TestExecuteRecordsMetrics"))), 1)
+	util.AssertEqual(t, len(collect(t, reader, "kn.operator.resources.applied", kind, ResourceKindAttr.With("ConfigMap"))), 1)
+}
+
+// gaugeValue returns the last value of the gauge with the given name, whose attributes contain attrs.
+func gaugeValue(t *testing.T, reader *sdkmetric.ManualReader, name string, attrs ...attribute.KeyValue) int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			if m.Name != name || !ok {
+				continue
+			}
+		points:
+			for _, dp := range gauge.DataPoints {
+				for _, kv := range attrs {
+					if v, ok := dp.Attributes.Value(kv.Key); !ok || v != kv.Value {
+						continue points
+					}
+				}
+				return dp.Value
+			}
+		}
+	}
+	t.Fatalf("no data point of %s with %v", name, attrs)
+	return 0
+}
+
+func TestRecordDriftedResources(t *testing.T) {
+	reader := setupMetrics()
+	ks := &v1beta1.KnativeServing{}
+	ks.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("KnativeServing"))
+	ks.SetNamespace("knative-serving")
+	ks.SetName("drift-metrics")
+	component := ComponentNameAttr.With("drift-metrics")
+	resource := func(kind, name string) DriftedResource {
+		u := &unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetNamespace("knative-serving")
+		u.SetName(name)
+		return DriftedResource{Resource: u}
+	}
+	value := func(kind, name string) int64 {
+		return gaugeValue(t, reader, "kn.operator.resource.drifted", component,
+			ResourceKindAttr.With(kind), ResourceNamespaceAttr.With("knative-serving"), ResourceNameAttr.With(name))
+	}
+
+	RecordDriftedResources(context.Background(), ks, []DriftedResource{
+		resource("Deployment", "activator"),
+		resource("ConfigMap", "config-network"),
+	})
+	util.AssertEqual(t, gaugeValue(t, reader, "kn.operator.resources.drifted", component), int64(2))
+	util.AssertEqual(t, value("Deployment", "activator"), int64(1))
+	util.AssertEqual(t, value("ConfigMap", "config-network"), int64(1))
+
+	// The resources back in sync are reset.
+	RecordDriftedResources(context.Background(), ks, []DriftedResource{resource("Deployment", "activator")})
+	util.AssertEqual(t, gaugeValue(t, reader, "kn.operator.resources.drifted", component), int64(1))
+	util.AssertEqual(t, value("Deployment", "activator"), int64(1))
+	util.AssertEqual(t, value("ConfigMap", "config-network"), int64(0))
+
+	RecordDriftedResources(context.Background(), ks, nil)
+	util.AssertEqual(t, gaugeValue(t, reader, "kn.operator.resources.drifted", component), int64(0))
+	util.AssertEqual(t, value("Deployment", "activator"), int64(0))
+}
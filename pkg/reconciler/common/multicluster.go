@@ -28,6 +28,7 @@ import (
 	"time"
 
 	mfc "github.com/manifestival/client-go-client"
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
 	mf "github.com/manifestival/manifestival"
 	"golang.org/x/sync/singleflight"
 	corev1 "k8s.io/api/core/v1"
@@ -84,6 +85,8 @@ type RemoteClusterClients interface {
 	MfClient() mf.Client
 	KubeClient() kubernetes.Interface
 	RestConfig() *rest.Config
+	// ResourceGetter returns the dynamic client of the cluster, built once on first use.
+	ResourceGetter() (mfdynamic.ResourceGetter, error)
 }
 
 type clusterEntry struct {
@@ -93,6 +96,10 @@ type clusterEntry struct {
 	cancel     context.CancelFunc
 	ctx        context.Context
 	closeOnce  sync.Once
+
+	getterOnce sync.Once
+	getter     mfdynamic.ResourceGetter
+	getterErr  error
 }
 
 func (e *clusterEntry) MfClient() mf.Client              { return e.mfClient }
@@ -100,6 +107,13 @@ func (e *clusterEntry) KubeClient() kubernetes.Interface { return e.kubeClient }
 func (e *clusterEntry) RestConfig() *rest.Config         { return e.restConfig }
 func (e *clusterEntry) IsAlive() bool                    { return e.ctx.Err() == nil }
 
+func (e *clusterEntry) ResourceGetter() (mfdynamic.ResourceGetter, error) {
+	e.getterOnce.Do(func() {
+		e.getter, e.getterErr = mfdynamic.NewForConfig(e.restConfig)
+	})
+	return e.getter, e.getterErr
+}
+
 func (e *clusterEntry) Close() {
 	e.closeOnce.Do(func() {
 		e.cancel()
@@ -668,6 +682,10 @@ func ResolveTargetCluster(provider *ClusterProvider, state *ReconcileState) Stag
 			instance.GetStatus().MarkTargetClusterNotResolved(reason, err.Error())
 			return fmt.Errorf("failed to resolve target cluster: %w", err)
 		}
+		if !isConditionTrue(instance.GetStatus(), base.TargetClusterResolved) {
+			RecordEvent(ctx, instance, corev1.EventTypeNormal, ReasonRemoteClusterResolved,
+				"Resolved the target cluster of ClusterProfile %s/%s", cpRef.Namespace, cpRef.Name)
+		}
 		instance.GetStatus().MarkTargetClusterResolved()
 
 		manifest.Client = entry.MfClient()
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/oci"
+)
+
+const (
+	// ociPullTimeout bounds the time spent pulling an OCI artifact.
+	ociPullTimeout = time.Minute
+	// maxPinnedArtifacts bounds the number of artifacts, whose digests are remembered to pin their URLs.
+	maxPinnedArtifacts = 256
+)
+
+var (
+	ociClient = oci.NewClient()
+
+	// pinnedMu guards pinned.
+	pinnedMu sync.Mutex
+	// pinned maps the oci:// URLs referencing artifacts by tag to the URLs referencing the pulled
+	// artifacts by digest. The least recently pulled artifacts are evicted.
+	pinned = newLRU[string](maxPinnedArtifacts)
+)
+
+// pullManifest pulls the OCI artifact referenced by the URL, and reads its YAML and JSON files in the
+// order of its layers. The digest of the artifact is recorded, so that the URL can be pinned to it.
+func pullManifest(ctx context.Context, url string) (mf.Manifest, error) {
+	ctx, cancel := context.WithTimeout(ctx, ociPullTimeout)
+	defer cancel()
+	artifact, err := ociClient.Pull(ctx, url)
+	if err != nil {
+		return mf.Manifest{}, err
+	}
+	var buf bytes.Buffer
+	for _, f := range artifact.Files {
+		switch strings.ToLower(filepath.Ext(f.Name)) {
+		case ".yaml", ".yml", ".json":
+			buf.Write(f.Data)
+			buf.WriteString("\n---\n")
+		}
+	}
+	manifest, err := mf.ManifestFrom(mf.Reader(&buf))
+	if err != nil {
+		return mf.Manifest{}, fmt.Errorf("failed to read the manifests of %s: %w", url, err)
+	}
+
+	ref, err := oci.ParseReference(url)
+	if err != nil {
+		return mf.Manifest{}, err
+	}
+	pin(url, oci.Pin(ref, artifact.Digest))
+	return manifest, nil
+}
+
+// pin records the URL referencing the artifact of the URL by digest.
+func pin(url, pinnedURL string) {
+	pinnedMu.Lock()
+	defer pinnedMu.Unlock()
+	pinned.add(url, pinnedURL)
+}
+
+// PinManifestPaths returns the manifest paths, with the oci:// URLs replaced by the URLs referencing
+// the pulled artifacts by digest, so that the installed manifests remain the same when tags are moved.
+func PinManifestPaths(paths []string) []string {
+	pinnedMu.Lock()
+	defer pinnedMu.Unlock()
+	result := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !strings.Contains(path, oci.Scheme) {
+			result = append(result, path)
+			continue
+		}
+		parts := strings.Split(path, COMMA)
+		for i, p := range parts {
+			if pinnedURL, ok := pinned.get(p); ok {
+				parts[i] = pinnedURL
+			}
+		}
+		result = append(result, strings.Join(parts, COMMA))
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/oci"
+	ocitesting "knative.dev/operator/pkg/oci/testing"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestTargetManifestFromOCI(t *testing.T) {
+	ClearCache()
+	defer ClearCache()
+	coreData, err := os.ReadFile("testdata/kodata/knative-serving/0.26.0/serving-core.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	digest := registry.Push("knative/serving", "0.26.0",
+		oci.File{Name: "serving-core.yaml", Data: coreData},
+		oci.File{Name: "README.md", Data: []byte("# Not a manifest\n")})
+
+	url := oci.Scheme + registry.Host() + "/knative/serving:" + VersionVariable
+	hpaPath := "testdata/kodata/knative-serving/0.26.0/serving-hpa.yaml"
+	ks := &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version:   "0.26.0",
+				Manifests: []base.Manifest{{Url: url}, {Url: hpaPath}},
+			},
+		},
+	}
+	manifest, err := TargetManifest(context.Background(), ks)
+	if err != nil {
+		t.Fatalf("TargetManifest() = %v", err)
+	}
+	expected, err := mf.NewManifest("testdata/kodata/knative-serving/0.26.0/serving-core.yaml," + hpaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertDeepEqual(t, manifest.Resources(), expected.Resources())
+
+	// The status records the pulled artifact by digest, so that moving the tag does not change the
+	// installed manifest.
+	pinnedPath := oci.Scheme + registry.Host() + "/knative/serving@" + digest + COMMA + hpaPath
+	paths := PinManifestPaths(TargetManifestPathArray(ks))
+	util.AssertDeepEqual(t, paths, []string{pinnedPath})
+
+	registry.Push("knative/serving", "0.26.0", oci.File{Name: "serving-core.yaml", Data: []byte("kind: ConfigMap\n")})
+	ClearCache()
+	ks.Status.Version = "0.26.0"
+	ks.Status.SetManifests(paths)
+	installed, err := InstalledManifest(context.Background(), ks)
+	if err != nil {
+		t.Fatalf("InstalledManifest() = %v", err)
+	}
+	util.AssertDeepEqual(t, installed.Resources(), expected.Resources())
+}
+
+func TestPullManifestCanceled(t *testing.T) {
+	registry := ocitesting.NewRegistry()
+	defer registry.Close()
+	registry.Push("knative/serving", "0.26.0", oci.File{Name: "serving-core.yaml", Data: []byte("kind: ConfigMap\n")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pullManifest(ctx, oci.Scheme+registry.Host()+"/knative/serving:0.26.0"); err == nil {
+		t.Error("Expected the pull to stop with the context of the reconciliation")
+	}
+}
+
+func TestPinnedBounded(t *testing.T) {
+	for i := 0; i <= maxPinnedArtifacts; i++ {
+		pin(oci.Scheme+"example.com/knative/serving:"+strconv.Itoa(i), oci.Scheme+"example.com/knative/serving@sha256:"+strconv.Itoa(i))
+	}
+	pinnedMu.Lock()
+	defer pinnedMu.Unlock()
+	util.AssertEqual(t, pinned.len(), maxPinnedArtifacts)
+	_, ok := pinned.get(oci.Scheme + "example.com/knative/serving:0")
+	util.AssertEqual(t, ok, false)
+}
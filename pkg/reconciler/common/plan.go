@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+const (
+	// DryRunAnnotation is the annotation, which makes the reconciler record the changes it would apply
+	// to the cluster in the plan ConfigMap of the component, instead of applying them.
+	DryRunAnnotation = "operator.knative.dev/dry-run"
+	// FieldManager is the name of the field manager used by the operator for server-side apply.
+	FieldManager = "knative-operator"
+
+	// PlanSummaryKey is the key of the summary of the changes in the plan ConfigMap.
+	PlanSummaryKey = "summary"
+	// PlanKey is the key of the detailed changes in the plan ConfigMap.
+	PlanKey = "plan"
+
+	// maxPlanSize keeps the plan ConfigMap well below the size limit of etcd.
+	maxPlanSize = 900 * 1024
+)
+
+// Action is the change a resource would undergo.
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// ResourceChange describes the change of a single resource.
+type ResourceChange struct {
+	Action   Action
+	Resource *unstructured.Unstructured
+	// Diff is the difference between the live object and the result of applying the resource,
+	// set for updates only.
+	Diff string
+}
+
+// Plan is the list of the changes that installing a manifest would make to the cluster.
+type Plan []ResourceChange
+
+// IsDryRun returns true if the component asks for the changes to be planned instead of applied.
+func IsDryRun(instance base.KComponent) bool {
+	return strings.EqualFold(instance.GetAnnotations()[DryRunAnnotation], "true")
+}
+
+// Summary returns the number of resources per action.
+func (p Plan) Summary() string {
+	counts := map[Action]int{}
+	for _, c := range p {
+		counts[c.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d to delete, %d unchanged",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete], counts[ActionUnchanged])
+}
+
+// String returns the changed resources, along with the diffs of the updated ones.
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, c := range p {
+		if c.Action == ActionUnchanged {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", c.Action, c.Resource.GroupVersionKind().String(), resourceKey(c.Resource))
+		if c.Diff != "" {
+			b.WriteString(c.Diff)
+			if !strings.HasSuffix(c.Diff, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// ComputePlan computes the changes that installing the manifest, and deleting the resources of the
+// installed manifest that are no longer part of it, would make to the cluster. Updates are calculated
+// with a server-side apply in dry-run mode.
+func ComputePlan(ctx context.Context, getter mfdynamic.ResourceGetter, manifest, installed *mf.Manifest) (Plan, error) {
+	resources := manifest.Resources()
+	plan := make(Plan, 0, len(resources))
+	for i := range resources {
+		change, err := planResource(ctx, getter, &resources[i])
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, change)
+	}
+	if installed == nil {
+		return plan, nil
+	}
+	for _, u := range installed.Filter(mf.NoCRDs, mf.Not(mf.In(*manifest))).Resources() {
+		ri, err := getter.ResourceInterface(&u)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if _, err := ri.Get(ctx, u.GetName(), metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", resourceKey(&u), err)
+		}
+		plan = append(plan, ResourceChange{Action: ActionDelete, Resource: u.DeepCopy()})
+	}
+	return plan, nil
+}
+
+func planResource(ctx context.Context, getter mfdynamic.ResourceGetter, u *unstructured.Unstructured) (ResourceChange, error) {
+	change := ResourceChange{Action: ActionCreate, Resource: u}
+	ri, err := getter.ResourceInterface(u)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// The CRD of the resource is not installed yet.
+			return change, nil
+		}
+		return change, err
+	}
+	live, err := ri.Get(ctx, u.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return change, nil
+	}
+	if err != nil {
+		return change, fmt.Errorf("failed to get %s: %w", resourceKey(u), err)
+	}
+
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return change, err
+	}
+	applied, err := ri.Patch(ctx, u.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: FieldManager,
+		Force:        ptr.Bool(true),
+	})
+	if err != nil {
+		return change, fmt.Errorf("failed to dry-run apply %s: %w", resourceKey(u), err)
+	}
+	change.Diff = cmp.Diff(comparableFields(live), comparableFields(applied))
+	if change.Diff == "" {
+		change.Action = ActionUnchanged
+	} else {
+		change.Action = ActionUpdate
+	}
+	return change, nil
+}
+
+// comparableFields drops the fields of an object, which are maintained by the API server, and the
+// last applied configuration maintained by the appliers.
+func comparableFields(u *unstructured.Unstructured) map[string]interface{} {
+	obj := u.DeepCopy()
+	delete(obj.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp", "uid"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+	return obj.Object
+}
+
+func resourceKey(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName()
+	}
+	return u.GetNamespace() + "/" + u.GetName()
+}
+
+// PlanConfigMapName returns the name of the ConfigMap holding the plan of the component.
+func PlanConfigMapName(instance base.KComponent) string {
+	return strings.ToLower(instance.GroupVersionKind().Kind) + "-" + instance.GetName() + "-plan"
+}
+
+// RecordPlan returns a Stage, which computes the changes the manifest would make to the target cluster,
+// and records them in the plan ConfigMap next to the component. It is meant to replace the stages
+// installing the manifest, when the component is in dry-run mode.
+func RecordPlan(kubeClient kubernetes.Interface, clients *DynamicClients, state *ReconcileState, fetch ManifestFetcher) Stage {
+	return func(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+		getter, err := clients.ResourceGetter(state)
+		if err != nil {
+			return fmt.Errorf("failed to create the client to plan the changes: %w", err)
+		}
+		installed, err := fetch(ctx, instance)
+		if err != nil {
+			logging.FromContext(ctx).Warnw("Unable to obtain the installed manifest; obsolete resources are not planned", "error", err)
+			installed = nil
+		}
+		plan, err := ComputePlan(ctx, getter, manifest, installed)
+		if err != nil {
+			return err
+		}
+		state.Plan = plan
+		return savePlan(ctx, kubeClient, instance, plan)
+	}
+}
+
+func savePlan(ctx context.Context, kubeClient kubernetes.Interface, instance base.KComponent, plan Plan) error {
+	details := plan.String()
+	if len(details) > maxPlanSize {
+		details = details[:maxPlanSize] + "\n... truncated\n"
+	}
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PlanConfigMapName(instance),
+			Namespace: instance.GetNamespace(),
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "knative-operator",
+				"operator.knative.dev/cr-name": instance.GetName(),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(instance, instance.GroupVersionKind()),
+			},
+		},
+		Data: map[string]string{
+			PlanSummaryKey: plan.Summary(),
+			PlanKey:        details,
+		},
+	}
+
+	configMaps := kubeClient.CoreV1().ConfigMaps(desired.Namespace)
+	existing, err := configMaps.Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the plan ConfigMap: %w", err)
+	}
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Data = desired.Data
+	_, err = configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+// fakeGetter serves the live objects of the kinds it knows, and reports all the other kinds as unknown.
+type fakeGetter struct {
+	live map[string]map[string]*unstructured.Unstructured
+}
+
+func (g *fakeGetter) ResourceInterface(u *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	objs, ok := g.live[u.GetKind()]
+	if !ok {
+		return nil, &meta.NoKindMatchError{GroupKind: u.GroupVersionKind().GroupKind()}
+	}
+	return &fakeResource{objs: objs}, nil
+}
+
+type fakeResource struct {
+	dynamic.ResourceInterface
+	objs map[string]*unstructured.Unstructured
+}
+
+func (r *fakeResource) Get(_ context.Context, name string, _ metav1.GetOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if u, ok := r.objs[name]; ok {
+		return u.DeepCopy(), nil
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, name)
+}
+
+// Patch mimics a server-side apply by replacing the top-level fields of the live object.
+func (r *fakeResource) Patch(_ context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if pt != types.ApplyPatchType || len(opts.DryRun) == 0 || opts.FieldManager != FieldManager {
+		return nil, apierrors.NewBadRequest("unexpected patch")
+	}
+	applied := map[string]interface{}{}
+	if err := json.Unmarshal(data, &applied); err != nil {
+		return nil, err
+	}
+	result := r.objs[name].DeepCopy()
+	for k, v := range applied {
+		if k != "metadata" {
+			result.Object[k] = v
+		}
+	}
+	return result, nil
+}
+
+func configMap(name string, data map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "knative-serving",
+		},
+		"data": data,
+	}}
+	return u
+}
+
+func TestComputePlan(t *testing.T) {
+	unchanged := configMap("config-unchanged", map[string]interface{}{"a": "b"})
+	changed := configMap("config-changed", map[string]interface{}{"a": "b"})
+	obsolete := configMap("config-obsolete", map[string]interface{}{"a": "b"})
+	live := map[string]*unstructured.Unstructured{}
+	for _, u := range []*unstructured.Unstructured{unchanged, changed, obsolete} {
+		u := u.DeepCopy()
+		u.SetResourceVersion("42")
+		live[u.GetName()] = u
+	}
+	getter := &fakeGetter{live: map[string]map[string]*unstructured.Unstructured{"ConfigMap": live}}
+
+	created := configMap("config-new", nil)
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Example",
+		"metadata":   map[string]interface{}{"name": "example"},
+	}}
+	manifest, _ := mf.ManifestFrom(mf.Slice{
+		*unchanged,
+		*configMap("config-changed", map[string]interface{}{"a": "c"}),
+		*created,
+		*crd,
+	})
+	installed, _ := mf.ManifestFrom(mf.Slice{*unchanged, *changed, *obsolete, *configMap("config-gone", nil)})
+
+	plan, err := ComputePlan(context.Background(), getter, &manifest, &installed)
+	if err != nil {
+		t.Fatalf("ComputePlan() = %v", err)
+	}
+
+	actions := map[string]Action{}
+	for _, c := range plan {
+		actions[c.Resource.GetName()] = c.Action
+	}
+	util.AssertDeepEqual(t, actions, map[string]Action{
+		"config-unchanged": ActionUnchanged,
+		"config-changed":   ActionUpdate,
+		"config-new":       ActionCreate,
+		"example":          ActionCreate,
+		"config-obsolete":  ActionDelete,
+	})
+	util.AssertEqual(t, plan.Summary(), "2 to create, 1 to update, 1 to delete, 1 unchanged")
+
+	out := plan.String()
+	if strings.Contains(out, "config-unchanged") {
+		t.Errorf("String() = %q, should not contain the unchanged resources", out)
+	}
+	for _, want := range []string{
+		"update /v1, Kind=ConfigMap knative-serving/config-changed",
+		`-`, `"b"`, `+`, `"c"`,
+		"delete /v1, Kind=ConfigMap knative-serving/config-obsolete",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() = %q, want to contain %q", out, want)
+		}
+	}
+}
+
+func TestRecordPlanConfigMap(t *testing.T) {
+	ks := &v1beta1.KnativeServing{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "KnativeServing",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "knative-serving",
+			Namespace:   "knative-serving",
+			Annotations: map[string]string{DryRunAnnotation: "true"},
+		},
+	}
+	util.AssertEqual(t, IsDryRun(ks), true)
+
+	kubeClient := fake.NewSimpleClientset()
+	plan := Plan{{Action: ActionCreate, Resource: configMap("config-new", nil)}}
+	for i := 0; i < 2; i++ {
+		// The second round updates the existing ConfigMap.
+		if err := savePlan(context.Background(), kubeClient, ks, plan); err != nil {
+			t.Fatalf("savePlan() = %v", err)
+		}
+	}
+	cm, err := kubeClient.CoreV1().ConfigMaps("knative-serving").Get(context.Background(), "knativeserving-knative-serving-plan", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the plan ConfigMap: %v", err)
+	}
+	util.AssertEqual(t, cm.Data[PlanSummaryKey], "1 to create, 0 to update, 0 to delete, 0 unchanged")
+	util.AssertEqual(t, cm.Data[PlanKey], "create /v1, Kind=ConfigMap knative-serving/config-new\n")
+	util.AssertEqual(t, cm.OwnerReferences[0].Kind, "KnativeServing")
+}
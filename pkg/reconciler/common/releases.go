@@ -17,6 +17,8 @@ limitations under the License.
 package common
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -29,6 +31,7 @@ import (
 	"golang.org/x/mod/semver"
 	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/oci"
 )
 
 const (
@@ -42,8 +45,6 @@ const (
 	LATEST_VERSION = "latest"
 )
 
-var cache = map[string]mf.Manifest{}
-
 // TargetVersion returns the version of the manifest to be installed
 // per the spec in the component. If spec.version is empty, the latest
 // version known to the operator is returned.
@@ -68,31 +69,33 @@ func TargetVersion(instance base.KComponent) string {
 
 // TargetManifest returns the default manifest for the TargetVersion or the manifest for the TargetVersion specified
 // with spec.manifests
-func TargetManifest(instance base.KComponent) (mf.Manifest, error) {
+func TargetManifest(ctx context.Context, instance base.KComponent) (mf.Manifest, error) {
 	manifestsPath := targetManifestPath(instance)
 	if len(instance.GetSpec().GetManifests()) == 0 {
-		return getManifestWithVersionValidation(manifestsPath, instance, FetchManifest)
+		return getManifestWithVersionValidation(ctx, manifestsPath, instance, FetchManifest)
 	}
-	return getManifestWithVersionValidation(manifestsPath, instance, fetchManifestFromPath)
+	return getManifestWithVersionValidation(ctx, manifestsPath, instance,
+		verifyingFetcher(instance, instance.GetSpec().GetManifests()))
 }
 
 // TargetAdditionalManifest returns the manifest for the TargetVersion specified with spec.additionalManifests.
-func TargetAdditionalManifest(instance base.KComponent) (mf.Manifest, error) {
+func TargetAdditionalManifest(ctx context.Context, instance base.KComponent) (mf.Manifest, error) {
 	additionalManifestsPath := additionalManifestPath(instance)
 	if additionalManifestsPath == "" {
 		return mf.Manifest{}, nil
 	}
-	return getManifestWithVersionValidation(additionalManifestsPath, instance, fetchManifestFromPath)
+	return getManifestWithVersionValidation(ctx, additionalManifestsPath, instance,
+		verifyingFetcher(instance, instance.GetSpec().GetAdditionalManifests()))
 }
 
 // InstalledManifest returns the version currently installed, which is
 // harder than it sounds, since status.version isn't set until the
 // target version is successfully installed, which can take some time.
 // So we return the target manifest if status.version is empty.
-func InstalledManifest(instance base.KComponent) (mf.Manifest, error) {
+func InstalledManifest(ctx context.Context, instance base.KComponent) (mf.Manifest, error) {
 	current := instance.GetStatus().GetVersion()
 	if len(instance.GetStatus().GetManifests()) == 0 && current == "" {
-		return TargetManifest(instance)
+		return TargetManifest(ctx, instance)
 	}
 	// If status.manifests is not empty, get the manifests from the cache if available, and get them from
 	// the path if not available in the cache.
@@ -102,7 +105,7 @@ func InstalledManifest(instance base.KComponent) (mf.Manifest, error) {
 	if len(paths) == 0 {
 		return mf.Manifest{}, nil
 	}
-	return FetchManifestFromArray(paths)
+	return FetchManifestFromArray(ctx, paths)
 }
 
 // IsVersionValidMigrationEligible returns the bool indicate whether the target version is valid and the installed
@@ -169,11 +172,32 @@ func IsVersionValidMigrationEligible(instance base.KComponent) error {
 		"installed KnativeServing version is %v", current)
 }
 
-type manifestFetcher func(string) (mf.Manifest, error)
+// ValidateTargetVersion returns an error if the manifests of the target version are neither bundled with
+// the operator nor specified with spec.manifests, or if the installed version can neither be migrated to
+// it directly nor upgraded to it through intermediate releases.
+func ValidateTargetVersion(instance base.KComponent) error {
+	if _, err := allReleases(instance); err != nil {
+		return fmt.Errorf("unable to list the releases available to the operator: %w", err)
+	}
+	if len(instance.GetSpec().GetManifests()) == 0 && targetManifestPath(instance) == "" {
+		return fmt.Errorf("the manifests of the target version %v are not available to this release",
+			instance.GetSpec().GetVersion())
+	}
+	if err := IsVersionValidMigrationEligible(instance); err != nil {
+		// Upgrades across multiple minor versions are carried out through the intermediate releases.
+		if path, pathErr := UpgradePath(instance); pathErr == nil && len(path) != 0 {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
 
-func getManifestWithVersionValidation(manifestsPath string, instance base.KComponent, fn manifestFetcher) (mf.Manifest, error) {
+type manifestFetcher func(context.Context, string) (mf.Manifest, error)
+
+func getManifestWithVersionValidation(ctx context.Context, manifestsPath string, instance base.KComponent, fn manifestFetcher) (mf.Manifest, error) {
 	version := TargetVersion(instance)
-	manifests, err := fn(manifestsPath)
+	manifests, err := fn(ctx, manifestsPath)
 	if err != nil {
 		if len(instance.GetSpec().GetManifests()) == 0 {
 			// If we cannot access the manifests, there is no need to check whether the versions match.
@@ -220,26 +244,26 @@ func abs(x int) int {
 
 // FetchManifest returns the manifest by either getting it from the cache, or reading them from the path.
 // The manifest is saved in the cache, if it is not available.
-func FetchManifest(path string) (mf.Manifest, error) {
-	if m, ok := cache[path]; ok {
+func FetchManifest(ctx context.Context, path string) (mf.Manifest, error) {
+	if m, ok := sharedManifestCache().get(path); ok {
 		return m, nil
 	}
-	result, err := mf.NewManifest(path)
+	result, err := newManifest(ctx, path)
 	if err == nil {
-		cache[path] = result
+		sharedManifestCache().add(path, result)
 	}
 	return result, err
 }
 
 // FetchManifestFromArray returns the manifest by either getting it from the cache, or reading them from the path.
 // The manifest is saved in the cache, if it is not available.
-func FetchManifestFromArray(paths []string) (mf.Manifest, error) {
-	manifest, err := FetchManifest(paths[0])
+func FetchManifestFromArray(ctx context.Context, paths []string) (mf.Manifest, error) {
+	manifest, err := FetchManifest(ctx, paths[0])
 	if err != nil {
 		return manifest, err
 	}
 	for i := 1; i < len(paths); i++ {
-		m, er := FetchManifest(paths[i])
+		m, er := FetchManifest(ctx, paths[i])
 		if er != nil {
 			return manifest, er
 		}
@@ -249,17 +273,56 @@ func FetchManifestFromArray(paths []string) (mf.Manifest, error) {
 }
 
 // fetchManifestFromPath returns the manifest by reading them from the path, and saves them in the cache.
-func fetchManifestFromPath(path string) (mf.Manifest, error) {
-	result, err := mf.NewManifest(path)
+// Remote manifests are only downloaded again if they have changed.
+func fetchManifestFromPath(ctx context.Context, path string) (mf.Manifest, error) {
+	result, err := newManifest(ctx, path)
 	if err == nil {
-		cache[path] = result
+		sharedManifestCache().add(path, result)
 	}
 	return result, err
 }
 
-// ClearCache removes all the records saved in the cache.
+// newManifest reads the manifest from the comma-separated path. The oci:// URLs in the path are pulled
+// as OCI artifacts, the http(s) URLs are downloaded through the store of remote manifests, and the
+// others are read by manifestival.
+func newManifest(ctx context.Context, path string) (mf.Manifest, error) {
+	if !strings.Contains(path, oci.Scheme) && !strings.Contains(path, "http://") && !strings.Contains(path, "https://") {
+		return mf.NewManifest(path)
+	}
+	var result mf.Manifest
+	for _, p := range strings.Split(path, COMMA) {
+		var (
+			m   mf.Manifest
+			err error
+		)
+		switch {
+		case oci.IsReference(p):
+			m, err = pullManifest(ctx, p)
+		case isRemoteURL(p):
+			m, err = fetchRemoteManifest(ctx, p)
+		default:
+			m, err = mf.NewManifest(p)
+		}
+		if err != nil {
+			return mf.Manifest{}, err
+		}
+		result = result.Append(m)
+	}
+	return result, nil
+}
+
+func fetchRemoteManifest(ctx context.Context, url string) (mf.Manifest, error) {
+	data, err := remoteDocuments().fetch(ctx, url)
+	if err != nil {
+		return mf.Manifest{}, err
+	}
+	return mf.ManifestFrom(mf.Reader(bytes.NewReader(data)))
+}
+
+// ClearCache removes all the manifests saved in the cache from memory. The remote manifests persisted
+// on disk are kept.
 func ClearCache() {
-	cache = map[string]mf.Manifest{}
+	sharedManifestCache().clear()
 }
 
 func componentDir(instance base.KComponent) string {
@@ -17,6 +17,7 @@ limitations under the License.
 package common
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -654,6 +655,116 @@ func TestIsVersionValidMigrationEligible(t *testing.T) {
 	}
 }
 
+func TestValidateTargetVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		koPath    string
+		component base.KComponent
+		expected  bool
+	}{{
+		name:   "knative-serving with an available target version",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.26",
+				},
+			},
+			Status: v1beta1.KnativeServingStatus{
+				Version: "0.25.0",
+			},
+		},
+		expected: true,
+	}, {
+		name:   "knative-serving with an unavailable target version",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.26.7",
+				},
+			},
+		},
+		expected: false,
+	}, {
+		name:   "knative-serving with an unavailable target version in spec.manifests",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.26.7",
+					Manifests: []base.Manifest{{
+						Url: "https://example.com/serving-core.yaml",
+					}},
+				},
+			},
+		},
+		expected: true,
+	}, {
+		name:   "knative-eventing upgrading across multiple minor versions",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.26.0",
+				},
+			},
+			Status: v1beta1.KnativeEventingStatus{
+				Version: "0.24.2",
+			},
+		},
+		expected: true,
+	}, {
+		name:   "knative-eventing upgrading across multiple minor versions without intermediate releases",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.25.0",
+				},
+			},
+			Status: v1beta1.KnativeEventingStatus{
+				Version: "0.22.0",
+			},
+		},
+		expected: false,
+	}, {
+		name:   "knative-eventing downgrading across multiple minor versions",
+		koPath: "testdata/kodata",
+		component: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.24.2",
+				},
+			},
+			Status: v1beta1.KnativeEventingStatus{
+				Version: "0.26.0",
+			},
+		},
+		expected: false,
+	}, {
+		name:   "knative-eventing without any release available",
+		koPath: "testdata/nonexistent",
+		component: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.26.0",
+				},
+			},
+		},
+		expected: false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Setenv(KoEnvKey, test.koPath)
+			defer os.Unsetenv(KoEnvKey)
+			result := ValidateTargetVersion(test.component)
+			util.AssertEqual(t, result == nil, test.expected)
+		})
+	}
+}
+
 func TestTargetManifest(t *testing.T) {
 	koPath := "testdata/kodata"
 	os.Setenv(KoEnvKey, koPath)
@@ -871,7 +982,7 @@ func TestTargetManifest(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m, err := TargetManifest(test.component)
+			m, err := TargetManifest(context.Background(), test.component)
 			if err != nil {
 				util.AssertEqual(t, err.Error(), test.expectedError.Error())
 				util.AssertEqual(t, len(m.Resources()), 0)
@@ -976,7 +1087,7 @@ func TestTargetAdditionalManifest(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m, err := TargetAdditionalManifest(test.component)
+			m, err := TargetAdditionalManifest(context.Background(), test.component)
 			util.AssertEqual(t, err, nil)
 			if test.expectedManifestsPath != "" {
 				util.AssertEqual(t, util.DeepMatchWithPath(m, test.expectedManifestsPath), true)
@@ -1143,7 +1254,7 @@ func TestInstalledManifest(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m, err := InstalledManifest(test.component)
+			m, err := InstalledManifest(context.Background(), test.component)
 			// The InstalledManifest should never raise the error, even of the manifests are not available.
 			// If the installed manifests are unable to retrieve, it returns a manifest with no resource.
 			util.AssertEqual(t, util.DeepMatchWithPath(m, test.expectedManifestsPath), true)
@@ -1155,14 +1266,15 @@ func TestInstalledManifest(t *testing.T) {
 func TestCache(t *testing.T) {
 	// Make sure to start with empty cache
 	ClearCache()
-	util.AssertEqual(t, len(cache), 0)
+	util.AssertEqual(t, sharedManifestCache().len(), 0)
 	expectedPath := "testdata/kodata/knative-serving/0.26.1/"
 	manifest, err := mf.NewManifest(expectedPath)
-	cache["key"] = manifest
-	util.AssertEqual(t, len(cache), 1)
+	sharedManifestCache().add("key", manifest)
+	util.AssertEqual(t, sharedManifestCache().len(), 1)
 	util.AssertEqual(t, err, nil)
-	m := cache["key"]
+	m, ok := sharedManifestCache().get("key")
+	util.AssertEqual(t, ok, true)
 	util.AssertEqual(t, util.DeepMatchWithPath(m, expectedPath), true)
 	ClearCache()
-	util.AssertEqual(t, len(cache), 0)
+	util.AssertEqual(t, sharedManifestCache().len(), 0)
 }
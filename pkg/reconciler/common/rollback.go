@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// DefaultProgressDeadline is how long the deployments of a new version have to become available, if
+// spec.upgradeStrategy does not specify it.
+const DefaultProgressDeadline = 10 * time.Minute
+
+// now is replaced in the tests.
+var now = time.Now
+
+// RolledBackVersion returns the version the instance has been rolled back to, if its upgrade did not
+// become ready and its spec has not changed since. It returns an empty string otherwise.
+func RolledBackVersion(instance base.KComponent) string {
+	rollback := instance.GetStatus().GetRollback()
+	if instance.GetSpec().GetUpgradeStrategy() == nil || rollback == nil ||
+		rollback.RolledBackGeneration != instance.GetGeneration() {
+		return ""
+	}
+	return rollback.Version
+}
+
+// RecordRollbackTarget records the installed release in the status, when an upgrade starts, so that it can
+// be reinstalled if the upgrade does not become ready within the progress deadline. It needs to run before
+// the status is updated with the manifests of the target version.
+func RecordRollbackTarget(_ context.Context, _ *mf.Manifest, instance base.KComponent) error {
+	status := instance.GetStatus()
+	rollback := status.GetRollback()
+	if instance.GetSpec().GetUpgradeStrategy() == nil {
+		status.SetRollback(nil)
+		status.ClearRolledBack()
+		return nil
+	}
+	if rollback != nil && rollback.RolledBackGeneration != 0 {
+		if rollback.RolledBackGeneration == instance.GetGeneration() {
+			// The release the upgrade has been rolled back to is kept.
+			return nil
+		}
+		// The spec changed since the rollback, so the upgrade is attempted again.
+		status.ClearRolledBack()
+		rollback = nil
+	}
+
+	current := status.GetVersion()
+	if current == "" || current == TargetVersion(instance) {
+		status.SetRollback(nil)
+		return nil
+	}
+	if rollback == nil || rollback.Version != current {
+		status.SetRollback(&base.RollbackStatus{
+			Version:          current,
+			Manifests:        status.GetManifests(),
+			UpgradeStartTime: metav1.NewTime(now()),
+		})
+	}
+	return nil
+}
+
+// ProgressDeadlineExceeded returns whether the upgrade of the instance has not become ready within the
+// progress deadline. If the deadline has not been reached yet, it returns the time left.
+func ProgressDeadlineExceeded(instance base.KComponent) (bool, time.Duration) {
+	strategy := instance.GetSpec().GetUpgradeStrategy()
+	rollback := instance.GetStatus().GetRollback()
+	if strategy == nil || rollback == nil || rollback.RolledBackGeneration != 0 || len(rollback.Manifests) == 0 {
+		return false, 0
+	}
+	deadline := DefaultProgressDeadline
+	if strategy.ProgressDeadline != nil {
+		deadline = strategy.ProgressDeadline.Duration
+	}
+	left := rollback.UpgradeStartTime.Add(deadline).Sub(now())
+	if left <= 0 {
+		return true, 0
+	}
+	return false, left
+}
+
+// AppendRollbackManifests mutates the passed manifest by appending the manifests of the release recorded
+// to roll back to.
+func AppendRollbackManifests(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	rollback := instance.GetStatus().GetRollback()
+	if rollback == nil || len(rollback.Manifests) == 0 {
+		return fmt.Errorf("no release to roll back to is recorded")
+	}
+	m, err := FetchManifestFromArray(ctx, rollback.Manifests)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the manifests of the release %s to roll back to: %w", rollback.Version, err)
+	}
+	*manifest = manifest.Append(m)
+	return nil
+}
+
+// MarkRolledBack returns a Stage, which records in the status that the upgrade to the given version has been
+// rolled back, because the given deployments did not become available.
+func MarkRolledBack(failedVersion string, deployments []string) Stage {
+	return func(ctx context.Context, _ *mf.Manifest, instance base.KComponent) error {
+		status := instance.GetStatus()
+		rollback := status.GetRollback()
+		rollback.RolledBackGeneration = instance.GetGeneration()
+		status.SetManifests(rollback.Manifests)
+		status.SetVersion(rollback.Version)
+		msg := fmt.Sprintf("Upgrade to %s rolled back to %s, deployments not available: %s",
+			failedVersion, rollback.Version, strings.Join(deployments, ", "))
+		status.MarkRolledBack(msg)
+		logging.FromContext(ctx).Warn(msg)
+		return nil
+	}
+}
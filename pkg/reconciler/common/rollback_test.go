@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func servingWithStrategy(spec, status string, rollback *base.RollbackStatus) *v1beta1.KnativeServing {
+	ks := servingWithVersions(spec, status, nil)
+	ks.Generation = 2
+	ks.Spec.UpgradeStrategy = &base.UpgradeStrategy{
+		ProgressDeadline: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	ks.Status.Manifests = []string{"testdata/kodata/knative-serving/" + status}
+	ks.Status.Rollback = rollback
+	ks.Status.InitializeConditions()
+	return ks
+}
+
+func TestRecordRollbackTarget(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	recorded := &base.RollbackStatus{
+		Version:          "0.25.0",
+		Manifests:        []string{"testdata/kodata/knative-serving/0.25.0"},
+		UpgradeStartTime: metav1.NewTime(start.Add(-time.Minute)),
+	}
+	tests := []struct {
+		name      string
+		component *v1beta1.KnativeServing
+		expected  *base.RollbackStatus
+	}{{
+		name: "no upgrade strategy",
+		component: func() *v1beta1.KnativeServing {
+			ks := servingWithStrategy("0.26.0", "0.25.0", recorded.DeepCopy())
+			ks.Spec.UpgradeStrategy = nil
+			return ks
+		}(),
+	}, {
+		name:      "no upgrade",
+		component: servingWithStrategy("0.25.0", "0.25.0", recorded.DeepCopy()),
+	}, {
+		name:      "upgrade starts",
+		component: servingWithStrategy("0.26.0", "0.25.0", nil),
+		expected: &base.RollbackStatus{
+			Version:          "0.25.0",
+			Manifests:        []string{"testdata/kodata/knative-serving/0.25.0"},
+			UpgradeStartTime: metav1.NewTime(start),
+		},
+	}, {
+		name: "upgrade in progress",
+		component: func() *v1beta1.KnativeServing {
+			ks := servingWithStrategy("0.26.0", "0.25.0", recorded.DeepCopy())
+			// The manifests of the target version have been installed already.
+			ks.Status.Manifests = []string{"testdata/kodata/knative-serving/0.26.0"}
+			return ks
+		}(),
+		expected: recorded,
+	}, {
+		name: "rolled back",
+		component: func() *v1beta1.KnativeServing {
+			rollback := recorded.DeepCopy()
+			rollback.RolledBackGeneration = 2
+			return servingWithStrategy("0.26.0", "0.25.0", rollback)
+		}(),
+		expected: func() *base.RollbackStatus {
+			rollback := recorded.DeepCopy()
+			rollback.RolledBackGeneration = 2
+			return rollback
+		}(),
+	}, {
+		name: "spec changed after the rollback",
+		component: func() *v1beta1.KnativeServing {
+			rollback := recorded.DeepCopy()
+			rollback.RolledBackGeneration = 1
+			return servingWithStrategy("0.26.0", "0.25.0", rollback)
+		}(),
+		expected: &base.RollbackStatus{
+			Version:          "0.25.0",
+			Manifests:        []string{"testdata/kodata/knative-serving/0.25.0"},
+			UpgradeStartTime: metav1.NewTime(start),
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := RecordRollbackTarget(context.TODO(), nil, test.component); err != nil {
+				t.Fatalf("RecordRollbackTarget() = %v", err)
+			}
+			util.AssertDeepEqual(t, test.component.Status.Rollback, test.expected)
+		})
+	}
+}
+
+func TestProgressDeadlineExceeded(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+	rollback := &base.RollbackStatus{
+		Version:          "0.25.0",
+		Manifests:        []string{"testdata/kodata/knative-serving/0.25.0"},
+		UpgradeStartTime: metav1.NewTime(start),
+	}
+
+	ks := servingWithStrategy("0.26.0", "0.25.0", rollback)
+	now = func() time.Time { return start.Add(2 * time.Minute) }
+	exceeded, left := ProgressDeadlineExceeded(ks)
+	util.AssertEqual(t, exceeded, false)
+	util.AssertEqual(t, left, 3*time.Minute)
+
+	now = func() time.Time { return start.Add(5 * time.Minute) }
+	exceeded, _ = ProgressDeadlineExceeded(ks)
+	util.AssertEqual(t, exceeded, true)
+
+	// The default deadline applies without spec.upgradeStrategy.progressDeadline.
+	ks.Spec.UpgradeStrategy.ProgressDeadline = nil
+	exceeded, left = ProgressDeadlineExceeded(ks)
+	util.AssertEqual(t, exceeded, false)
+	util.AssertEqual(t, left, DefaultProgressDeadline-5*time.Minute)
+
+	// An upgrade, which has been rolled back, is not rolled back again.
+	now = func() time.Time { return start.Add(time.Hour) }
+	ks.Status.Rollback.RolledBackGeneration = ks.Generation
+	exceeded, left = ProgressDeadlineExceeded(ks)
+	util.AssertEqual(t, exceeded, false)
+	util.AssertEqual(t, left, time.Duration(0))
+}
+
+func TestRollbackStages(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+
+	rollback := &base.RollbackStatus{
+		Version:   "0.25.0",
+		Manifests: []string{"testdata/kodata/knative-serving/0.25.0"},
+	}
+	ks := servingWithStrategy("0.26.0", "0.25.0", rollback)
+	ks.Status.Manifests = []string{"testdata/kodata/knative-serving/0.26.0"}
+
+	manifest, _ := mf.ManifestFrom(mf.Slice{})
+	if err := AppendRollbackManifests(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("AppendRollbackManifests() = %v", err)
+	}
+	expected, _ := mf.NewManifest("testdata/kodata/knative-serving/0.25.0")
+	util.AssertEqual(t, len(manifest.Resources()), len(expected.Resources()))
+
+	if err := MarkRolledBack("0.26.0", []string{"activator", "controller"})(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("MarkRolledBack() = %v", err)
+	}
+	util.AssertDeepEqual(t, ks.Status.Manifests, rollback.Manifests)
+	util.AssertEqual(t, ks.Status.Version, "0.25.0")
+	util.AssertEqual(t, ks.Status.Rollback.RolledBackGeneration, int64(2))
+	util.AssertEqual(t, RolledBackVersion(ks), "0.25.0")
+	condition := ks.Status.GetCondition(base.RolledBack)
+	if condition == nil || !condition.IsTrue() {
+		t.Fatalf("RolledBack = %v, want True", condition)
+	}
+	util.AssertEqual(t, condition.Message, "Upgrade to 0.26.0 rolled back to 0.25.0, deployments not available: activator, controller")
+
+	// A new generation attempts the upgrade again.
+	ks.Generation = 3
+	util.AssertEqual(t, RolledBackVersion(ks), "")
+	if err := RecordRollbackTarget(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("RecordRollbackTarget() = %v", err)
+	}
+	if ks.Status.GetCondition(base.RolledBack) != nil {
+		t.Errorf("RolledBack = %v, want nil", ks.Status.GetCondition(base.RolledBack))
+	}
+}
@@ -19,8 +19,11 @@ package common
 import (
 	"context"
 	"fmt"
+	"time"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/pkg/logging"
@@ -42,7 +45,11 @@ type ExecuteResult struct {
 func (stages Stages) Execute(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) (ExecuteResult, error) {
 	var result ExecuteResult
 	for _, stage := range stages {
-		if err := stage(ctx, manifest, instance); err != nil {
+		start := time.Now()
+		err := stage(ctx, manifest, instance)
+		// Waiting for the deployments is not a failure of the stage.
+		recordStage(ctx, instance, stageName(stage), time.Since(start), err != nil && !IsDeploymentsNotReadyError(err))
+		if err != nil {
 			if IsDeploymentsNotReadyError(err) {
 				result.DeploymentsNotReady = true
 				break
@@ -61,7 +68,7 @@ func NoOp(context.Context, *mf.Manifest, base.KComponent) error {
 // AppendTarget mutates the passed manifest by appending one
 // appropriate for the passed KComponent
 func AppendTarget(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
-	m, err := TargetManifest(instance)
+	m, err := TargetManifest(ctx, instance)
 	if err != nil {
 		instance.GetStatus().MarkInstallFailed(err.Error())
 		return err
@@ -73,7 +80,7 @@ func AppendTarget(ctx context.Context, manifest *mf.Manifest, instance base.KCom
 // AppendAdditionalManifests mutates the passed manifest by appending the manifests specified with the
 // field spec.additionalManifests.
 func AppendAdditionalManifests(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
-	m, err := TargetAdditionalManifest(instance)
+	m, err := TargetAdditionalManifest(ctx, instance)
 	if err != nil {
 		instance.GetStatus().MarkInstallFailed(err.Error())
 		return err
@@ -90,11 +97,11 @@ func AppendAdditionalManifests(ctx context.Context, manifest *mf.Manifest, insta
 // corresponding to status.version
 func AppendInstalled(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	logger := logging.FromContext(ctx)
-	m, err := InstalledManifest(instance)
+	m, err := InstalledManifest(ctx, instance)
 	if err != nil {
 		// TODO: return the oldest instead of the latest?
 		logger.Error("Unable to fetch installed manifest, trying target", err)
-		m, err = TargetManifest(instance)
+		m, err = TargetManifest(ctx, instance)
 	}
 	if err != nil {
 		return err
@@ -106,6 +113,10 @@ func AppendInstalled(ctx context.Context, manifest *mf.Manifest, instance base.K
 type ReconcileState struct {
 	AnchorOwner   mf.Owner
 	RemoteClients RemoteClusterClients
+	// Plan is set by RecordPlan, when the component is in dry-run mode.
+	Plan Plan
+	// Drifted is set by DetectDrift to the resources left as they are, when the drift policy is "report".
+	Drifted []DriftedResource
 }
 
 func (s *ReconcileState) IsRemote() bool {
@@ -131,12 +142,21 @@ func DeleteObsoleteResources(ctx context.Context, instance base.KComponent, fetc
 		logger.Error("Unable to obtain the installed manifest; obsolete resources may linger", err)
 		return NoOp
 	}
-	return func(_ context.Context, manifest *mf.Manifest, _ base.KComponent) error {
+	return func(ctx context.Context, manifest *mf.Manifest, _ base.KComponent) error {
 		for _, r := range installed.Filter(mf.NoCRDs, mf.Not(mf.In(*manifest))).Resources() {
+			if _, err := manifest.Client.Get(&r); err != nil {
+				if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+					continue
+				}
+				return fmt.Errorf("failed to get obsolete resource: %w", err)
+			}
 			m, _ := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{r}), mf.UseClient(manifest.Client))
 			if err := m.Delete(); err != nil && !meta.IsNoMatchError(err) {
 				return fmt.Errorf("failed to delete obsolete resources: %w", err)
 			}
+			recordResources(ctx, resourcesDeleted, instance, m)
+			RecordEvent(ctx, instance, corev1.EventTypeNormal, ReasonObsoleteResourceDeleted, "Deleted obsolete %s %s",
+				r.GetKind(), resourceKey(&r))
 		}
 		return nil
 	}
@@ -32,6 +32,7 @@ func transformers(ctx context.Context, obj base.KComponent) []mf.Transformer {
 		NamespaceConfigurationTransform(obj.GetSpec().GetNamespaceConfiguration()),
 		HighAvailabilityTransform(obj),
 		ImageTransform(obj.GetSpec().GetRegistry(), logger),
+		ImageDigestTransform(ctx, obj),
 		JobTransform(obj),
 		ConfigMapTransform(obj.GetSpec().GetConfig(), logger),
 		KubernetesMinVersionTransform(),
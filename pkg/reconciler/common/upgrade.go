@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"golang.org/x/mod/semver"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// jobsPollInterval is the interval to check whether the Jobs of a version completed, before the
+// next version of an upgrade is installed.
+const jobsPollInterval = 10 * time.Second
+
+// UpgradePath returns the versions to install in turn to move the instance from the installed version to
+// the target version, when they are more than one minor version apart. The path goes through the latest
+// patch release of every intermediate minor version available under kodata, and ends with the target
+// version. It returns nil, if the target version can be installed directly.
+func UpgradePath(instance base.KComponent) ([]string, error) {
+	if len(instance.GetSpec().GetManifests()) != 0 {
+		// The manifests of the intermediate versions are only known for the releases under kodata.
+		return nil, nil
+	}
+	current := instance.GetStatus().GetVersion()
+	target := TargetVersion(instance)
+	if current == "" || current == LATEST_VERSION || target == LATEST_VERSION {
+		return nil, nil
+	}
+
+	current, target = SanitizeSemver(current), SanitizeSemver(target)
+	if !semver.IsValid(current) || !semver.IsValid(target) || semver.Major(current) != semver.Major(target) ||
+		semver.Compare(current, target) >= 0 {
+		// Only upgrades within the same major version are carried out in steps.
+		return nil, nil
+	}
+	currentMinor, err := minorVersion(current)
+	if err != nil {
+		return nil, err
+	}
+	targetMinor, err := minorVersion(target)
+	if err != nil {
+		return nil, err
+	}
+	if targetMinor-currentMinor < 2 {
+		return nil, nil
+	}
+
+	releases, err := allReleases(instance)
+	if err != nil {
+		return nil, err
+	}
+	major := strings.TrimPrefix(semver.Major(current), "v")
+	path := make([]string, 0, targetMinor-currentMinor)
+	for minor := currentMinor + 1; minor < targetMinor; minor++ {
+		majorMinor := fmt.Sprintf("%s.%d", major, minor)
+		release := getLatestReleaseFromList(releases, majorMinor)
+		if release == majorMinor {
+			return nil, fmt.Errorf("unable to upgrade through the version %s, because none of its releases "+
+				"is available to this release", majorMinor)
+		}
+		path = append(path, release)
+	}
+	return append(path, TargetVersion(instance)), nil
+}
+
+// ResolveUpgradeStep returns the version to install in this reconciliation, if the target version of the
+// instance can only be reached by upgrading through intermediate minor versions, and records the progress
+// of the upgrade in the status. It returns an empty string, if the target version can be installed directly.
+func ResolveUpgradeStep(instance base.KComponent) (string, error) {
+	status := instance.GetStatus()
+	path, err := UpgradePath(instance)
+	if err != nil {
+		return "", err
+	}
+
+	previous := status.GetUpgrade()
+	if previous != nil && (len(previous.Path) == 0 || previous.Path[len(previous.Path)-1] != TargetVersion(instance)) {
+		// The target version changed since the upgrade started.
+		previous = nil
+	}
+	if len(path) == 0 {
+		if previous != nil {
+			// The last step of the upgrade installs the target version directly.
+			status.SetUpgrade(&base.UpgradeStatus{Path: previous.Path, CurrentStep: len(previous.Path) - 1})
+		} else {
+			status.SetUpgrade(nil)
+		}
+		return "", nil
+	}
+
+	upgrade := &base.UpgradeStatus{Path: path}
+	if previous != nil {
+		// Keep the path recorded when the upgrade started, so that the steps keep their numbers.
+		for i, version := range previous.Path {
+			if version == path[0] {
+				upgrade = &base.UpgradeStatus{Path: previous.Path, CurrentStep: i}
+				break
+			}
+		}
+	}
+	status.SetUpgrade(upgrade)
+	return path[0], nil
+}
+
+// ResolveVersion sets the version, the spec.version of the instance, to the version to install in
+// this reconciliation: the version a failed upgrade has been rolled back to, until the spec changes,
+// or the next version on the upgrade path. The returned function restores the target version, and
+// must be called even if an error is returned.
+func ResolveVersion(instance base.KComponent, version *string) (func(), error) {
+	target := *version
+	restore := func() { *version = target }
+	if rolledBack := RolledBackVersion(instance); rolledBack != "" {
+		*version = rolledBack
+	}
+	step, err := ResolveUpgradeStep(instance)
+	if err != nil {
+		return restore, err
+	}
+	if step != "" {
+		*version = step
+	}
+	return restore, nil
+}
+
+// CheckJobs checks whether the Jobs in the manifest completed, while an upgrade across multiple minor
+// versions is in progress, so that the next version is only installed after the post-install Jobs of the
+// previous one succeeded.
+func CheckJobs(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	status := instance.GetStatus()
+	if status.GetUpgrade() == nil {
+		return nil
+	}
+	var pending []string
+	for _, u := range manifest.Filter(mf.ByKind("Job")).Resources() {
+		resource, err := manifest.Client.Get(&u)
+		if apierrors.IsNotFound(err) {
+			pending = append(pending, u.GetName())
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		job := &batchv1.Job{}
+		if err := scheme.Scheme.Convert(resource, job, nil); err != nil {
+			return err
+		}
+		if isJobFailed(job) {
+			msg := fmt.Sprintf("job %s failed, the upgrade cannot proceed", job.Name)
+			status.MarkInstallFailed(msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if job.Status.Succeeded == 0 {
+			pending = append(pending, job.Name)
+		}
+	}
+	if len(pending) > 0 {
+		logging.FromContext(ctx).Infow("Waiting on jobs before upgrading to the next version", "jobs", pending)
+		return controller.NewRequeueAfter(jobsPollInterval)
+	}
+	return nil
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func minorVersion(version string) (int, error) {
+	minor, err := strconv.Atoi(strings.Split(semver.MajorMinor(version), ".")[1])
+	if err != nil {
+		return 0, fmt.Errorf("minor number of the version %v should be an integer", version)
+	}
+	return minor, nil
+}
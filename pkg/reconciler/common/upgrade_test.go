@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	fake "github.com/manifestival/manifestival/fake"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func servingWithVersions(spec, status string, upgrade *base.UpgradeStatus) *v1beta1.KnativeServing {
+	return &v1beta1.KnativeServing{
+		Spec: v1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{
+				Version: spec,
+			},
+		},
+		Status: v1beta1.KnativeServingStatus{
+			Version: status,
+			Upgrade: upgrade,
+		},
+	}
+}
+
+func TestUpgradePath(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+
+	tests := []struct {
+		name      string
+		component base.KComponent
+		expected  []string
+		wantError bool
+	}{{
+		name:      "new installation",
+		component: servingWithVersions("0.26", "", nil),
+	}, {
+		name:      "upgrade by one minor version",
+		component: servingWithVersions("0.25.0", "0.24.0", nil),
+	}, {
+		name:      "downgrade across multiple minor versions",
+		component: servingWithVersions("0.24.0", "0.26.0", nil),
+	}, {
+		name:      "upgrade across the major version",
+		component: servingWithVersions("1.0.0", "0.24.0", nil),
+	}, {
+		name:      "upgrade across multiple minor versions",
+		component: servingWithVersions("0.26", "0.23.1", nil),
+		expected:  []string{"0.24.0", "0.25.0", "0.26.1"},
+	}, {
+		name: "upgrade across multiple minor versions with spec.manifests",
+		component: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version:   "0.26.0",
+					Manifests: []base.Manifest{{Url: "testdata/kodata/knative-serving/0.26.0"}},
+				},
+			},
+			Status: v1beta1.KnativeServingStatus{
+				Version: "0.23.0",
+			},
+		},
+	}, {
+		name: "upgrade without an intermediate release",
+		component: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.25.0",
+				},
+			},
+			Status: v1beta1.KnativeEventingStatus{
+				Version: "0.22.0",
+			},
+		},
+		wantError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := UpgradePath(test.component)
+			if (err != nil) != test.wantError {
+				t.Fatalf("UpgradePath() = %v, wantError: %v", err, test.wantError)
+			}
+			util.AssertDeepEqual(t, path, test.expected)
+		})
+	}
+}
+
+func TestResolveUpgradeStep(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+
+	path := []string{"0.24.0", "0.25.0", "0.26.1"}
+	tests := []struct {
+		name            string
+		component       *v1beta1.KnativeServing
+		expectedStep    string
+		expectedUpgrade *base.UpgradeStatus
+	}{{
+		name:         "first step",
+		component:    servingWithVersions("0.26", "0.23.0", nil),
+		expectedStep: "0.24.0",
+		expectedUpgrade: &base.UpgradeStatus{
+			Path: path,
+		},
+	}, {
+		name:         "second step keeps the recorded path",
+		component:    servingWithVersions("0.26", "0.24.0", &base.UpgradeStatus{Path: path}),
+		expectedStep: "0.25.0",
+		expectedUpgrade: &base.UpgradeStatus{
+			Path:        path,
+			CurrentStep: 1,
+		},
+	}, {
+		name:         "last step installs the target version",
+		component:    servingWithVersions("0.26", "0.25.0", &base.UpgradeStatus{Path: path, CurrentStep: 1}),
+		expectedStep: "",
+		expectedUpgrade: &base.UpgradeStatus{
+			Path:        path,
+			CurrentStep: 2,
+		},
+	}, {
+		name:         "target version changed",
+		component:    servingWithVersions("0.25", "0.24.0", &base.UpgradeStatus{Path: path}),
+		expectedStep: "",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			step, err := ResolveUpgradeStep(test.component)
+			if err != nil {
+				t.Fatalf("ResolveUpgradeStep() = %v", err)
+			}
+			util.AssertEqual(t, step, test.expectedStep)
+			util.AssertDeepEqual(t, test.component.Status.Upgrade, test.expectedUpgrade)
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+
+	ks := servingWithVersions("0.26", "0.23.0", nil)
+	restore, err := ResolveVersion(ks, &ks.Spec.Version)
+	if err != nil {
+		t.Fatalf("ResolveVersion() = %v", err)
+	}
+	util.AssertEqual(t, ks.Spec.Version, "0.24.0")
+	restore()
+	util.AssertEqual(t, ks.Spec.Version, "0.26")
+
+	// The release an upgrade has been rolled back to is installed, until the spec changes.
+	ks = servingWithStrategy("0.26.0", "0.25.0", &base.RollbackStatus{Version: "0.25.0", RolledBackGeneration: 2})
+	restore, err = ResolveVersion(ks, &ks.Spec.Version)
+	if err != nil {
+		t.Fatalf("ResolveVersion() = %v", err)
+	}
+	util.AssertEqual(t, ks.Spec.Version, "0.25.0")
+	restore()
+	util.AssertEqual(t, ks.Spec.Version, "0.26.0")
+}
+
+func TestMarkStatusSuccessCompletesUpgrade(t *testing.T) {
+	os.Setenv(KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(KoEnvKey)
+
+	upgrade := &base.UpgradeStatus{Path: []string{"0.24.0", "0.25.0", "0.26.1"}}
+	ks := servingWithVersions("0.24.0", "0.23.0", upgrade)
+	if err := MarkStatusSuccess(context.TODO(), nil, ks); err != nil {
+		t.Fatalf("MarkStatusSuccess() = %v", err)
+	}
+	util.AssertEqual(t, ks.Status.Version, "0.24.0")
+	util.AssertDeepEqual(t, ks.Status.Upgrade, upgrade)
+
+	ks.Spec.Version = "0.26"
+	if err := MarkStatusSuccess(context.TODO(), nil, ks); err != nil {
+		t.Fatalf("MarkStatusSuccess() = %v", err)
+	}
+	util.AssertEqual(t, ks.Status.Version, "0.26.1")
+	if ks.Status.Upgrade != nil {
+		t.Errorf("Upgrade = %v, want nil", ks.Status.Upgrade)
+	}
+}
+
+func TestCheckJobs(t *testing.T) {
+	job := func(name string, status batchv1.JobStatus) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+			Status:     status,
+		}
+	}
+	succeeded := job("succeeded", batchv1.JobStatus{Succeeded: 1})
+	running := job("running", batchv1.JobStatus{Active: 1})
+	failed := job("failed", batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+		Type:   batchv1.JobFailed,
+		Status: corev1.ConditionTrue,
+	}}})
+
+	tests := []struct {
+		name        string
+		upgrade     *base.UpgradeStatus
+		inManifest  []unstructured.Unstructured
+		inAPI       []runtime.Object
+		wantRequeue bool
+		wantError   bool
+	}{{
+		name:       "no upgrade in progress",
+		inManifest: []unstructured.Unstructured{*NamespacedResource("batch/v1", "Job", "test", "running")},
+		inAPI:      []runtime.Object{running},
+	}, {
+		name:       "succeeded job",
+		upgrade:    &base.UpgradeStatus{Path: []string{"0.25.0", "0.26.0"}},
+		inManifest: []unstructured.Unstructured{*NamespacedResource("batch/v1", "Job", "test", "succeeded")},
+		inAPI:      []runtime.Object{succeeded},
+	}, {
+		name:    "running job",
+		upgrade: &base.UpgradeStatus{Path: []string{"0.25.0", "0.26.0"}},
+		inManifest: []unstructured.Unstructured{
+			*NamespacedResource("batch/v1", "Job", "test", "succeeded"),
+			*NamespacedResource("batch/v1", "Job", "test", "running"),
+		},
+		inAPI:       []runtime.Object{succeeded, running},
+		wantRequeue: true,
+	}, {
+		name:        "not found job",
+		upgrade:     &base.UpgradeStatus{Path: []string{"0.25.0", "0.26.0"}},
+		inManifest:  []unstructured.Unstructured{*NamespacedResource("batch/v1", "Job", "test", "notFound")},
+		wantRequeue: true,
+	}, {
+		name:       "failed job",
+		upgrade:    &base.UpgradeStatus{Path: []string{"0.25.0", "0.26.0"}},
+		inManifest: []unstructured.Unstructured{*NamespacedResource("batch/v1", "Job", "test", "failed")},
+		inAPI:      []runtime.Object{failed},
+		wantError:  true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.New(test.inAPI...)
+			manifest, err := mf.ManifestFrom(mf.Slice(test.inManifest), mf.UseClient(client))
+			if err != nil {
+				t.Fatalf("Failed to generate manifest: %v", err)
+			}
+			ks := &v1beta1.KnativeServing{}
+			ks.Status.InitializeConditions()
+			ks.Status.Upgrade = test.upgrade
+
+			err = CheckJobs(context.TODO(), &manifest, ks)
+			requeue, _ := controller.IsRequeueKey(err)
+			util.AssertEqual(t, requeue, test.wantRequeue)
+			if gotError := err != nil && !requeue; gotError != test.wantError {
+				t.Fatalf("CheckJobs() = %v, wantError: %v", err, test.wantError)
+			}
+			if test.wantError && !ks.Status.GetCondition(base.InstallSucceeded).IsFalse() {
+				t.Errorf("InstallSucceeded = %v, want False", ks.Status.GetCondition(base.InstallSucceeded))
+			}
+		})
+	}
+}
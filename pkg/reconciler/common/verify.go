@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/oci"
+	"knative.dev/operator/pkg/signature"
+)
+
+// verifyingFetcher returns the manifestFetcher of the manifests, which verifies their digests and,
+// if spec.manifestVerification is set, their signatures. The manifests are read only once, so the
+// verified content is the installed one.
+func verifyingFetcher(instance base.KComponent, manifests []base.Manifest) manifestFetcher {
+	verification := instance.GetSpec().GetManifestVerification()
+	version := TargetVersion(instance)
+	byURL := make(map[string]base.Manifest, len(manifests))
+	needed := verification != nil
+	for _, m := range manifests {
+		byURL[strings.ReplaceAll(m.Url, VersionVariable, version)] = m
+		needed = needed || m.Digest != ""
+	}
+	if !needed {
+		return fetchManifestFromPath
+	}
+	return func(ctx context.Context, path string) (mf.Manifest, error) {
+		var result mf.Manifest
+		for _, p := range strings.Split(path, COMMA) {
+			m, err := fetchVerifiedManifest(ctx, p, byURL[p], verification, version)
+			if err != nil {
+				return mf.Manifest{}, fmt.Errorf("failed to verify the manifest %s: %w", p, err)
+			}
+			result = result.Append(m)
+		}
+		sharedManifestCache().add(path, result)
+		return result, nil
+	}
+}
+
+func fetchVerifiedManifest(ctx context.Context, url string, manifest base.Manifest, verification *base.ManifestVerification, version string) (mf.Manifest, error) {
+	if oci.IsReference(url) {
+		if verification != nil {
+			return mf.Manifest{}, fmt.Errorf("signatures of oci:// manifests are not supported")
+		}
+		if manifest.Digest == "" {
+			return pullManifest(ctx, url)
+		}
+		// Pulling the artifact by digest verifies it.
+		ref, err := oci.ParseReference(url)
+		if err != nil {
+			return mf.Manifest{}, err
+		}
+		pinnedURL := oci.Pin(ref, manifest.Digest)
+		m, err := pullManifest(ctx, pinnedURL)
+		if err != nil {
+			return mf.Manifest{}, err
+		}
+		pin(url, pinnedURL)
+		return m, nil
+	}
+
+	data, err := readManifest(ctx, url)
+	if err != nil {
+		return mf.Manifest{}, err
+	}
+	if manifest.Digest != "" {
+		if digest := oci.Digest(data); digest != manifest.Digest {
+			return mf.Manifest{}, fmt.Errorf("the digest %s does not match %s", digest, manifest.Digest)
+		}
+	}
+	if verification != nil {
+		if err := verifySignature(ctx, url, manifest, verification, version, data); err != nil {
+			return mf.Manifest{}, err
+		}
+	}
+	return mf.ManifestFrom(mf.Reader(bytes.NewReader(data)))
+}
+
+func verifySignature(ctx context.Context, url string, manifest base.Manifest, verification *base.ManifestVerification, version string, data []byte) error {
+	sigURL := url + ".sig"
+	if manifest.SignatureURL != "" {
+		sigURL = strings.ReplaceAll(manifest.SignatureURL, VersionVariable, version)
+	}
+	sig, err := readManifest(ctx, sigURL)
+	if err != nil {
+		return fmt.Errorf("failed to read the signature: %w", err)
+	}
+	if verification.Keyless == nil {
+		return signature.VerifyWithKey([]byte(verification.PublicKey), data, sig)
+	}
+
+	certURL := url + ".pem"
+	if manifest.CertificateURL != "" {
+		certURL = strings.ReplaceAll(manifest.CertificateURL, VersionVariable, version)
+	}
+	cert, err := readManifest(ctx, certURL)
+	if err != nil {
+		return fmt.Errorf("failed to read the signing certificate: %w", err)
+	}
+	identity := signature.Identity{
+		Issuer:  verification.Keyless.Issuer,
+		Subject: verification.Keyless.Subject,
+	}
+	if identity.Subject == "" {
+		if identity.SubjectRegExp, err = regexp.Compile(verification.Keyless.SubjectRegExp); err != nil {
+			return err
+		}
+	}
+	return signature.VerifyKeyless(identity, []byte(verification.Keyless.TrustedRoots), cert, data, sig)
+}
+
+// readManifest reads a single file from a local path or an http(s) URL.
+func readManifest(ctx context.Context, url string) ([]byte, error) {
+	if !isRemoteURL(url) {
+		if info, err := os.Stat(url); err == nil && info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory, digests and signatures require a single file", url)
+		}
+		return os.ReadFile(url)
+	}
+	return remoteDocuments().fetch(ctx, url)
+}
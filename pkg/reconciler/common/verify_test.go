@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/oci"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestAppendTargetVerification(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config-verified\n  namespace: knative-serving\n")
+	manifestPath := filepath.Join(dir, "serving-core.yaml")
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manifestPath+".sig", []byte(base64.StdEncoding.EncodeToString(sig)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A signature of other content.
+	otherSig, err := ecdsa.SignASN1(rand.Reader, key, make([]byte, sha256.Size))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSigPath := filepath.Join(dir, "other.sig")
+	if err := os.WriteFile(otherSigPath, []byte(base64.StdEncoding.EncodeToString(otherSig)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		manifest     base.Manifest
+		verification *base.ManifestVerification
+		wantErr      string
+	}{{
+		name:     "matching digest",
+		manifest: base.Manifest{Url: manifestPath, Digest: oci.Digest(data)},
+	}, {
+		name:     "mismatching digest",
+		manifest: base.Manifest{Url: manifestPath, Digest: "sha256:" + strings.Repeat("0", 64)},
+		wantErr:  "the digest " + oci.Digest(data) + " does not match",
+	}, {
+		name:         "valid signature",
+		manifest:     base.Manifest{Url: manifestPath},
+		verification: &base.ManifestVerification{PublicKey: publicKey},
+	}, {
+		name:         "invalid signature",
+		manifest:     base.Manifest{Url: manifestPath, SignatureURL: otherSigPath},
+		verification: &base.ManifestVerification{PublicKey: publicKey},
+		wantErr:      "invalid signature",
+	}, {
+		name:         "missing signature",
+		manifest:     base.Manifest{Url: manifestPath, SignatureURL: filepath.Join(dir, "missing.sig")},
+		verification: &base.ManifestVerification{PublicKey: publicKey},
+		wantErr:      "failed to read the signature",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ClearCache()
+			defer ClearCache()
+			ks := &v1beta1.KnativeServing{
+				Spec: v1beta1.KnativeServingSpec{
+					CommonSpec: base.CommonSpec{
+						Version:              "0.26.0",
+						Manifests:            []base.Manifest{test.manifest},
+						ManifestVerification: test.verification,
+					},
+				},
+			}
+			ks.Status.InitializeConditions()
+			manifest := mf.Manifest{}
+			err := AppendTarget(context.Background(), &manifest, ks)
+			cond := ks.Status.GetCondition(base.InstallSucceeded)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("AppendTarget() = %v", err)
+				}
+				util.AssertEqual(t, len(manifest.Resources()), 1)
+				util.AssertEqual(t, cond.Status, corev1.ConditionUnknown)
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("AppendTarget() = %v, want an error containing %q", err, test.wantErr)
+			}
+			util.AssertEqual(t, len(manifest.Resources()), 0)
+			util.AssertEqual(t, cond.Status, corev1.ConditionFalse)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"golang.org/x/mod/semver"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// The manifests of the controller of eventing-kafka-broker, shared by the Kafka source, broker and
+// channel, in the kodata directory of the Kafka source.
+var kafkaControllerFiles = []string{"eventing-kafka-controller.yaml", "eventing-kafka-post-install.yaml"}
+
+// kodataDir returns the kodata directory of the component for the version, e.g.
+// kodata/eventing-broker/<major.minor>.
+func kodataDir(component, version string) string {
+	koDataDir := os.Getenv(common.KoEnvKey)
+	componentVersion := common.LATEST_VERSION
+	if !strings.EqualFold(version, common.LATEST_VERSION) {
+		componentVersion = semver.MajorMinor(common.SanitizeSemver(version))[1:]
+	}
+	return filepath.Join(koDataDir, component, componentVersion)
+}
+
+// GetBrokerPath returns the path of the manifests of the broker and channel implementations,
+// selected by the Eventing CR.
+func GetBrokerPath(version string, ke *v1beta1.KnativeEventing) string {
+	broker, channel := ke.Spec.KafkaBrokerEnabled(), ke.Spec.KafkaChannelEnabled()
+	var urls []string
+	// The Kafka source installs the controller already.
+	if (broker || channel) && (ke.Spec.Source == nil || !ke.Spec.Source.Kafka.Enabled) {
+		for _, file := range kafkaControllerFiles {
+			urls = append(urls, filepath.Join(kodataDir("eventing-source", version), "kafka", file))
+		}
+	}
+	if broker {
+		urls = append(urls, filepath.Join(kodataDir("eventing-broker", version), "kafka"))
+	}
+	if channel {
+		urls = append(urls, filepath.Join(kodataDir("eventing-channel", version), "kafka"))
+	}
+	if ke.Spec.RabbitmqBrokerEnabled() {
+		urls = append(urls, filepath.Join(kodataDir("eventing-broker", version), "rabbitmq"))
+	}
+	return strings.Join(urls, common.COMMA)
+}
+
+// AppendTargetBrokers appends the manifests of the broker and channel implementations to be installed.
+func AppendTargetBrokers(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	ke, ok := instance.(*v1beta1.KnativeEventing)
+	if !ok || (!ke.Spec.KafkaBrokerEnabled() && !ke.Spec.KafkaChannelEnabled() && !ke.Spec.RabbitmqBrokerEnabled()) {
+		return nil
+	}
+	path := GetBrokerPath(common.TargetVersion(instance), ke)
+	err := Bundled(path)
+	if err == nil {
+		var m mf.Manifest
+		if m, err = common.FetchManifest(ctx, path); err == nil {
+			*manifest = manifest.Append(m)
+		}
+	}
+	if err := appendRabbitmqBrokerConfig(manifest, ke); err != nil {
+		return err
+	}
+	if len(instance.GetSpec().GetManifests()) != 0 {
+		// As for the eventing sources, spec.manifests may provide the manifests of a version, which
+		// the operator does not bundle.
+		return nil
+	}
+	return err
+}
+
+// Bundled returns an error naming the first path, which is not bundled in the kodata directory.
+func Bundled(path string) error {
+	for _, p := range strings.Split(path, common.COMMA) {
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Errorf("the manifests %s are not bundled with the operator, run cmd/fetcher to add them or set spec.manifests: %w", p, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestGetBrokerPath(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+	koData := os.Getenv(common.KoEnvKey)
+	kafkaBroker := &eventingv1beta1.BrokerConfigs{Kafka: base.KafkaBrokerConfiguration{Enabled: true}}
+	kafkaChannel := &eventingv1beta1.ChannelConfigs{Kafka: base.KafkaChannelConfiguration{Enabled: true}}
+
+	tests := []struct {
+		name     string
+		spec     eventingv1beta1.KnativeEventingSpec
+		expected string
+	}{{
+		name:     "no broker or channel",
+		expected: "",
+	}, {
+		name: "disabled broker",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			Broker: &eventingv1beta1.BrokerConfigs{},
+		},
+		expected: "",
+	}, {
+		name: "kafka broker",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			Broker: kafkaBroker,
+		},
+		expected: koData + "/eventing-source/1.23/kafka/eventing-kafka-controller.yaml" + common.COMMA +
+			koData + "/eventing-source/1.23/kafka/eventing-kafka-post-install.yaml" + common.COMMA +
+			koData + "/eventing-broker/1.23/kafka",
+	}, {
+		name: "kafka broker and channel with the kafka source installing the controller",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			Source: &eventingv1beta1.SourceConfigs{
+				Kafka: base.KafkaSourceConfiguration{Enabled: true},
+			},
+			Broker:  kafkaBroker,
+			Channel: kafkaChannel,
+		},
+		expected: koData + "/eventing-broker/1.23/kafka" + common.COMMA +
+			koData + "/eventing-channel/1.23/kafka",
+	}, {
+		name: "rabbitmq broker",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			Broker: &eventingv1beta1.BrokerConfigs{Rabbitmq: base.RabbitmqBrokerConfiguration{Enabled: true}},
+		},
+		expected: koData + "/eventing-broker/1.23/rabbitmq",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ke := &eventingv1beta1.KnativeEventing{Spec: tt.spec}
+			util.AssertEqual(t, GetBrokerPath("1.23.0", ke), tt.expected)
+		})
+	}
+}
+
+func TestAppendTargetBrokers(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+
+	tests := []struct {
+		name      string
+		spec      eventingv1beta1.KnativeEventingSpec
+		resources int
+		err       bool
+	}{{
+		name:      "no broker or channel",
+		resources: 0,
+	}, {
+		name: "kafka broker and channel",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{Version: "1.23"},
+			Broker:     &eventingv1beta1.BrokerConfigs{Kafka: base.KafkaBrokerConfiguration{Enabled: true}},
+			Channel:    &eventingv1beta1.ChannelConfigs{Kafka: base.KafkaChannelConfiguration{Enabled: true}},
+		},
+		// The controller, its post-install job and the data planes of the broker and the channel.
+		resources: 10,
+	}, {
+		name: "rabbitmq broker with a cluster reference",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{Version: "1.23"},
+			Broker: &eventingv1beta1.BrokerConfigs{
+				Rabbitmq: base.RabbitmqBrokerConfiguration{
+					Enabled:    true,
+					ClusterRef: &base.RabbitmqClusterReference{Name: "rabbitmq", Namespace: "rabbitmq-system"},
+				},
+			},
+		},
+		// The CRD and the controller of the broker, and the RabbitmqBrokerConfig.
+		resources: 3,
+	}, {
+		name: "unavailable version",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{Version: "1.12"},
+			Broker:     &eventingv1beta1.BrokerConfigs{Kafka: base.KafkaBrokerConfiguration{Enabled: true}},
+		},
+		err: true,
+	}, {
+		name: "unavailable version with spec.manifests",
+		spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Version:   "1.12",
+				Manifests: []base.Manifest{{Url: "testdata/kodata/eventing-broker/1.23/kafka"}},
+			},
+			Broker: &eventingv1beta1.BrokerConfigs{Kafka: base.KafkaBrokerConfiguration{Enabled: true}},
+		},
+		resources: 0,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, _ := mf.ManifestFrom(mf.Slice{})
+			err := AppendTargetBrokers(context.TODO(), &manifest, &eventingv1beta1.KnativeEventing{Spec: tt.spec})
+			util.AssertEqual(t, err != nil, tt.err)
+			util.AssertEqual(t, len(manifest.Resources()), tt.resources)
+		})
+	}
+}
+
+func TestAppendTargetBrokersBundled(t *testing.T) {
+	koData := "../../../../cmd/operator/kodata"
+	os.Setenv(common.KoEnvKey, koData)
+	defer os.Unsetenv(common.KoEnvKey)
+
+	// The Kafka and RabbitMQ broker and the Kafka channel manifests are fetched for every minor, for
+	// which the sources are.
+	versions, err := os.ReadDir(filepath.Join(koData, "eventing-source"))
+	if err != nil {
+		t.Fatalf("ReadDir() = %v", err)
+	}
+	for _, version := range versions {
+		t.Run(version.Name(), func(t *testing.T) {
+			manifest, _ := mf.ManifestFrom(mf.Slice{})
+			err := AppendTargetBrokers(context.TODO(), &manifest, &eventingv1beta1.KnativeEventing{
+				Spec: eventingv1beta1.KnativeEventingSpec{
+					CommonSpec: base.CommonSpec{Version: version.Name()},
+					Broker: &eventingv1beta1.BrokerConfigs{
+						Kafka:    base.KafkaBrokerConfiguration{Enabled: true},
+						Rabbitmq: base.RabbitmqBrokerConfiguration{Enabled: true},
+					},
+					Channel: &eventingv1beta1.ChannelConfigs{Kafka: base.KafkaChannelConfiguration{Enabled: true}},
+				},
+			})
+			if err != nil {
+				// The manifests missing from the kodata directory are reported as such.
+				util.AssertEqual(t, errors.Is(err, os.ErrNotExist), true)
+				util.AssertEqual(t, strings.Contains(err.Error(), "not bundled with the operator"), true)
+				return
+			}
+			util.AssertEqual(t, len(manifest.Filter(mf.ByKind("Deployment")).Resources()) > 0, true)
+		})
+	}
+}
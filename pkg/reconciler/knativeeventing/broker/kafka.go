@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	kec "knative.dev/operator/pkg/reconciler/knativeeventing/common"
+)
+
+// The ConfigMaps of the Kafka broker and channel, configured by spec.broker.kafka and spec.channel.kafka.
+const (
+	kafkaChannelConfigMap          = "kafka-channel-config"
+	kafkaBrokerDataPlaneConfigMap  = "config-kafka-broker-data-plane"
+	kafkaChannelDataPlaneConfigMap = "config-kafka-channel-data-plane"
+)
+
+// Transformers returns the transformers, which apply the options of the enabled broker and channel
+// implementations to their manifests.
+func Transformers(ke *v1beta1.KnativeEventing) []mf.Transformer {
+	var transformers []mf.Transformer
+	if ke.Spec.KafkaBrokerEnabled() {
+		kafka := ke.Spec.Broker.Kafka
+		data := kafkaConfig(&kafka.KafkaConfiguration)
+		if kafka.NumPartitions != nil {
+			data["default.topic.partitions"] = strconv.Itoa(int(*kafka.NumPartitions))
+		}
+		if kafka.ReplicationFactor != nil {
+			data["default.topic.replication.factor"] = strconv.Itoa(int(*kafka.ReplicationFactor))
+		}
+		transformers = append(transformers,
+			kec.ConfigMapDataTransform(ke, kec.KafkaBrokerConfigMap, data),
+			dataPlaneTransform(ke, kafkaBrokerDataPlaneConfigMap, "config-kafka-broker", &kafka.KafkaConfiguration))
+	}
+	if ke.Spec.KafkaChannelEnabled() {
+		kafka := ke.Spec.Channel.Kafka
+		data := kafkaConfig(&kafka.KafkaConfiguration)
+		if kafka.AuthSecretName != "" {
+			data["auth.secret.ref.namespace"] = ke.GetNamespace()
+		}
+		transformers = append(transformers,
+			kec.ConfigMapDataTransform(ke, kafkaChannelConfigMap, data),
+			dataPlaneTransform(ke, kafkaChannelDataPlaneConfigMap, "config-kafka-channel", &kafka.KafkaConfiguration))
+	}
+	return transformers
+}
+
+// kafkaConfig returns the entries of the ConfigMap of a Kafka broker or channel, which refer to the
+// Kafka cluster.
+func kafkaConfig(kc *base.KafkaConfiguration) map[string]string {
+	data := map[string]string{}
+	if len(kc.BootstrapServers) > 0 {
+		data["bootstrap.servers"] = strings.Join(kc.BootstrapServers, ",")
+	}
+	if kc.AuthSecretName != "" {
+		data["auth.secret.ref.name"] = kc.AuthSecretName
+	}
+	return data
+}
+
+// dataPlaneTransform merges the producer and consumer properties into the <prefix>-producer.properties
+// and <prefix>-consumer.properties entries of the ConfigMap of the data plane, unless the entries
+// are set in spec.config.
+func dataPlaneTransform(ke *v1beta1.KnativeEventing, name, prefix string, kc *base.KafkaConfiguration) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != name {
+			return nil
+		}
+		for key, properties := range map[string]map[string]string{
+			prefix + "-producer.properties": kc.Producer,
+			prefix + "-consumer.properties": kc.Consumer,
+		} {
+			if len(properties) == 0 || kec.Configured(ke, name, key) {
+				continue
+			}
+			content, _, err := unstructured.NestedString(u.Object, "data", key)
+			if err != nil {
+				return err
+			}
+			if err := unstructured.SetNestedField(u.Object, mergeProperties(content, properties), "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// mergeProperties replaces the values of the properties in the content of a .properties file, and
+// appends the properties it does not set, sorted by key.
+func mergeProperties(content string, properties map[string]string) string {
+	set := map[string]bool{}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+	for i, line := range lines {
+		key, _, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if value, ok := properties[key]; ok && found && !strings.HasPrefix(key, "#") {
+			lines[i] = key + "=" + value
+			set[key] = true
+		}
+	}
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		if !set[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, key+"="+properties[key])
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestTransformers(t *testing.T) {
+	ke := &eventingv1beta1.KnativeEventing{
+		Spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Config: base.ConfigMapData{
+					"kafka-broker-config":             {"default.topic.partitions": "20"},
+					"config-kafka-channel-data-plane": {"config-kafka-channel-producer.properties": "acks=1\n"},
+				},
+			},
+			Broker: &eventingv1beta1.BrokerConfigs{
+				Kafka: base.KafkaBrokerConfiguration{
+					Enabled: true,
+					KafkaConfiguration: base.KafkaConfiguration{
+						BootstrapServers: []string{"kafka-0.kafka:9092", "kafka-1.kafka:9092"},
+						AuthSecretName:   "kafka-auth",
+						Producer:         map[string]string{"acks": "1", "compression.type": "zstd"},
+					},
+					NumPartitions:     ptr.To(int32(5)),
+					ReplicationFactor: ptr.To(int32(1)),
+				},
+			},
+			Channel: &eventingv1beta1.ChannelConfigs{
+				Kafka: base.KafkaChannelConfiguration{
+					Enabled: true,
+					KafkaConfiguration: base.KafkaConfiguration{
+						AuthSecretName: "kafka-auth",
+						Producer:       map[string]string{"acks": "0"},
+					},
+				},
+			},
+		},
+	}
+	ke.SetNamespace("knative-eventing")
+
+	manifest, err := mf.NewManifest("testdata/kodata/eventing-source/1.23/kafka/eventing-kafka-controller.yaml," +
+		"testdata/kodata/eventing-broker/1.23/kafka,testdata/kodata/eventing-channel/1.23/kafka")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transformers := Transformers(ke)
+	util.AssertEqual(t, len(transformers), 4)
+	manifest, err = manifest.Transform(transformers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := func(kind, name string) map[string]string {
+		resources := manifest.Filter(mf.ByKind(kind), mf.ByName(name)).Resources()
+		if len(resources) != 1 {
+			t.Fatalf("found %d resources %s", len(resources), name)
+		}
+		data, _, _ := unstructured.NestedStringMap(resources[0].Object, "data")
+		return data
+	}
+	// default.topic.partitions is set in spec.config, which takes precedence.
+	util.AssertDeepEqual(t, data("ConfigMap", "kafka-broker-config"), map[string]string{
+		"default.topic.partitions":         "10",
+		"default.topic.replication.factor": "1",
+		"bootstrap.servers":                "kafka-0.kafka:9092,kafka-1.kafka:9092",
+		"auth.secret.ref.name":             "kafka-auth",
+	})
+	util.AssertDeepEqual(t, data("ConfigMap", "kafka-channel-config"), map[string]string{
+		"bootstrap.servers":         "my-cluster-kafka-bootstrap.kafka:9092",
+		"auth.secret.ref.name":      "kafka-auth",
+		"auth.secret.ref.namespace": "knative-eventing",
+	})
+	util.AssertEqual(t, data("ConfigMap", "config-kafka-broker-data-plane")["config-kafka-broker-producer.properties"],
+		"key.serializer=org.apache.kafka.common.serialization.StringSerializer\nacks=1\nlinger.ms=0\ncompression.type=zstd\n")
+	// The properties of the channel producer are set in spec.config.
+	util.AssertEqual(t, data("ConfigMap", "config-kafka-channel-data-plane")["config-kafka-channel-producer.properties"],
+		"key.serializer=org.apache.kafka.common.serialization.StringSerializer\nacks=all\nlinger.ms=0\n")
+}
+
+func TestTransformersWithoutBroker(t *testing.T) {
+	util.AssertEqual(t, len(Transformers(&eventingv1beta1.KnativeEventing{})), 0)
+}
+
+func TestMergeProperties(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		properties map[string]string
+		expected   string
+	}{{
+		name:       "empty",
+		properties: map[string]string{"b": "2", "a": "1"},
+		expected:   "a=1\nb=2\n",
+	}, {
+		name:       "replace and append",
+		content:    "# acks=all\nacks = all\nretries=3\n",
+		properties: map[string]string{"acks": "1", "linger.ms": "5"},
+		expected:   "# acks=all\nacks=1\nretries=3\nlinger.ms=5\n",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			util.AssertEqual(t, mergeProperties(tt.content, tt.properties), tt.expected)
+		})
+	}
+}
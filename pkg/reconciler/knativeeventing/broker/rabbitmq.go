@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	kec "knative.dev/operator/pkg/reconciler/knativeeventing/common"
+)
+
+var (
+	// rabbitmqClusterOperatorCRDs are the CRDs of the RabbitMQ Cluster Operator.
+	rabbitmqClusterOperatorCRDs = []string{"rabbitmqclusters.rabbitmq.com"}
+	// rabbitmqTopologyOperatorCRDs are the CRDs of the RabbitMQ Messaging Topology Operator, which the
+	// RabbitMQ broker creates its exchanges, queues and bindings with.
+	rabbitmqTopologyOperatorCRDs = []string{"exchanges.rabbitmq.com", "queues.rabbitmq.com", "bindings.rabbitmq.com"}
+)
+
+// rabbitmqBrokerConfig renders the RabbitmqBrokerConfig of the brokers of the RabbitMQBroker class,
+// which do not refer to a config of their own. It returns nil if no cluster is configured.
+func rabbitmqBrokerConfig(ke *v1beta1.KnativeEventing) *unstructured.Unstructured {
+	rabbitmq := ke.Spec.Broker.Rabbitmq
+	if rabbitmq.ClusterRef == nil {
+		return nil
+	}
+	ref := map[string]interface{}{}
+	if rabbitmq.ClusterRef.Name != "" {
+		ref["name"] = rabbitmq.ClusterRef.Name
+	}
+	if rabbitmq.ClusterRef.Namespace != "" {
+		ref["namespace"] = rabbitmq.ClusterRef.Namespace
+	}
+	if rabbitmq.ClusterRef.ConnectionSecret != "" {
+		ref["connectionSecret"] = map[string]interface{}{"name": rabbitmq.ClusterRef.ConnectionSecret}
+	}
+	spec := map[string]interface{}{"rabbitmqClusterReference": ref}
+	if rabbitmq.QueueType != "" {
+		spec["queueType"] = rabbitmq.QueueType
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetAPIVersion(kec.RabbitmqBrokerConfigAPIVersion)
+	u.SetKind(kec.RabbitmqBrokerConfigKind)
+	u.SetName(kec.RabbitmqBrokerConfigName)
+	u.SetNamespace(ke.GetNamespace())
+	return u
+}
+
+// appendRabbitmqBrokerConfig appends the RabbitmqBrokerConfig rendered from the spec to the manifest.
+func appendRabbitmqBrokerConfig(manifest *mf.Manifest, ke *v1beta1.KnativeEventing) error {
+	if !ke.Spec.RabbitmqBrokerEnabled() {
+		return nil
+	}
+	u := rabbitmqBrokerConfig(ke)
+	if u == nil {
+		return nil
+	}
+	m, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*u}))
+	if err != nil {
+		return err
+	}
+	*manifest = manifest.Append(m)
+	return nil
+}
+
+// CheckDependencies verifies that the operators the enabled broker implementations depend on are
+// installed in the target cluster, and marks the dependencies of the KnativeEventing missing if
+// they are not.
+func CheckDependencies(_ context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	ke, ok := instance.(*v1beta1.KnativeEventing)
+	if !ok {
+		return nil
+	}
+	if ke.Spec.RabbitmqBrokerEnabled() {
+		for _, dep := range []struct {
+			operator string
+			crds     []string
+		}{
+			{"RabbitMQ Cluster Operator", rabbitmqClusterOperatorCRDs},
+			{"RabbitMQ Messaging Topology Operator", rabbitmqTopologyOperatorCRDs},
+		} {
+			if err := common.CheckCRDs(manifest, dep.crds...); err != nil {
+				msg := fmt.Sprintf("the RabbitMQ broker requires the %s: %v", dep.operator, err)
+				instance.GetStatus().MarkDependencyMissing(msg)
+				return errors.New(msg)
+			}
+		}
+	}
+	instance.GetStatus().MarkDependenciesInstalled()
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestRabbitmqBrokerConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		rabbitmq base.RabbitmqBrokerConfiguration
+		expected map[string]interface{}
+	}{{
+		name:     "no cluster reference",
+		rabbitmq: base.RabbitmqBrokerConfiguration{Enabled: true},
+	}, {
+		name: "rabbitmq cluster",
+		rabbitmq: base.RabbitmqBrokerConfiguration{
+			Enabled:    true,
+			ClusterRef: &base.RabbitmqClusterReference{Name: "rabbitmq", Namespace: "rabbitmq-system"},
+			QueueType:  "quorum",
+		},
+		expected: map[string]interface{}{
+			"rabbitmqClusterReference": map[string]interface{}{"name": "rabbitmq", "namespace": "rabbitmq-system"},
+			"queueType":                "quorum",
+		},
+	}, {
+		name: "external cluster",
+		rabbitmq: base.RabbitmqBrokerConfiguration{
+			Enabled:    true,
+			ClusterRef: &base.RabbitmqClusterReference{ConnectionSecret: "rabbitmq-credentials"},
+		},
+		expected: map[string]interface{}{
+			"rabbitmqClusterReference": map[string]interface{}{
+				"connectionSecret": map[string]interface{}{"name": "rabbitmq-credentials"},
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ke := &eventingv1beta1.KnativeEventing{
+				Spec: eventingv1beta1.KnativeEventingSpec{
+					Broker: &eventingv1beta1.BrokerConfigs{Rabbitmq: tt.rabbitmq},
+				},
+			}
+			ke.SetNamespace("knative-eventing")
+			u := rabbitmqBrokerConfig(ke)
+			if tt.expected == nil {
+				util.AssertEqual(t, u == nil, true)
+				return
+			}
+			util.AssertEqual(t, u.GetKind(), "RabbitmqBrokerConfig")
+			util.AssertEqual(t, u.GetName(), "default-rabbitmq-broker-config")
+			util.AssertEqual(t, u.GetNamespace(), "knative-eventing")
+			util.AssertDeepEqual(t, u.Object["spec"], tt.expected)
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	client := fake.New()
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ke := &eventingv1beta1.KnativeEventing{}
+	ke.Status.InitializeConditions()
+	if err := CheckDependencies(context.TODO(), &manifest, ke); err != nil {
+		t.Fatalf("CheckDependencies() = %v", err)
+	}
+	util.AssertEqual(t, ke.Status.GetCondition(base.DependenciesInstalled).IsTrue(), true)
+
+	ke.Spec.Broker = &eventingv1beta1.BrokerConfigs{Rabbitmq: base.RabbitmqBrokerConfiguration{Enabled: true}}
+	err = CheckDependencies(context.TODO(), &manifest, ke)
+	util.AssertEqual(t, err.Error(), "the RabbitMQ broker requires the RabbitMQ Cluster Operator: "+
+		"the CustomResourceDefinition rabbitmqclusters.rabbitmq.com is not installed")
+	util.AssertEqual(t, ke.Status.GetCondition(base.DependenciesInstalled).IsFalse(), true)
+
+	createCRD(t, client, "rabbitmqclusters.rabbitmq.com")
+	err = CheckDependencies(context.TODO(), &manifest, ke)
+	util.AssertEqual(t, err.Error(), "the RabbitMQ broker requires the RabbitMQ Messaging Topology Operator: "+
+		"the CustomResourceDefinition exchanges.rabbitmq.com is not installed")
+
+	for _, name := range rabbitmqTopologyOperatorCRDs {
+		createCRD(t, client, name)
+	}
+	if err := CheckDependencies(context.TODO(), &manifest, ke); err != nil {
+		t.Fatalf("CheckDependencies() = %v", err)
+	}
+	util.AssertEqual(t, ke.Status.GetCondition(base.DependenciesInstalled).IsTrue(), true)
+}
+
+func createCRD(t *testing.T, client fake.Client, name string) {
+	t.Helper()
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+	if err := client.Create(crd); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+// Configured returns true if spec.config sets the key of the ConfigMap, with or without the
+// optional "config-" prefix of its name.
+func Configured(instance *eventingv1beta1.KnativeEventing, name, key string) bool {
+	name = strings.TrimPrefix(name, "config-")
+	for _, n := range []string{name, "config-" + name} {
+		if _, ok := instance.Spec.GetConfig()[n][key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigMapDataTransform writes the entries to the data of the ConfigMap, unless they are set in
+// spec.config.
+func ConfigMapDataTransform(instance *eventingv1beta1.KnativeEventing, name string, data map[string]string) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != name {
+			return nil
+		}
+		for key, value := range data {
+			if Configured(instance, name, key) {
+				continue
+			}
+			if err := unstructured.SetNestedField(u.Object, value, "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
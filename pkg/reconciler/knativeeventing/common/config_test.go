@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestConfigMapDataTransform(t *testing.T) {
+	instance := &v1beta1.KnativeEventing{
+		Spec: v1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Config: base.ConfigMapData{"config-redis": {"numConsumers": "10"}},
+			},
+		},
+	}
+	data := map[string]string{"numConsumers": "100", "other": "value"}
+
+	u := util.MakeUnstructured(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config-redis"},
+	})
+	if err := ConfigMapDataTransform(instance, "config-redis", data)(&u); err != nil {
+		t.Fatal(err)
+	}
+	// numConsumers is set in spec.config, which takes precedence.
+	result, _, _ := unstructured.NestedStringMap(u.Object, "data")
+	util.AssertDeepEqual(t, result, map[string]string{"other": "value"})
+
+	other := util.MakeUnstructured(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config-other"},
+	})
+	if err := ConfigMapDataTransform(instance, "config-redis", data)(&other); err != nil {
+		t.Fatal(err)
+	}
+	_, found, _ := unstructured.NestedStringMap(other.Object, "data")
+	util.AssertEqual(t, found, false)
+}
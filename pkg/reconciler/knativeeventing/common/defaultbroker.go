@@ -27,11 +27,22 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/scheme"
 	eventingconfig "knative.dev/eventing/pkg/apis/config"
-	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/operator/pkg/apis/operator/base"
 	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"sigs.k8s.io/yaml"
 )
 
+const (
+	// KafkaBrokerConfigMap is the ConfigMap referring to the Kafka cluster of the Kafka brokers.
+	KafkaBrokerConfigMap = "kafka-broker-config"
+
+	// The RabbitmqBrokerConfig rendered from spec.broker.rabbitmq.clusterRef.
+	RabbitmqBrokerConfigAPIVersion = "eventing.knative.dev/v1alpha1"
+	RabbitmqBrokerConfigKind       = "RabbitmqBrokerConfig"
+	RabbitmqBrokerConfigName       = "default-rabbitmq-broker-config"
+)
+
 // DefaultBrokerConfigMapTransform updates the default broker configMap with the value defined in the spec
 func DefaultBrokerConfigMapTransform(instance *eventingv1beta1.KnativeEventing, log *zap.SugaredLogger) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
@@ -65,11 +76,42 @@ func DefaultBrokerConfigMapTransform(instance *eventingv1beta1.KnativeEventing,
 				return err
 			}
 
+			rabbitmq := instance.Spec.RabbitmqBrokerEnabled() && instance.Spec.Broker.Rabbitmq.ClusterRef != nil
 			defaultBrokerClass := instance.Spec.DefaultBrokerClass
 			if defaultBrokerClass == "" {
-				defaultBrokerClass = eventing.MTChannelBrokerClassValue
+				switch {
+				case instance.Spec.KafkaBrokerEnabled():
+					defaultBrokerClass = eventingv1beta1.KafkaBrokerClass
+				case rabbitmq:
+					defaultBrokerClass = eventingv1beta1.RabbitmqBrokerClass
+				default:
+					defaultBrokerClass = eventingv1beta1.MTChannelBrokerClass
+				}
 			}
 			defaults.ClusterDefaultConfig.DefaultBrokerClass = defaultBrokerClass
+			if instance.Spec.Broker != nil && instance.Spec.Broker.Defaults != nil &&
+				!Configured(instance, eventingconfig.DefaultsConfigName, eventingconfig.BrokerDefaultsKey) {
+				if err := setBrokerDefaults(defaults, instance.Spec.Broker.Defaults); err != nil {
+					log.Error(err, "Error converting the Broker defaults of the spec", "defaults", instance.Spec.Broker.Defaults)
+					return err
+				}
+			}
+			if instance.Spec.KafkaBrokerEnabled() {
+				setBrokerClassConfig(defaults.ClusterDefaultConfig, eventingv1beta1.KafkaBrokerClass, &duckv1.KReference{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       KafkaBrokerConfigMap,
+					Namespace:  instance.GetNamespace(),
+				})
+			}
+			if rabbitmq {
+				setBrokerClassConfig(defaults.ClusterDefaultConfig, eventingv1beta1.RabbitmqBrokerClass, &duckv1.KReference{
+					APIVersion: RabbitmqBrokerConfigAPIVersion,
+					Kind:       RabbitmqBrokerConfigKind,
+					Name:       RabbitmqBrokerConfigName,
+					Namespace:  instance.GetNamespace(),
+				})
+			}
 
 			err = writeDefaultsToConfigMap(defaults, configMap, log)
 			if err != nil {
@@ -90,6 +132,49 @@ func DefaultBrokerConfigMapTransform(instance *eventingv1beta1.KnativeEventing,
 	}
 }
 
+// setBrokerDefaults sets the delivery of the cluster default, and the broker classes of the
+// namespaces.
+func setBrokerDefaults(defaults *eventingconfig.Defaults, d *base.BrokerDefaults) error {
+	delivery, err := d.Delivery.DeliverySpec()
+	if err != nil {
+		return err
+	}
+	if delivery != nil {
+		if defaults.ClusterDefaultConfig.BrokerConfig == nil {
+			defaults.ClusterDefaultConfig.BrokerConfig = &eventingconfig.BrokerConfig{}
+		}
+		defaults.ClusterDefaultConfig.BrokerConfig.Delivery = delivery
+	}
+	for namespace, class := range d.NamespaceBrokerClasses {
+		if defaults.NamespaceDefaultsConfig == nil {
+			defaults.NamespaceDefaultsConfig = map[string]*eventingconfig.DefaultConfig{}
+		}
+		config := defaults.NamespaceDefaultsConfig[namespace]
+		if config == nil {
+			config = &eventingconfig.DefaultConfig{}
+			defaults.NamespaceDefaultsConfig[namespace] = config
+		}
+		config.DefaultBrokerClass = class
+	}
+	return nil
+}
+
+// setBrokerClassConfig makes the brokers of the class refer to the config, with the delivery of the
+// cluster default, unless the class is configured already.
+func setBrokerClassConfig(config *eventingconfig.DefaultConfig, class string, ref *duckv1.KReference) {
+	if config.BrokerClasses[class] != nil {
+		return
+	}
+	if config.BrokerClasses == nil {
+		config.BrokerClasses = map[string]*eventingconfig.BrokerConfig{}
+	}
+	brokerConfig := &eventingconfig.BrokerConfig{KReference: ref}
+	if config.BrokerConfig != nil {
+		brokerConfig.Delivery = config.BrokerConfig.Delivery
+	}
+	config.BrokerClasses[class] = brokerConfig
+}
+
 func writeDefaultsToConfigMap(defaults *eventingconfig.Defaults, configMap *corev1.ConfigMap, log *zap.SugaredLogger) error {
 	jsonBytes, err := json.Marshal(defaults)
 	if err != nil {
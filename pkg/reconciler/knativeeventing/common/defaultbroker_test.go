@@ -22,8 +22,11 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	eventingconfig "knative.dev/eventing/pkg/apis/config"
 	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/yaml"
@@ -205,6 +208,188 @@ func TestDefaultBrokerTransform(t *testing.T) {
 	}
 }
 
+func TestDefaultBrokerTransformKafka(t *testing.T) {
+	configMap := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config-br-defaults"},
+		Data: map[string]string{
+			"default-br-config": "clusterDefault:\n" +
+				"  brokerClass: MTChannelBasedBroker\n" +
+				"  apiVersion: v1\n" +
+				"  kind: ConfigMap\n" +
+				"  name: config-br-default-channel\n" +
+				"  namespace: knative-eventing\n" +
+				"  delivery:\n" +
+				"    retry: 10\n",
+		},
+	}
+	instance := &v1beta1.KnativeEventing{
+		Spec: v1beta1.KnativeEventingSpec{
+			Broker: &v1beta1.BrokerConfigs{Kafka: base.KafkaBrokerConfiguration{Enabled: true}},
+		},
+	}
+	instance.SetNamespace("knative-eventing")
+
+	u := util.MakeUnstructured(t, &configMap)
+	if err := DefaultBrokerConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	var result corev1.ConfigMap
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, result.Data["default-br-config"], `clusterDefault:
+  apiVersion: v1
+  brokerClass: Kafka
+  brokerClasses:
+    Kafka:
+      apiVersion: v1
+      delivery:
+        retry: 10
+      kind: ConfigMap
+      name: kafka-broker-config
+      namespace: knative-eventing
+  delivery:
+    retry: 10
+  kind: ConfigMap
+  name: config-br-default-channel
+  namespace: knative-eventing
+`)
+}
+
+func TestDefaultBrokerTransformRabbitmq(t *testing.T) {
+	configMap := makeConfigMap(t, "config-br-defaults", base.ConfigMapData{
+		"clusterDefault": {
+			"brokerClass": "MTChannelBasedBroker",
+			"apiVersion":  "v1",
+			"kind":        "ConfigMap",
+			"name":        "config-br-default-channel",
+			"namespace":   "knative-eventing",
+		},
+	})
+	instance := &v1beta1.KnativeEventing{
+		Spec: v1beta1.KnativeEventingSpec{
+			Broker: &v1beta1.BrokerConfigs{
+				Rabbitmq: base.RabbitmqBrokerConfiguration{
+					Enabled:    true,
+					ClusterRef: &base.RabbitmqClusterReference{Name: "rabbitmq"},
+				},
+			},
+		},
+	}
+	instance.SetNamespace("knative-eventing")
+
+	u := util.MakeUnstructured(t, &configMap)
+	if err := DefaultBrokerConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	var result corev1.ConfigMap
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	defaults, err := eventingconfig.NewDefaultsConfigFromConfigMap(&result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, defaults.ClusterDefaultConfig.DefaultBrokerClass, "RabbitMQBroker")
+	util.AssertDeepEqual(t, defaults.ClusterDefaultConfig.BrokerClasses["RabbitMQBroker"].KReference, &duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1alpha1",
+		Kind:       "RabbitmqBrokerConfig",
+		Name:       "default-rabbitmq-broker-config",
+		Namespace:  "knative-eventing",
+	})
+}
+
+func TestDefaultBrokerTransformDefaults(t *testing.T) {
+	configMap := makeConfigMap(t, "config-br-defaults", base.ConfigMapData{
+		"clusterDefault": {
+			"brokerClass": "MTChannelBasedBroker",
+			"apiVersion":  "v1",
+			"kind":        "ConfigMap",
+			"name":        "config-br-default-channel",
+			"namespace":   "knative-eventing",
+		},
+	})
+	instance := &v1beta1.KnativeEventing{
+		Spec: v1beta1.KnativeEventingSpec{
+			Broker: &v1beta1.BrokerConfigs{
+				Kafka: base.KafkaBrokerConfiguration{Enabled: true},
+				Defaults: &base.BrokerDefaults{
+					Delivery: &base.BrokerDelivery{
+						Retry:         ptr.To(int32(5)),
+						BackoffPolicy: "exponential",
+						BackoffDelay:  "PT0.5S",
+						DeadLetterSink: &base.DeadLetterSink{
+							Ref: &base.DeadLetterSinkReference{APIVersion: "v1", Kind: "Service", Name: "dead-letters"},
+						},
+					},
+					NamespaceBrokerClasses: map[string]string{"in-memory": "MTChannelBasedBroker"},
+				},
+			},
+		},
+	}
+	instance.SetNamespace("knative-eventing")
+
+	u := util.MakeUnstructured(t, &configMap)
+	if err := DefaultBrokerConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	var result corev1.ConfigMap
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, result.Data["default-br-config"], `clusterDefault:
+  apiVersion: v1
+  brokerClass: Kafka
+  brokerClasses:
+    Kafka:
+      apiVersion: v1
+      delivery:
+        backoffDelay: PT0.5S
+        backoffPolicy: exponential
+        deadLetterSink:
+          ref:
+            apiVersion: v1
+            kind: Service
+            name: dead-letters
+        retry: 5
+      kind: ConfigMap
+      name: kafka-broker-config
+      namespace: knative-eventing
+  delivery:
+    backoffDelay: PT0.5S
+    backoffPolicy: exponential
+    deadLetterSink:
+      ref:
+        apiVersion: v1
+        kind: Service
+        name: dead-letters
+    retry: 5
+  kind: ConfigMap
+  name: config-br-default-channel
+  namespace: knative-eventing
+namespaceDefaults:
+  in-memory:
+    brokerClass: MTChannelBasedBroker
+`)
+
+	// The defaults are ignored, when spec.config sets default-br-config.
+	instance.Spec.Config = base.ConfigMapData{"config-br-defaults": {"default-br-config": ""}}
+	u = util.MakeUnstructured(t, &configMap)
+	if err := DefaultBrokerConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	defaults, err := eventingconfig.NewDefaultsConfigFromConfigMap(&result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(defaults.NamespaceDefaultsConfig), 0)
+	util.AssertEqual(t, defaults.ClusterDefaultConfig.BrokerClasses["Kafka"].Delivery == nil, true)
+}
+
 func makeConfigMap(t *testing.T, name string, data base.ConfigMapData) corev1.ConfigMap {
 	out, err := yaml.Marshal(&data)
 	if err != nil {
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	mf "github.com/manifestival/manifestival"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	messagingconfig "knative.dev/eventing/pkg/apis/messaging/config"
+	"knative.dev/operator/pkg/apis/operator/base"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultChannelConfigMapTransform sets the default channel implementations of the default channel
+// configMap to the ones defined in the spec, unless the configMap data in the spec sets them
+func DefaultChannelConfigMapTransform(instance *eventingv1beta1.KnativeEventing, log *zap.SugaredLogger) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != messagingconfig.ChannelDefaultsConfigName {
+			return nil
+		}
+		if instance.Spec.Channel == nil || instance.Spec.Channel.Defaults == nil ||
+			Configured(instance, messagingconfig.ChannelDefaultsConfigName, messagingconfig.ChannelDefaulterKey) {
+			return nil
+		}
+		var configMap = &corev1.ConfigMap{}
+		if err := scheme.Scheme.Convert(u, configMap, nil); err != nil {
+			log.Error(err, "Error converting Unstructured to ConfigMap", "unstructured", u, "configMap", configMap)
+			return err
+		}
+
+		defaults := &messagingconfig.ChannelDefaults{}
+		if configMap.Data[messagingconfig.ChannelDefaulterKey] != "" {
+			var err error
+			defaults, err = messagingconfig.NewChannelDefaultsConfigFromConfigMap(configMap)
+			if err != nil {
+				log.Error(err, "Error parsing default channel ConfigMap", "unstructured", u, "configMap", configMap)
+				return err
+			}
+		}
+
+		channels := instance.Spec.InstalledChannels()
+		template := func(t base.ChannelTemplate) *messagingconfig.ChannelTemplateSpec {
+			return &messagingconfig.ChannelTemplateSpec{
+				TypeMeta: metav1.TypeMeta{APIVersion: channels[t.Kind], Kind: t.Kind},
+				Spec:     t.Spec,
+			}
+		}
+		d := instance.Spec.Channel.Defaults
+		if d.Cluster != nil {
+			defaults.ClusterDefault = template(*d.Cluster)
+		}
+		for namespace, t := range d.Namespaces {
+			if defaults.NamespaceDefaults == nil {
+				defaults.NamespaceDefaults = map[string]*messagingconfig.ChannelTemplateSpec{}
+			}
+			defaults.NamespaceDefaults[namespace] = template(t)
+		}
+
+		yamlBytes, err := yaml.Marshal(defaults)
+		if err != nil {
+			log.Error("Channel defaults could not be converted to YAML", "defaults", defaults)
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[messagingconfig.ChannelDefaulterKey] = string(yamlBytes)
+
+		if err := scheme.Scheme.Convert(configMap, u, nil); err != nil {
+			return err
+		}
+		// The zero-value timestamp defaulted by the conversion causes
+		// superfluous updates
+		u.SetCreationTimestamp(metav1.Time{})
+		log.Debugw("Finished updating Channel defaults configMap", "name", u.GetName(), "unstructured", u.Object)
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestDefaultChannelTransform(t *testing.T) {
+	configMap := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "default-ch-webhook"},
+		Data: map[string]string{
+			"default-ch-config": "clusterDefault:\n" +
+				"  apiVersion: messaging.knative.dev/v1\n" +
+				"  kind: InMemoryChannel\n" +
+				"namespaceDefaults:\n" +
+				"  some-namespace:\n" +
+				"    apiVersion: messaging.knative.dev/v1\n" +
+				"    kind: InMemoryChannel\n",
+		},
+	}
+	instance := &v1beta1.KnativeEventing{
+		Spec: v1beta1.KnativeEventingSpec{
+			Channel: &v1beta1.ChannelConfigs{
+				Kafka: base.KafkaChannelConfiguration{Enabled: true},
+				Defaults: &base.ChannelDefaults{
+					Cluster: &base.ChannelTemplate{
+						Kind: "KafkaChannel",
+						Spec: &runtime.RawExtension{Raw: []byte(`{"numPartitions":3,"replicationFactor":1}`)},
+					},
+					Namespaces: map[string]base.ChannelTemplate{"in-memory": {Kind: "InMemoryChannel"}},
+				},
+			},
+		},
+	}
+
+	u := util.MakeUnstructured(t, &configMap)
+	if err := DefaultChannelConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	var result corev1.ConfigMap
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, result.Data["default-ch-config"], `clusterDefault:
+  apiVersion: messaging.knative.dev/v1beta1
+  kind: KafkaChannel
+  spec:
+    numPartitions: 3
+    replicationFactor: 1
+namespaceDefaults:
+  in-memory:
+    apiVersion: messaging.knative.dev/v1
+    kind: InMemoryChannel
+  some-namespace:
+    apiVersion: messaging.knative.dev/v1
+    kind: InMemoryChannel
+`)
+
+	// The defaults are ignored, when spec.config sets default-ch-config.
+	instance.Spec.Config = base.ConfigMapData{"default-ch-webhook": {"default-ch-config": ""}}
+	u = util.MakeUnstructured(t, &configMap)
+	if err := DefaultChannelConfigMapTransform(instance, log)(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheme.Scheme.Convert(&u, &result, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, result.Data["default-ch-config"], configMap.Data["default-ch-config"])
+}
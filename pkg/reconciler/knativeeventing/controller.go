@@ -78,6 +78,7 @@ func NewExtendedController(generator common.ExtensionGenerator) injection.Contro
 
 		c := &Reconciler{
 			kubeClientSet:     kubeClient,
+			dynamicClients:    common.NewDynamicClients(restConfig),
 			operatorClientSet: operatorclient.Get(ctx),
 			manifest:          manifest,
 			clusterProvider:   clusterProvider,
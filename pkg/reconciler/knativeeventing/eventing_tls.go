@@ -28,6 +28,7 @@ import (
 
 	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
 )
 
 var (
@@ -43,13 +44,30 @@ func (r *Reconciler) handleTLSResources(ctx context.Context, manifests *mf.Manif
 
 	// Delete TLS resources (if present)
 	toBeDeleted := manifests.Filter(TLSResourcesPred)
+	existing := 0
+	for _, u := range toBeDeleted.Resources() {
+		if _, err := toBeDeleted.Client.Get(&u); err == nil {
+			existing++
+		}
+	}
 	if err := toBeDeleted.Delete(mf.IgnoreNotFound(true)); err != nil && !meta.IsNoMatchError(err) {
 		return fmt.Errorf("failed to delete TLS resources: %v", err)
 	}
+	if existing > 0 {
+		common.RecordEvent(ctx, comp, corev1.EventTypeNormal, common.ReasonTLSResourcesRemoved,
+			"Removed %d TLS resources, as transport encryption is disabled", existing)
+	}
+
+	return filterTLSResources(ctx, manifests, comp)
+}
 
+// filterTLSResources removes the TLS resources from the manifest, unless transport encryption is enabled.
+func filterTLSResources(_ context.Context, manifests *mf.Manifest, comp base.KComponent) error {
+	if isTLSEnabled(comp.(*v1beta1.KnativeEventing)) {
+		return nil
+	}
 	// Filter out TLS resources from the final list of manifests
 	*manifests = manifests.Filter(mf.Not(TLSResourcesPred))
-
 	return nil
 }
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
@@ -35,6 +36,7 @@ import (
 	knereconciler "knative.dev/operator/pkg/client/injection/reconciler/operator/v1beta1/knativeeventing"
 	operatorv1beta1lister "knative.dev/operator/pkg/client/listers/operator/v1beta1"
 	"knative.dev/operator/pkg/reconciler/common"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/broker"
 	kec "knative.dev/operator/pkg/reconciler/knativeeventing/common"
 	"knative.dev/operator/pkg/reconciler/knativeeventing/source"
 	"knative.dev/operator/pkg/reconciler/manifests"
@@ -44,6 +46,9 @@ import (
 type Reconciler struct {
 	// kubeClientSet allows us to talk to the k8s for core APIs
 	kubeClientSet kubernetes.Interface
+	// dynamicClients are used to apply the manifests server-side, and to plan the changes of the
+	// components in dry-run mode
+	dynamicClients *common.DynamicClients
 	// operatorClientSet allows us to talk to the k8s for operator APIs
 	operatorClientSet clientset.Interface
 	// manifest is empty, but with a valid client and logger. all
@@ -64,7 +69,6 @@ var _ knereconciler.Finalizer = (*Reconciler)(nil)
 // FinalizeKind removes all resources after deletion of a KnativeEventing.
 func (r *Reconciler) FinalizeKind(ctx context.Context, original *v1beta1.KnativeEventing) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
-	common.ClearCache()
 
 	kes, err := r.eventingLister.List(labels.Everything())
 	if err != nil {
@@ -117,6 +121,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, ke *v1beta1.KnativeEvent
 
 	logger.Infow("Reconciling KnativeEventing", "status", ke.Status)
 
+	restore, err := common.ResolveVersion(ke, &ke.Spec.Version)
+	defer restore()
+	if err != nil {
+		ke.Status.MarkVersionMigrationNotEligible(err.Error())
+		return nil
+	}
+
 	if err := common.IsVersionValidMigrationEligible(ke); err != nil {
 		ke.Status.MarkVersionMigrationNotEligible(err.Error())
 		return nil
@@ -129,47 +140,115 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, ke *v1beta1.KnativeEvent
 
 	var state common.ReconcileState
 
-	stages := common.Stages{
-		common.ResolveTargetCluster(r.clusterProvider, &state),
-		common.AppendTarget,
-		source.AppendTargetSources,
-		common.AppendAdditionalManifests,
-		r.appendExtensionManifests,
-		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
-			return r.transform(ctx, manifest, comp, state.AnchorOwner)
-		},
+	stages := common.Stages{common.ResolveTargetCluster(r.clusterProvider, &state)}
+	stages = append(stages, r.renderStages(&state)...)
+	if common.IsDryRun(ke) {
+		stages = append(stages,
+			filterTLSResources,
+			common.RecordPlan(r.kubeClientSet, r.dynamicClients, &state, r.installed),
+		)
+		manifest := r.manifest.Append()
+		if _, err := stages.Execute(ctx, &manifest, ke); err != nil {
+			return err
+		}
+		return pkgreconciler.NewEvent(corev1.EventTypeNormal, "DryRun", "Planned changes: %s", state.Plan.Summary())
+	}
+	stages = append(stages, common.Stages{
 		r.handleTLSResources,
-		manifests.Install,
+		common.RecordRollbackTarget, // recording the installed release before the manifest paths are overwritten
+		broker.CheckDependencies,
+		common.DetectDrift(&state),
+		common.ServerSideApply(r.dynamicClients, &state, common.ExcludeDrifted(&state, manifests.Install)),
 		manifests.SetManifestPaths, // setting path right after applying manifests to populate paths
 		common.CheckDeployments,
+		common.CheckJobs,
 		common.MarkStatusSuccess,
+		common.RecordHistory,
 		common.DeleteObsoleteResources(ctx, ke, r.installed),
-	}
+	}...)
 	manifest := r.manifest.Append()
 	result, err := stages.Execute(ctx, &manifest, ke)
 	if err != nil {
 		return err
 	}
-	if result.DeploymentsNotReady && state.IsRemote() {
-		return controller.NewRequeueAfter(common.RemoteDeploymentsPollIntervalValue())
+	if !result.DeploymentsNotReady {
+		return nil
+	}
+	exceeded, left := common.ProgressDeadlineExceeded(ke)
+	if exceeded {
+		return r.rollback(ctx, ke, &manifest, &state)
+	}
+	if state.IsRemote() && (left == 0 || left > common.RemoteDeploymentsPollIntervalValue()) {
+		left = common.RemoteDeploymentsPollIntervalValue()
+	}
+	if left > 0 {
+		// Check the deployments again when the progress deadline of the upgrade is reached, at the latest.
+		return controller.NewRequeueAfter(left)
 	}
 	return nil
 }
 
+// rollback reinstalls the release recorded before the upgrade, and deletes the resources, which only the
+// release that failed to become ready contains.
+func (r *Reconciler) rollback(ctx context.Context, ke *v1beta1.KnativeEventing, manifest *mf.Manifest, state *common.ReconcileState) error {
+	deployments, err := common.NotReadyDeployments(manifest)
+	if err != nil {
+		return err
+	}
+	failedVersion := common.TargetVersion(ke)
+	target := ke.Spec.Version
+	ke.Spec.Version = ke.Status.GetRollback().Version
+	defer func() { ke.Spec.Version = target }()
+
+	stages := common.Stages{
+		common.AppendRollbackManifests,
+		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+			return r.transform(ctx, manifest, comp, state.AnchorOwner)
+		},
+		r.handleTLSResources,
+		common.ServerSideApply(r.dynamicClients, state, manifests.Install),
+		// The status still points to the manifests of the failed release, so its resources are obsolete.
+		common.DeleteObsoleteResources(ctx, ke, r.installed),
+		common.MarkRolledBack(failedVersion, deployments),
+	}
+	// An empty manifest using the client of the target cluster
+	previous := manifest.Filter(mf.Any())
+	_, err = stages.Execute(ctx, &previous, ke)
+	return err
+}
+
+// renderStages returns the stages, which build the fully transformed manifest of the KnativeEventing
+// without applying it.
+func (r *Reconciler) renderStages(state *common.ReconcileState) common.Stages {
+	return common.Stages{
+		common.AppendTarget,
+		source.AppendTargetSources,
+		broker.AppendTargetBrokers,
+		common.AppendAdditionalManifests,
+		r.appendExtensionManifests,
+		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+			return r.transform(ctx, manifest, comp, state.AnchorOwner)
+		},
+	}
+}
+
 // transform mutates the passed manifest to one with common, component
 // and platform transformations applied
 func (r *Reconciler) transform(ctx context.Context, manifest *mf.Manifest, comp base.KComponent, anchorOwner mf.Owner) error {
 	logger := logging.FromContext(ctx)
 	instance := comp.(*v1beta1.KnativeEventing)
-	extra := make([]mf.Transformer, 0, 5)
+	extra := make([]mf.Transformer, 0, 6)
 	extra = append(extra,
 		common.InjectOwner(instance, anchorOwner),
 		kec.DefaultBrokerConfigMapTransform(instance, logger),
+		kec.DefaultChannelConfigMapTransform(instance, logger),
 		kec.SinkBindingSelectionModeTransform(instance, logger),
 		kec.ReplicasEnvVarsTransform(manifest.Client),
 		// Ensure all resources have the selector applied so that the controller re-queues applied resources when they change.
 		common.InjectLabel(SelectorKey, SelectorValue),
 	)
+	extra = append(extra, source.Transformers(ctx, instance)...)
+	extra = append(extra, broker.Transformers(instance)...)
 	extra = append(extra, r.extension.Transformers(instance)...)
 	return common.Transform(ctx, manifest, instance, extra...)
 }
@@ -184,7 +263,7 @@ func (r *Reconciler) installed(ctx context.Context, instance base.KComponent) (*
 	if len(paths) == 0 {
 		return nil, nil
 	}
-	installed, err := common.FetchManifestFromArray(paths)
+	installed, err := common.FetchManifestFromArray(ctx, paths)
 
 	if err != nil {
 		return &installed, err
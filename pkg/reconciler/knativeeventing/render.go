@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knativeeventing
+
+import (
+	"context"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// Renderer builds the manifests of a KnativeEventing the way the reconciler does, without applying them.
+// It allows tools outside of the controller to inspect what the operator would install.
+type Renderer struct {
+	r *Reconciler
+}
+
+// NewRenderer returns a Renderer. The client and logger of the given manifest are used by the
+// transformers that read the cluster, and by the installed manifest.
+func NewRenderer(manifest mf.Manifest, extension common.Extension) *Renderer {
+	return &Renderer{r: &Reconciler{manifest: manifest, extension: extension}}
+}
+
+// Stages returns the stages the reconciler executes before installing the manifest.
+func (rr *Renderer) Stages(state *common.ReconcileState) common.Stages {
+	return append(rr.r.renderStages(state), filterTLSResources)
+}
+
+// Installed returns the manifest recorded in the status of the instance.
+func (rr *Renderer) Installed(ctx context.Context, instance base.KComponent) (*mf.Manifest, error) {
+	return rr.r.installed(ctx, instance)
+}
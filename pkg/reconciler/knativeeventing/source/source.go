@@ -30,33 +30,47 @@ import (
 	"knative.dev/operator/pkg/reconciler/common"
 )
 
-func getSource(path string) (mf.Manifest, error) {
+func getSource(ctx context.Context, path string) (mf.Manifest, error) {
 	if path == "" {
 		return mf.Manifest{}, nil
 	}
-	return common.FetchManifest(path)
+	return common.FetchManifest(ctx, path)
 }
 
-func getAllSourcePath(version string) string {
+// sourceDir returns the kodata directory of the eventing sources of the version.
+func sourceDir(version string) string {
 	koDataDir := os.Getenv(common.KoEnvKey)
 	sourceVersion := common.LATEST_VERSION
 	if !strings.EqualFold(version, common.LATEST_VERSION) {
 		sourceVersion = semver.MajorMinor(common.SanitizeSemver(version))[1:]
 	}
+	return filepath.Join(koDataDir, "eventing-source", sourceVersion)
+}
 
-	sourcePath := filepath.Join(koDataDir, "eventing-source", sourceVersion)
-	// List all the directories under the sourcePath, because we will append all the paths for eventing sources
-	fileList, err := os.ReadDir(sourcePath)
+// Catalog returns the names of the eventing sources available for the version, which are the
+// directories of kodata/eventing-source/<major.minor>.
+func Catalog(version string) []string {
+	fileList, err := os.ReadDir(sourceDir(version))
 	if err != nil {
-		return ""
+		return nil
 	}
-
-	urls := make([]string, 0, len(fileList))
+	names := make([]string, 0, len(fileList))
 	for _, file := range fileList {
 		if file.IsDir() {
-			urls = append(urls, path.Join(sourcePath, file.Name()))
+			names = append(names, file.Name())
 		}
 	}
+	return names
+}
+
+func getAllSourcePath(version string) string {
+	sourcePath := sourceDir(version)
+	// Append the paths of all the eventing sources of the catalog.
+	names := Catalog(version)
+	urls := make([]string, 0, len(names))
+	for _, name := range names {
+		urls = append(urls, path.Join(sourcePath, name))
+	}
 	return strings.Join(urls, common.COMMA)
 }
 
@@ -68,48 +82,21 @@ func GetSourcePath(version string, ke *v1beta1.KnativeEventing) string {
 		return ""
 	}
 
-	koDataDir := os.Getenv(common.KoEnvKey)
-	sourceVersion := common.LATEST_VERSION
-	if !strings.EqualFold(version, common.LATEST_VERSION) {
-		sourceVersion = semver.MajorMinor(common.SanitizeSemver(version))[1:]
-	}
-
-	// This line can make sure a valid available source version is returned.
-	sourcePath := filepath.Join(koDataDir, "eventing-source", sourceVersion)
+	sourcePath := sourceDir(version)
 	var urls []string
-
-	if ke.Spec.Source.Ceph.Enabled {
-		url := filepath.Join(sourcePath, "ceph")
-		urls = append(urls, url)
-	}
-	if ke.Spec.Source.Github.Enabled {
-		url := filepath.Join(sourcePath, "github")
-		urls = append(urls, url)
-	}
-	if ke.Spec.Source.Gitlab.Enabled {
-		url := filepath.Join(sourcePath, "gitlab")
-		urls = append(urls, url)
-	}
-	if ke.Spec.Source.Kafka.Enabled {
-		url := filepath.Join(sourcePath, "kafka")
-		urls = append(urls, url)
-	}
-	if ke.Spec.Source.Rabbitmq.Enabled {
-		url := filepath.Join(sourcePath, "rabbitmq")
-		urls = append(urls, url)
-	}
-	if ke.Spec.Source.Redis.Enabled {
-		url := filepath.Join(sourcePath, "redis")
-		urls = append(urls, url)
+	// A source missing from the catalog of the version is kept, so that fetching its manifests
+	// reports it.
+	for _, name := range ke.Spec.Source.EnabledSources() {
+		urls = append(urls, filepath.Join(sourcePath, name))
 	}
 	return strings.Join(urls, common.COMMA)
 }
 
 // AppendTargetSources appends the manifests of the eventing sources to be installed
-func AppendTargetSources(_ context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+func AppendTargetSources(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	version := common.TargetVersion(instance)
 	sourcePath := GetSourcePath(version, ConvertToKE(instance))
-	m, err := getSource(sourcePath)
+	m, err := getSource(ctx, sourcePath)
 	if err == nil {
 		*manifest = manifest.Append(m)
 	}
@@ -122,13 +109,13 @@ func AppendTargetSources(_ context.Context, manifest *mf.Manifest, instance base
 }
 
 // AppendAllSources appends all the manifests of the eventing sources
-func AppendAllSources(_ context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+func AppendAllSources(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	version := instance.GetStatus().GetVersion()
 	if version == "" {
 		version = common.TargetVersion(instance)
 	}
 	sourcePath := getAllSourcePath(version)
-	m, err := getSource(sourcePath)
+	m, err := getSource(ctx, sourcePath)
 	if err == nil {
 		*manifest = manifest.Append(m)
 	}
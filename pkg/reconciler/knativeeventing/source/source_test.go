@@ -104,6 +104,7 @@ func TestAppendAllSources(t *testing.T) {
 			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/github" + common.COMMA +
 			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/gitlab" + common.COMMA +
 			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/kafka" + common.COMMA +
+			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/prometheus" + common.COMMA +
 			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/redis" + common.COMMA +
 			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/rabbitmq",
 		expectedErr: nil,
@@ -240,6 +241,41 @@ func TestAppendTargetSources(t *testing.T) {
 		},
 		expectedIngressPath: os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/ceph",
 		expectedErr:         nil,
+	}, {
+		name: "Source of the catalog without typed options",
+		instance: eventingv1beta1.KnativeEventing{
+			Spec: eventingv1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.23",
+				},
+				Source: &eventingv1beta1.SourceConfigs{
+					Redis: base.RedisSourceConfiguration{
+						Enabled: true,
+					},
+					Catalog: map[string]base.SourceConfiguration{
+						"prometheus": {Enabled: true},
+					},
+				},
+			},
+		},
+		expectedIngressPath: os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/prometheus" + common.COMMA +
+			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/redis",
+		expectedErr: nil,
+	}, {
+		name: "Source missing from the catalog",
+		instance: eventingv1beta1.KnativeEventing{
+			Spec: eventingv1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.23",
+				},
+				Source: &eventingv1beta1.SourceConfigs{
+					Catalog: map[string]base.SourceConfiguration{
+						"natss": {Enabled: true},
+					},
+				},
+			},
+		},
+		expectedErr: fmt.Errorf("stat testdata/kodata/eventing-source/0.23/natss: no such file or directory"),
 	}, {
 		name: "No source is enabled",
 		instance: eventingv1beta1.KnativeEventing{
@@ -336,6 +372,24 @@ func TestGetSourcePath(t *testing.T) {
 			},
 		},
 		expectedSourcePath: "",
+	}, {
+		name:    "Sources of the catalog are enabled by name",
+		version: "0.23.0",
+		instance: eventingv1beta1.KnativeEventing{
+			Spec: eventingv1beta1.KnativeEventingSpec{
+				Source: &eventingv1beta1.SourceConfigs{
+					Kafka: base.KafkaSourceConfiguration{
+						Enabled: true,
+					},
+					Catalog: map[string]base.SourceConfiguration{
+						"awssqs":     {Enabled: false},
+						"prometheus": {Enabled: true},
+					},
+				},
+			},
+		},
+		expectedSourcePath: os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/kafka" + common.COMMA +
+			os.Getenv(common.KoEnvKey) + "/eventing-source/0.23/prometheus",
 	}}
 
 	for _, tt := range tests {
@@ -345,3 +399,12 @@ func TestGetSourcePath(t *testing.T) {
 		})
 	}
 }
+
+func TestCatalog(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+
+	util.AssertDeepEqual(t, Catalog("0.22.1"), []string{"ceph", "github", "gitlab", "kafka", "rabbitmq", "redis"})
+	util.AssertDeepEqual(t, Catalog("0.23"), []string{"ceph", "github", "gitlab", "kafka", "prometheus", "rabbitmq", "redis"})
+	util.AssertEqual(t, len(Catalog("0.21")), 0)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	mf "github.com/manifestival/manifestival"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	kec "knative.dev/operator/pkg/reconciler/knativeeventing/common"
+)
+
+// The workloads and the ConfigMaps of the eventing sources, configured by spec.source.
+const (
+	cephController     = "ceph-controller"
+	githubController   = "github-controller-manager"
+	gitlabController   = "gitlab-controller-manager"
+	kafkaController    = "kafka-controller"
+	kafkaDispatcher    = "kafka-source-dispatcher"
+	rabbitmqController = "rabbitmq-controller-manager"
+	redisController    = "redis-controller-manager"
+
+	kafkaFeaturesConfigMap = "config-kafka-features"
+	kafkaDefaultsConfigMap = "config-kafka-source-defaults"
+	redisConfigMap         = "config-redis"
+)
+
+// TransformerFunc returns the transformers, which apply the options of an enabled eventing source
+// to its manifests.
+type TransformerFunc func(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer
+
+var (
+	transformersMu sync.RWMutex
+	transformers   = map[string]TransformerFunc{}
+)
+
+func init() {
+	RegisterTransformers("ceph", func(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+		return workloadTransformers(ctx, workload{cephController, ke.Spec.Source.Ceph.Controller})
+	})
+	RegisterTransformers("github", func(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+		return workloadTransformers(ctx, workload{githubController, ke.Spec.Source.Github.Controller})
+	})
+	RegisterTransformers("gitlab", func(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+		return workloadTransformers(ctx, workload{gitlabController, ke.Spec.Source.Gitlab.Controller})
+	})
+	RegisterTransformers("kafka", kafkaTransformers)
+	RegisterTransformers("rabbitmq", func(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+		return workloadTransformers(ctx, workload{rabbitmqController, ke.Spec.Source.Rabbitmq.Controller})
+	})
+	RegisterTransformers("redis", redisTransformers)
+}
+
+// RegisterTransformers registers the transformers of the eventing source of the catalog with the
+// name. It panics if transformers are registered for the name.
+func RegisterTransformers(name string, f TransformerFunc) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	if _, ok := transformers[name]; ok {
+		panic(fmt.Sprintf("transformers of the eventing source %q are already registered", name))
+	}
+	transformers[name] = f
+}
+
+// Transformers returns the transformers, which apply the options of the enabled eventing sources
+// to their manifests.
+func Transformers(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+	transformersMu.RLock()
+	defer transformersMu.RUnlock()
+	var result []mf.Transformer
+	for _, name := range ke.Spec.Source.EnabledSources() {
+		if f, ok := transformers[name]; ok {
+			result = append(result, f(ctx, ke)...)
+		}
+	}
+	return result
+}
+
+func kafkaTransformers(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+	kafka := ke.Spec.Source.Kafka
+	result := workloadTransformers(ctx,
+		workload{kafkaController, kafka.Controller},
+		workload{kafkaDispatcher, kafka.Dispatcher})
+	if len(kafka.Features) > 0 {
+		result = append(result, kec.ConfigMapDataTransform(ke, kafkaFeaturesConfigMap, kafka.Features))
+	}
+	if d := kafkaDefaults(kafka.Defaults); len(d) > 0 {
+		result = append(result, kec.ConfigMapDataTransform(ke, kafkaDefaultsConfigMap, d))
+	}
+	return result
+}
+
+func redisTransformers(ctx context.Context, ke *v1beta1.KnativeEventing) []mf.Transformer {
+	redis := ke.Spec.Source.Redis
+	result := workloadTransformers(ctx, workload{redisController, redis.Controller})
+	if redis.Defaults != nil && redis.Defaults.NumConsumers != nil {
+		result = append(result, kec.ConfigMapDataTransform(ke, redisConfigMap, map[string]string{
+			"numConsumers": strconv.Itoa(int(*redis.Defaults.NumConsumers)),
+		}))
+	}
+	return result
+}
+
+// workload is a workload of an eventing source and its options.
+type workload struct {
+	name   string
+	config *base.SourceWorkloadConfiguration
+}
+
+// workloadTransformers converts the options of the workloads of a source to a workload override
+// transformer.
+func workloadTransformers(ctx context.Context, workloads ...workload) []mf.Transformer {
+	var overrides []base.WorkloadOverride
+	for _, w := range workloads {
+		if w.config == nil {
+			continue
+		}
+		overrides = append(overrides, base.WorkloadOverride{
+			Name:      w.name,
+			Replicas:  w.config.Replicas,
+			Resources: w.config.Resources,
+		})
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	return []mf.Transformer{common.OverridesTransform(overrides, logging.FromContext(ctx))}
+}
+
+// kafkaDefaults returns the entries of config-kafka-source-defaults.
+func kafkaDefaults(d *base.KafkaSourceDefaults) map[string]string {
+	if d == nil {
+		return nil
+	}
+	data := map[string]string{}
+	if d.AutoscalingClass != "" {
+		data["autoscalingClass"] = d.AutoscalingClass
+	}
+	for key, value := range map[string]*int32{
+		"minScale":          d.MinScale,
+		"maxScale":          d.MaxScale,
+		"pollingInterval":   d.PollingInterval,
+		"cooldownPeriod":    d.CooldownPeriod,
+		"kafkaLagThreshold": d.KafkaLagThreshold,
+	} {
+		if value != nil {
+			data[key] = strconv.Itoa(int(*value))
+		}
+	}
+	return data
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	eventingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestTransformers(t *testing.T) {
+	ke := &eventingv1beta1.KnativeEventing{
+		Spec: eventingv1beta1.KnativeEventingSpec{
+			CommonSpec: base.CommonSpec{
+				Config: base.ConfigMapData{"kafka-source-defaults": {"maxScale": "50"}},
+			},
+			Source: &eventingv1beta1.SourceConfigs{
+				Kafka: base.KafkaSourceConfiguration{
+					Enabled:    true,
+					Controller: &base.SourceWorkloadConfiguration{Replicas: ptr.To(int32(2))},
+					Dispatcher: &base.SourceWorkloadConfiguration{
+						Resources: []base.ResourceRequirementsOverride{{
+							Container: "kafka-source-dispatcher",
+							ResourceRequirements: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							},
+						}},
+					},
+					Features: map[string]string{"dispatcher-rate-limiter": "enabled"},
+					Defaults: &base.KafkaSourceDefaults{
+						AutoscalingClass: "keda.autoscaling.knative.dev",
+						MaxScale:         ptr.To(int32(10)),
+					},
+				},
+				// The options of the disabled sources are ignored.
+				Redis: base.RedisSourceConfiguration{
+					Controller: &base.SourceWorkloadConfiguration{Replicas: ptr.To(int32(3))},
+				},
+			},
+		},
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice{
+		util.MakeUnstructured(t, util.MakeDeployment(kafkaController, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "controller"}},
+		})),
+		util.MakeUnstructured(t, util.MakeStatefulSet(kafkaDispatcher, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "kafka-source-dispatcher"}},
+		})),
+		util.MakeUnstructured(t, util.MakeDeployment(redisController, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "manager"}},
+		})),
+		configMap(t, kafkaFeaturesConfigMap),
+		configMap(t, kafkaDefaultsConfigMap),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transformers := Transformers(context.TODO(), ke)
+	util.AssertEqual(t, len(transformers), 3)
+	manifest, err = manifest.Transform(transformers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := manifest.Resources()
+	replicas, _, _ := unstructured.NestedInt64(resources[0].Object, "spec", "replicas")
+	util.AssertEqual(t, replicas, int64(2))
+	containers, _, _ := unstructured.NestedSlice(resources[1].Object, "spec", "template", "spec", "containers")
+	limit, _, _ := unstructured.NestedString(containers[0].(map[string]interface{}), "resources", "limits", "memory")
+	util.AssertEqual(t, limit, "1Gi")
+	_, found, _ := unstructured.NestedInt64(resources[2].Object, "spec", "replicas")
+	util.AssertEqual(t, found, false)
+
+	features, _, _ := unstructured.NestedStringMap(resources[3].Object, "data")
+	util.AssertDeepEqual(t, features, map[string]string{"dispatcher-rate-limiter": "enabled"})
+	// maxScale is set in spec.config, which takes precedence.
+	defaults, _, _ := unstructured.NestedStringMap(resources[4].Object, "data")
+	util.AssertDeepEqual(t, defaults, map[string]string{"autoscalingClass": "keda.autoscaling.knative.dev"})
+}
+
+func TestTransformersWithoutSource(t *testing.T) {
+	util.AssertEqual(t, len(Transformers(context.TODO(), &eventingv1beta1.KnativeEventing{})), 0)
+}
+
+func TestRegisterTransformers(t *testing.T) {
+	defer func() {
+		transformersMu.Lock()
+		delete(transformers, "prometheus")
+		transformersMu.Unlock()
+	}()
+
+	RegisterTransformers("prometheus", func(context.Context, *eventingv1beta1.KnativeEventing) []mf.Transformer {
+		return []mf.Transformer{func(*unstructured.Unstructured) error { return nil }}
+	})
+	ke := &eventingv1beta1.KnativeEventing{
+		Spec: eventingv1beta1.KnativeEventingSpec{
+			Source: &eventingv1beta1.SourceConfigs{
+				Catalog: map[string]base.SourceConfiguration{"prometheus": {Enabled: true}},
+			},
+		},
+	}
+	util.AssertEqual(t, len(Transformers(context.TODO(), ke)), 1)
+	ke.Spec.Source.Catalog["prometheus"] = base.SourceConfiguration{}
+	util.AssertEqual(t, len(Transformers(context.TODO(), ke)), 0)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering the transformers of a source twice did not panic")
+		}
+	}()
+	RegisterTransformers("kafka", kafkaTransformers)
+}
+
+func configMap(t *testing.T, name string) unstructured.Unstructured {
+	return util.MakeUnstructured(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "knative-eventing"},
+	})
+}
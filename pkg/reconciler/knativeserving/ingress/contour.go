@@ -18,11 +18,359 @@ package ingress
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+const (
+	contourConfigName       = "config-contour"
+	contourDelegationName   = "knative-default-tls-secret"
+	contourExternalClass    = "contour-external"
+	contourExternalService  = "contour-external/envoy"
+	contourInternalClass    = "contour-internal"
+	contourInternalService  = "contour-internal/envoy"
+	contourVisibilityKey    = "visibility"
+	contourDefaultSecretKey = "default-tls-secret"
 )
 
-func contourTransformers(_ context.Context, _ *v1beta1.KnativeServing) []mf.Transformer {
+// contourPlugin installs net-contour.
+type contourPlugin struct{}
+
+func (contourPlugin) Name() string { return "contour" }
+
+func (contourPlugin) Enabled(ks *v1beta1.KnativeServing) bool {
+	return ks.Spec.Ingress != nil && ks.Spec.Ingress.Contour.Enabled
+}
+
+func (p contourPlugin) ManifestPaths(ingressDir, _ string, _ *v1beta1.KnativeServing) []string {
+	return kodataPath(ingressDir, p)
+}
+
+func (contourPlugin) Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
+	return contourTransformers(ctx, ks)
+}
+
+func (contourPlugin) IngressClass(*v1beta1.KnativeServing) string {
+	return "contour.ingress.networking.knative.dev"
+}
+
+// contourCRDs are the CRDs of Contour, which net-contour and the TLSCertificateDelegation depend on.
+var contourCRDs = []string{"httpproxies.projectcontour.io", "tlscertificatedelegations.projectcontour.io"}
+
+// CheckReady verifies that Contour, which net-contour does not ship, is installed, unless the manifest
+// installs its CRDs, e.g. with spec.additionalManifests.
+func (contourPlugin) CheckReady(_ context.Context, manifest *mf.Manifest, ks *v1beta1.KnativeServing) error {
+	var missing []string
+	for _, name := range contourCRDs {
+		if len(manifest.Filter(mf.ByKind("CustomResourceDefinition"), mf.ByName(name)).Resources()) == 0 {
+			missing = append(missing, name)
+		}
+	}
+	if err := common.CheckCRDs(manifest, missing...); err != nil {
+		return fmt.Errorf("please install Contour or disable the contour ingress plugin: %w", err)
+	}
+	// The type and the annotations of the Envoy Services, which Contour installed separately owns,
+	// are patched in the cluster.
+	for service := range liveEnvoyServiceOverrides(manifest, ks) {
+		if _, err := manifest.Client.Get(envoyService(service)); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("the Envoy Service %s, whose type or annotations are set in spec.ingress.contour, does not exist", service)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ResourceKinds returns the kind of the TLSCertificateDelegation.
+func (contourPlugin) ResourceKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{{Group: "projectcontour.io", Version: "v1", Kind: "TLSCertificateDelegation"}}
+}
+
+// Resources renders the TLSCertificateDelegation of the default TLS secret.
+func (contourPlugin) Resources(_ context.Context, ks *v1beta1.KnativeServing) ([]unstructured.Unstructured, error) {
+	if ks.Spec.Ingress == nil || ks.Spec.Ingress.Contour.DefaultTLSSecret == "" {
+		return nil, nil
+	}
+	namespace, name, _ := strings.Cut(ks.Spec.Ingress.Contour.DefaultTLSSecret, "/")
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"delegations": []interface{}{map[string]interface{}{
+				"secretName":       name,
+				"targetNamespaces": []interface{}{"*"},
+			}},
+		},
+	}}
+	u.SetAPIVersion("projectcontour.io/v1")
+	u.SetKind("TLSCertificateDelegation")
+	u.SetName(contourDelegationName)
+	u.SetNamespace(namespace)
+	u.SetLabels(map[string]string{createdByLabel: createdByValue})
+	return []unstructured.Unstructured{u}, nil
+}
+
+func contourTransformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
+	if ks.Spec.Ingress == nil {
+		return nil
+	}
+	contour := ks.Spec.Ingress.Contour
+	if contour.External == nil && contour.Internal == nil && contour.DefaultTLSSecret == "" &&
+		contour.TimeoutPolicyIdle == "" && contour.TimeoutPolicyResponse == "" {
+		return nil
+	}
+	resources, _ := contourPlugin{}.Resources(ctx, ks)
+	return []mf.Transformer{
+		configContourTransform(ks),
+		envoyServiceTransform(ks),
+		createdResourcesTransform(ks, resources),
+	}
+}
+
+// contourVisibility is an entry of the visibility of config-contour.
+type contourVisibility struct {
+	Class   string `json:"class"`
+	Service string `json:"service"`
+}
+
+// configContourTransform writes the Contour configuration to the config-contour ConfigMap, unless
+// the entries are set in spec.config.
+func configContourTransform(ks *v1beta1.KnativeServing) mf.Transformer {
+	contour := ks.Spec.Ingress.Contour
+	data := map[string]string{
+		contourDefaultSecretKey:   contour.DefaultTLSSecret,
+		"timeout-policy-idle":     contour.TimeoutPolicyIdle,
+		"timeout-policy-response": contour.TimeoutPolicyResponse,
+	}
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != contourConfigName {
+			return nil
+		}
+		if (contour.External != nil || contour.Internal != nil) && !configured(ks, "contour", contourVisibilityKey) {
+			visibility, err := yaml.Marshal(map[string]contourVisibility{
+				"ExternalIP":   visibilityOf(contour.External, contourExternalClass, contourExternalService),
+				"ClusterLocal": visibilityOf(contour.Internal, contourInternalClass, contourInternalService),
+			})
+			if err != nil {
+				return err
+			}
+			if err := unstructured.SetNestedField(u.Object, string(visibility), "data", contourVisibilityKey); err != nil {
+				return err
+			}
+		}
+		for key, value := range data {
+			if value == "" || configured(ks, "contour", key) {
+				continue
+			}
+			if err := unstructured.SetNestedField(u.Object, value, "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// visibilityOf returns the visibility of the Contour, with the defaults of net-contour.
+func visibilityOf(v *base.ContourVisibilityConfiguration, class, service string) contourVisibility {
+	visibility := contourVisibility{Class: class, Service: service}
+	if v != nil && v.Class != "" {
+		visibility.Class = v.Class
+	}
+	if v != nil && v.Service != "" {
+		visibility.Service = v.Service
+	}
+	return visibility
+}
+
+// envoyNamespaceAnnotation records the namespace of an Envoy Service of the manifests, before the
+// namespace of the KnativeServing is injected. Both Services are named envoy, so their namespaces
+// tell them apart. The annotation is removed by envoyServiceTransform.
+const envoyNamespaceAnnotation = "operator.knative.dev/envoy-namespace"
+
+// envoyServiceOverrides returns the overrides of the Envoy Services, keyed by namespace/name.
+func envoyServiceOverrides(ks *v1beta1.KnativeServing) map[string]*base.ContourVisibilityConfiguration {
+	overrides := map[string]*base.ContourVisibilityConfiguration{}
+	if ks.Spec.Ingress == nil {
+		return overrides
+	}
+	for _, v := range []struct {
+		config  *base.ContourVisibilityConfiguration
+		service string
+	}{
+		{ks.Spec.Ingress.Contour.Internal, contourInternalService},
+		{ks.Spec.Ingress.Contour.External, contourExternalService},
+	} {
+		if v.config == nil || (v.config.ServiceType == "" && len(v.config.ServiceAnnotations) == 0) {
+			continue
+		}
+		overrides[visibilityOf(v.config, "", v.service).Service] = v.config
+	}
+	return overrides
+}
+
+// MarkEnvoyServices records the namespaces of the Envoy Services in the manifest, which the type and
+// the annotations of spec.ingress.contour apply to. It needs to run before the manifest is transformed.
+func MarkEnvoyServices(_ context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+	ks := comp.(*v1beta1.KnativeServing)
+	if !(contourPlugin{}).Enabled(ks) {
+		return nil
+	}
+	overrides := envoyServiceOverrides(ks)
+	if len(overrides) == 0 {
+		return nil
+	}
+	m, err := manifest.Transform(func(u *unstructured.Unstructured) error {
+		if u.GetAPIVersion() != "v1" || u.GetKind() != "Service" {
+			return nil
+		}
+		if _, ok := overrides[u.GetNamespace()+"/"+u.GetName()]; !ok {
+			return nil
+		}
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[envoyNamespaceAnnotation] = u.GetNamespace()
+		u.SetAnnotations(annotations)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*manifest = m
+	return nil
+}
+
+// envoyServiceTransform overrides the type and the annotations of the Envoy Services marked by
+// MarkEnvoyServices, when Contour is installed by the operator, e.g. with spec.additionalManifests.
+// The Envoy Services of a Contour installed separately are patched by PatchEnvoyServices instead.
+// The Services are restored to the namespaces of the visibilities, and lose their owner references
+// across namespaces.
+func envoyServiceTransform(ks *v1beta1.KnativeServing) mf.Transformer {
+	overrides := envoyServiceOverrides(ks)
+	return func(u *unstructured.Unstructured) error {
+		if u.GetAPIVersion() != "v1" || u.GetKind() != "Service" {
+			return nil
+		}
+		namespace, ok := u.GetAnnotations()[envoyNamespaceAnnotation]
+		if !ok {
+			return nil
+		}
+		svc := &corev1.Service{}
+		if err := scheme.Scheme.Convert(u, svc, nil); err != nil {
+			return err
+		}
+		delete(svc.Annotations, envoyNamespaceAnnotation)
+		if len(svc.Annotations) == 0 {
+			svc.Annotations = nil
+		}
+		override, ok := overrides[namespace+"/"+svc.GetName()]
+		if !ok {
+			return scheme.Scheme.Convert(svc, u, nil)
+		}
+		svc.SetNamespace(namespace)
+		if svc.GetNamespace() != ks.GetNamespace() {
+			svc.SetOwnerReferences(nil)
+		}
+		if override.ServiceType != "" {
+			svc.Spec.Type = override.ServiceType
+		}
+		if len(override.ServiceAnnotations) > 0 {
+			if svc.Annotations == nil {
+				svc.Annotations = map[string]string{}
+			}
+			for k, v := range override.ServiceAnnotations {
+				svc.Annotations[k] = v
+			}
+		}
+		return scheme.Scheme.Convert(svc, u, nil)
+	}
+}
+
+// envoyService returns the Service of the namespace/name.
+func envoyService(service string) *unstructured.Unstructured {
+	namespace, name, _ := strings.Cut(service, "/")
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("Service")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+// liveEnvoyServiceOverrides returns the overrides of the Envoy Services, which the manifest does not
+// contain, keyed by namespace/name.
+func liveEnvoyServiceOverrides(manifest *mf.Manifest, ks *v1beta1.KnativeServing) map[string]*base.ContourVisibilityConfiguration {
+	overrides := envoyServiceOverrides(ks)
+	for service := range overrides {
+		namespace, name, _ := strings.Cut(service, "/")
+		for _, u := range manifest.Filter(mf.ByKind("Service"), mf.ByName(name)).Resources() {
+			if u.GetNamespace() == namespace {
+				delete(overrides, service)
+			}
+		}
+	}
+	return overrides
+}
+
+// PatchEnvoyServices overrides the type and the annotations of the Envoy Services of a Contour
+// installed separately, which the manifest does not contain. The overrides of the Services in the
+// manifest are applied by envoyServiceTransform. The annotations, which are removed from the spec,
+// are left on the Services.
+func PatchEnvoyServices(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+	ks := comp.(*v1beta1.KnativeServing)
+	if !(contourPlugin{}).Enabled(ks) {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+	for service, override := range liveEnvoyServiceOverrides(manifest, ks) {
+		u, err := manifest.Client.Get(envoyService(service))
+		if err != nil {
+			return fmt.Errorf("failed to get the Envoy Service %s: %w", service, err)
+		}
+		svc := &corev1.Service{}
+		if err := scheme.Scheme.Convert(u, svc, nil); err != nil {
+			return err
+		}
+		patched := svc.DeepCopy()
+		if override.ServiceType != "" && patched.Spec.Type != override.ServiceType {
+			patched.Spec.Type = override.ServiceType
+			if override.ServiceType == corev1.ServiceTypeClusterIP {
+				// A ClusterIP Service cannot keep the node ports of a NodePort or LoadBalancer Service.
+				for i := range patched.Spec.Ports {
+					patched.Spec.Ports[i].NodePort = 0
+				}
+			}
+		}
+		for k, v := range override.ServiceAnnotations {
+			if patched.Annotations == nil {
+				patched.Annotations = map[string]string{}
+			}
+			patched.Annotations[k] = v
+		}
+		if equality.Semantic.DeepEqual(svc, patched) {
+			continue
+		}
+		if err := scheme.Scheme.Convert(patched, u, nil); err != nil {
+			return err
+		}
+		logger.Infof("Patching the Envoy Service %s", service)
+		if err := manifest.Client.Update(u); err != nil {
+			return fmt.Errorf("failed to patch the Envoy Service %s: %w", service, err)
+		}
+	}
 	return nil
 }
@@ -20,6 +20,13 @@ import (
 	"context"
 	"testing"
 
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
 	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
 	util "knative.dev/operator/pkg/reconciler/common/testing"
 )
@@ -29,3 +36,245 @@ func TestContourTransformers(t *testing.T) {
 	transformer := contourTransformers(context.TODO(), instance)
 	util.AssertEqual(t, len(transformer), 0)
 }
+
+func contourServing() *servingv1beta1.KnativeServing {
+	return &servingv1beta1.KnativeServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "knative-serving", Namespace: "knative-serving"},
+		Spec: servingv1beta1.KnativeServingSpec{
+			Ingress: &servingv1beta1.IngressConfigs{
+				Contour: base.ContourIngressConfiguration{
+					Enabled: true,
+					External: &base.ContourVisibilityConfiguration{
+						Class:              "public",
+						ServiceType:        corev1.ServiceTypeLoadBalancer,
+						ServiceAnnotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+					},
+					DefaultTLSSecret:  "certs/wildcard",
+					TimeoutPolicyIdle: "5m",
+				},
+			},
+		},
+	}
+}
+
+func TestConfigContourTransform(t *testing.T) {
+	ks := contourServing()
+	ks.Spec.Config = base.ConfigMapData{"contour": {"timeout-policy-idle": "infinity"}}
+
+	cm := util.MakeUnstructured(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config-contour", Namespace: "knative-serving"},
+		Data:       map[string]string{"timeout-policy-idle": "infinity"},
+	})
+	for _, transformer := range contourTransformers(context.TODO(), ks) {
+		if err := transformer(&cm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	util.AssertDeepEqual(t, data, map[string]string{
+		"default-tls-secret":  "certs/wildcard",
+		"timeout-policy-idle": "infinity",
+		"visibility": `ClusterLocal:
+  class: contour-internal
+  service: contour-internal/envoy
+ExternalIP:
+  class: public
+  service: contour-external/envoy
+`,
+	})
+}
+
+func TestEnvoyServiceTransform(t *testing.T) {
+	ks := contourServing()
+	ks.Spec.Ingress.Contour.Internal = &base.ContourVisibilityConfiguration{
+		ServiceAnnotations: map[string]string{"networking.gke.io/load-balancer-type": "Internal"},
+	}
+	envoy := func(namespace string) unstructured.Unstructured {
+		return util.MakeUnstructured(t, &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: "envoy", Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		})
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice{envoy("contour-internal"), envoy("contour-external"), envoy("other")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := MarkEnvoyServices(context.TODO(), &manifest, ks); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err = manifest.Transform(mf.InjectNamespace("knative-serving"), mf.InjectOwner(ks), envoyServiceTransform(ks))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := manifest.Resources()
+	util.AssertEqual(t, len(resources), 3)
+	for i, expected := range []struct {
+		namespace   string
+		owners      int
+		annotations map[string]string
+		serviceType string
+	}{
+		{"contour-internal", 0, map[string]string{"networking.gke.io/load-balancer-type": "Internal"}, "ClusterIP"},
+		{"contour-external", 0, map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"}, "LoadBalancer"},
+		// A Service named envoy of another namespace is left alone.
+		{"knative-serving", 1, nil, "ClusterIP"},
+	} {
+		svc := resources[i]
+		util.AssertEqual(t, svc.GetNamespace(), expected.namespace)
+		util.AssertEqual(t, len(svc.GetOwnerReferences()), expected.owners)
+		util.AssertDeepEqual(t, svc.GetAnnotations(), expected.annotations)
+		serviceType, _, _ := unstructured.NestedString(svc.Object, "spec", "type")
+		util.AssertEqual(t, serviceType, expected.serviceType)
+	}
+}
+
+func TestContourResources(t *testing.T) {
+	ks := contourServing()
+	resources, err := contourPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transformers := append([]mf.Transformer{mf.InjectNamespace("knative-serving"), mf.InjectOwner(ks)}, contourTransformers(context.TODO(), ks)...)
+	manifest, err = manifest.Transform(transformers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(manifest.Resources()), 1)
+	delegation := manifest.Resources()[0]
+	util.AssertEqual(t, delegation.GetKind(), "TLSCertificateDelegation")
+	util.AssertEqual(t, delegation.GetNamespace(), "certs")
+	util.AssertEqual(t, len(delegation.GetOwnerReferences()), 0)
+	delegations, _, _ := unstructured.NestedSlice(delegation.Object, "spec", "delegations")
+	util.AssertDeepEqual(t, delegations, []interface{}{map[string]interface{}{
+		"secretName":       "wildcard",
+		"targetNamespaces": []interface{}{"*"},
+	}})
+
+	ks.Spec.Ingress.Contour.DefaultTLSSecret = ""
+	resources, err = contourPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(resources), 0)
+}
+
+// envoyLiveService returns the Envoy Service of a Contour installed separately.
+func envoyLiveService(t *testing.T) unstructured.Unstructured {
+	return util.MakeUnstructured(t, &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "envoy", Namespace: "contour-external", Annotations: map[string]string{"owner": "contour"}},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 80, NodePort: 30080}},
+		},
+	})
+}
+
+func TestContourCheckReady(t *testing.T) {
+	envoy := envoyLiveService(t)
+	client := fake.New(&envoy)
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := contourServing()
+	err = contourPlugin{}.CheckReady(context.TODO(), &manifest, ks)
+	util.AssertEqual(t, err.Error(), "please install Contour or disable the contour ingress plugin: "+
+		"the CustomResourceDefinition httpproxies.projectcontour.io is not installed")
+
+	// The CRDs installed with the manifest, e.g. with spec.additionalManifests, are not checked.
+	var crds []unstructured.Unstructured
+	for _, name := range contourCRDs {
+		crd := unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName(name)
+		crds = append(crds, crd)
+	}
+	withCRDs, err := mf.ManifestFrom(mf.Slice(crds), mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (contourPlugin{}).CheckReady(context.TODO(), &withCRDs, ks); err != nil {
+		t.Fatalf("CheckReady() = %v", err)
+	}
+
+	for i := range crds {
+		if err := client.Create(&crds[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := (contourPlugin{}).CheckReady(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("CheckReady() = %v", err)
+	}
+}
+
+func TestPatchEnvoyServices(t *testing.T) {
+	client := fake.New()
+	for _, name := range contourCRDs {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName(name)
+		if err := client.Create(crd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The overrides of a missing Envoy Service are reported, rather than ignored.
+	ks := contourServing()
+	err = contourPlugin{}.CheckReady(context.TODO(), &manifest, ks)
+	util.AssertEqual(t, err.Error(), "the Envoy Service contour-external/envoy, whose type or annotations are set in spec.ingress.contour, does not exist")
+
+	envoy := envoyLiveService(t)
+	if err := client.Create(&envoy); err != nil {
+		t.Fatal(err)
+	}
+	if err := (contourPlugin{}).CheckReady(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("CheckReady() = %v", err)
+	}
+	if err := PatchEnvoyServices(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("PatchEnvoyServices() = %v", err)
+	}
+	live, err := client.Get(&envoy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serviceType, _, _ := unstructured.NestedString(live.Object, "spec", "type")
+	util.AssertEqual(t, serviceType, "LoadBalancer")
+	util.AssertDeepEqual(t, live.GetAnnotations(), map[string]string{
+		"owner": "contour",
+		"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+	})
+
+	// The type of a ClusterIP Service drops the node ports.
+	ks.Spec.Ingress.Contour.External.ServiceType = corev1.ServiceTypeClusterIP
+	if err := PatchEnvoyServices(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("PatchEnvoyServices() = %v", err)
+	}
+	live, err = client.Get(&envoy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports, _, _ := unstructured.NestedSlice(live.Object, "spec", "ports")
+	_, found, _ := unstructured.NestedFieldNoCopy(ports[0].(map[string]interface{}), "nodePort")
+	util.AssertEqual(t, found, false)
+
+	// The Envoy Services in the manifest are not patched in the cluster.
+	inManifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{envoyLiveService(t)}), mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(liveEnvoyServiceOverrides(&inManifest, ks)), 0)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
+	mf "github.com/manifestival/manifestival"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// createdForAnnotation records the KnativeServing, for which a resource was created from the spec,
+// as "<namespace>/<name>". The resources in other namespaces have no owner reference to it.
+const createdForAnnotation = "operator.knative.dev/created-for"
+
+// createdFor returns the value of the createdForAnnotation of the resources of the instance.
+func createdFor(instance base.KComponent) string {
+	return instance.GetNamespace() + "/" + instance.GetName()
+}
+
+// PruneCreatedResources returns a Stage, which deletes the resources created for the KnativeServing
+// from its spec, which the manifest does not contain anymore, e.g. after a Gateway is removed from
+// the spec or its networking layer is disabled.
+func PruneCreatedResources(clients *common.DynamicClients, state *common.ReconcileState) common.Stage {
+	return func(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+		getter, err := clients.ResourceGetter(state)
+		if err != nil {
+			return fmt.Errorf("failed to create the client of the created resources: %w", err)
+		}
+		return deleteCreatedResources(ctx, getter, instance, manifest)
+	}
+}
+
+// DeleteCreatedResources deletes all the resources created for the KnativeServing from its spec.
+func DeleteCreatedResources(ctx context.Context, getter mfdynamic.ResourceGetter, instance base.KComponent) error {
+	return deleteCreatedResources(ctx, getter, instance, nil)
+}
+
+// deleteCreatedResources deletes the resources created for the instance, which the manifest does not
+// contain. The kinds, whose CRDs are not installed, are skipped.
+func deleteCreatedResources(ctx context.Context, getter mfdynamic.ResourceGetter, instance base.KComponent, manifest *mf.Manifest) error {
+	logger := logging.FromContext(ctx)
+	key := func(u *unstructured.Unstructured) string {
+		return u.GroupVersionKind().GroupKind().String() + "/" + u.GetNamespace() + "/" + u.GetName()
+	}
+	rendered := map[string]bool{}
+	if manifest != nil {
+		for _, u := range manifest.Filter(mf.ByLabel(createdByLabel, createdByValue)).Resources() {
+			rendered[key(&u)] = true
+		}
+	}
+	selector := metav1.ListOptions{LabelSelector: createdByLabel + "=" + createdByValue}
+	for _, gvk := range createdKinds() {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		client, err := getter.ResourceInterface(u)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		list, err := client.List(ctx, selector)
+		if err != nil {
+			return fmt.Errorf("failed to list the created %s resources: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetAnnotations()[createdForAnnotation] != createdFor(instance) || rendered[key(item)] {
+				continue
+			}
+			logger.Infof("Deleting the created %s %s/%s", gvk.Kind, item.GetNamespace(), item.GetName())
+			namespaced, err := getter.ResourceInterface(item)
+			if err != nil {
+				return err
+			}
+			if err := namespaced.Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete the created %s %s/%s: %w", gvk.Kind, item.GetNamespace(), item.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// createdKinds returns the kinds of the resources rendered by the registered plugins.
+func createdKinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for _, p := range Plugins() {
+		if renderer, ok := p.(ResourceRenderer); ok {
+			kinds = append(kinds, renderer.ResourceKinds()...)
+		}
+	}
+	return kinds
+}
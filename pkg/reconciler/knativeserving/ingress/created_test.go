@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+
+	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+// createdGetter serves the live objects of the group kinds it knows, in all namespaces, and reports
+// the other kinds as unknown.
+type createdGetter struct {
+	live map[string][]*unstructured.Unstructured
+}
+
+func (g *createdGetter) ResourceInterface(u *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	groupKind := u.GroupVersionKind().GroupKind()
+	if _, ok := g.live[groupKind.String()]; !ok {
+		return nil, &meta.NoKindMatchError{GroupKind: groupKind}
+	}
+	return &createdResource{getter: g, groupKind: groupKind.String(), namespace: u.GetNamespace()}, nil
+}
+
+// names returns the sorted namespace/name of the live objects of the group kind.
+func (g *createdGetter) names(groupKind string) []string {
+	var names []string
+	for _, u := range g.live[groupKind] {
+		names = append(names, u.GetNamespace()+"/"+u.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+type createdResource struct {
+	dynamic.ResourceInterface
+	getter    *createdGetter
+	groupKind string
+	namespace string
+}
+
+func (r *createdResource) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{}
+	for _, u := range r.getter.live[r.groupKind] {
+		if selector.Matches(labels.Set(u.GetLabels())) {
+			list.Items = append(list.Items, *u.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+func (r *createdResource) Delete(_ context.Context, name string, _ metav1.DeleteOptions, _ ...string) error {
+	objs := r.getter.live[r.groupKind][:0]
+	for _, u := range r.getter.live[r.groupKind] {
+		if u.GetNamespace() != r.namespace || u.GetName() != name {
+			objs = append(objs, u)
+		}
+	}
+	r.getter.live[r.groupKind] = objs
+	return nil
+}
+
+// renderCreated returns the manifest of the resources rendered for the KnativeServing.
+func renderCreated(t *testing.T, ks *servingv1beta1.KnativeServing) mf.Manifest {
+	t.Helper()
+	manifest, _ := mf.ManifestFrom(mf.Slice{})
+	if err := appendPluginResources(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("appendPluginResources() = %v", err)
+	}
+	return manifest
+}
+
+// liveResources returns copies of the resources of the manifest, as if they had been applied.
+func liveResources(manifest mf.Manifest) []*unstructured.Unstructured {
+	var live []*unstructured.Unstructured
+	for _, u := range manifest.Resources() {
+		live = append(live, u.DeepCopy())
+	}
+	return live
+}
+
+func TestPruneCreatedGateways(t *testing.T) {
+	ks := gatewayAPIServing()
+	live := liveResources(renderCreated(t, ks))
+
+	other := live[0].DeepCopy()
+	other.SetName("other-external")
+	other.SetAnnotations(map[string]string{createdForAnnotation: "other/knative-serving"})
+	unlabeled := live[0].DeepCopy()
+	unlabeled.SetName("unlabeled")
+	unlabeled.SetLabels(nil)
+	unlabeled.SetAnnotations(nil)
+	getter := &createdGetter{live: map[string][]*unstructured.Unstructured{
+		"Gateway.gateway.networking.k8s.io": append(live, other, unlabeled),
+	}}
+
+	ks.Spec.Ingress.GatewayAPI.LocalGateway.Create = false
+	manifest := renderCreated(t, ks)
+	if err := deleteCreatedResources(context.TODO(), getter, ks, &manifest); err != nil {
+		t.Fatalf("deleteCreatedResources() = %v", err)
+	}
+	util.AssertDeepEqual(t, getter.names("Gateway.gateway.networking.k8s.io"),
+		[]string{"envoy-gateway-system/knative-external", "envoy-gateway-system/other-external", "envoy-gateway-system/unlabeled"})
+
+	ks.Spec.Ingress.GatewayAPI.Enabled = false
+	manifest = renderCreated(t, ks)
+	if err := deleteCreatedResources(context.TODO(), getter, ks, &manifest); err != nil {
+		t.Fatalf("deleteCreatedResources() = %v", err)
+	}
+	util.AssertDeepEqual(t, getter.names("Gateway.gateway.networking.k8s.io"),
+		[]string{"envoy-gateway-system/other-external", "envoy-gateway-system/unlabeled"})
+}
+
+func TestDeleteCreatedResources(t *testing.T) {
+	ks := gatewayAPIServing()
+	getter := &createdGetter{live: map[string][]*unstructured.Unstructured{
+		"Gateway.gateway.networking.k8s.io": liveResources(renderCreated(t, ks)),
+	}}
+	if err := DeleteCreatedResources(context.TODO(), getter, ks); err != nil {
+		t.Fatalf("DeleteCreatedResources() = %v", err)
+	}
+	util.AssertEqual(t, len(getter.names("Gateway.gateway.networking.k8s.io")), 0)
+}
+
+func TestPruneCreatedIstioGateways(t *testing.T) {
+	ks := istioGatewaysServing()
+	getter := &createdGetter{live: map[string][]*unstructured.Unstructured{
+		"Gateway.networking.istio.io": liveResources(renderCreated(t, ks)),
+	}}
+	util.AssertDeepEqual(t, getter.names("Gateway.networking.istio.io"), []string{"knative-serving/tenant-a-local", "tenant-a/tenant-a"})
+
+	// Removing a Gateway from the spec deletes it, also in a namespace the owner reference cannot reach.
+	ks.Spec.Ingress.Istio.Gateways = ks.Spec.Ingress.Istio.Gateways[1:]
+	manifest := renderCreated(t, ks)
+	if err := deleteCreatedResources(context.TODO(), getter, ks, &manifest); err != nil {
+		t.Fatalf("deleteCreatedResources() = %v", err)
+	}
+	util.AssertDeepEqual(t, getter.names("Gateway.networking.istio.io"), []string{"knative-serving/tenant-a-local"})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+)
+
+// customPlugin installs the networking layer from the manifests of spec.ingress.custom.
+type customPlugin struct{}
+
+func (customPlugin) Name() string { return "custom" }
+
+func (customPlugin) Enabled(ks *v1beta1.KnativeServing) bool {
+	return ks.Spec.Ingress != nil && ks.Spec.Ingress.Custom.Enabled
+}
+
+func (customPlugin) ManifestPaths(_, version string, ks *v1beta1.KnativeServing) []string {
+	paths := make([]string, 0, len(ks.Spec.Ingress.Custom.Manifests))
+	for _, m := range ks.Spec.Ingress.Custom.Manifests {
+		paths = append(paths, strings.ReplaceAll(m.Url, common.VersionVariable, version))
+	}
+	return paths
+}
+
+func (customPlugin) Transformers(context.Context, *v1beta1.KnativeServing) []mf.Transformer {
+	return nil
+}
+
+func (customPlugin) IngressClass(ks *v1beta1.KnativeServing) string {
+	return ks.Spec.Ingress.Custom.IngressClass
+}
+
+func (customPlugin) CheckReady(context.Context, *mf.Manifest, *v1beta1.KnativeServing) error {
+	return nil
+}
@@ -18,11 +18,194 @@ package ingress
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	mf "github.com/manifestival/manifestival"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
 )
 
-func gatewayAPITransformers(_ context.Context, _ *v1beta1.KnativeServing) []mf.Transformer {
+const gatewayAPIVersion = "gateway.networking.k8s.io/v1"
+
+// gatewayAPIPlugin installs net-gateway-api.
+type gatewayAPIPlugin struct{}
+
+func (gatewayAPIPlugin) Name() string { return "gateway-api" }
+
+func (gatewayAPIPlugin) Enabled(ks *v1beta1.KnativeServing) bool {
+	return ks.Spec.Ingress != nil && ks.Spec.Ingress.GatewayAPI.Enabled
+}
+
+func (p gatewayAPIPlugin) ManifestPaths(ingressDir, _ string, _ *v1beta1.KnativeServing) []string {
+	return kodataPath(ingressDir, p)
+}
+
+func (gatewayAPIPlugin) Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
+	return gatewayAPITransformers(ctx, ks)
+}
+
+func (gatewayAPIPlugin) IngressClass(*v1beta1.KnativeServing) string {
+	return "gateway-api.ingress.networking.knative.dev"
+}
+
+// CheckReady verifies that the Gateway API, which net-gateway-api does not ship, is installed, and
+// that the GatewayClasses of the configured Gateways exist.
+func (gatewayAPIPlugin) CheckReady(_ context.Context, manifest *mf.Manifest, ks *v1beta1.KnativeServing) error {
+	if err := common.CheckCRDs(manifest, "gateways.gateway.networking.k8s.io", "httproutes.gateway.networking.k8s.io"); err != nil {
+		return err
+	}
+	for _, g := range configuredGateways(ks) {
+		class := &unstructured.Unstructured{}
+		class.SetAPIVersion(gatewayAPIVersion)
+		class.SetKind("GatewayClass")
+		class.SetName(g.Class)
+		if _, err := manifest.Client.Get(class); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("the GatewayClass %s of the Gateway %s does not exist", g.Class, g.Gateway)
+			}
+			return err
+		}
+	}
 	return nil
 }
+
+// ResourceKinds returns the kind of the created Gateways.
+func (gatewayAPIPlugin) ResourceKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{schema.FromAPIVersionAndKind(gatewayAPIVersion, "Gateway")}
+}
+
+// Resources renders the Gateways, which the operator is asked to create.
+func (gatewayAPIPlugin) Resources(_ context.Context, ks *v1beta1.KnativeServing) ([]unstructured.Unstructured, error) {
+	var resources []unstructured.Unstructured
+	for _, g := range configuredGateways(ks) {
+		if !g.Create {
+			continue
+		}
+		resources = append(resources, renderGateway(g))
+	}
+	return resources, nil
+}
+
+func gatewayAPITransformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
+	if len(configuredGateways(ks)) == 0 {
+		return nil
+	}
+	resources, _ := gatewayAPIPlugin{}.Resources(ctx, ks)
+	return []mf.Transformer{configGatewayTransform(ks), createdResourcesTransform(ks, resources)}
+}
+
+// configuredGateways returns the external and the local Gateways of spec.ingress.gateway-api.
+func configuredGateways(ks *v1beta1.KnativeServing) []*base.GatewayAPIGateway {
+	if ks.Spec.Ingress == nil {
+		return nil
+	}
+	var gateways []*base.GatewayAPIGateway
+	for _, g := range []*base.GatewayAPIGateway{ks.Spec.Ingress.GatewayAPI.ExternalGateway, ks.Spec.Ingress.GatewayAPI.LocalGateway} {
+		if g != nil {
+			gateways = append(gateways, g)
+		}
+	}
+	return gateways
+}
+
+// gatewayEntry is an entry of the external-gateways and local-gateways of config-gateway.
+type gatewayEntry struct {
+	Class                string   `json:"class"`
+	Gateway              string   `json:"gateway"`
+	Service              string   `json:"service,omitempty"`
+	SupportedFeatures    []string `json:"supported-features,omitempty"`
+	ProxyProtocolEnabled bool     `json:"proxy-protocol-enabled,omitempty"`
+}
+
+// configGatewayTransform writes the configured Gateways to the config-gateway ConfigMap, unless the
+// entries are set in spec.config.
+func configGatewayTransform(ks *v1beta1.KnativeServing) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != "config-gateway" {
+			return nil
+		}
+		gateways := ks.Spec.Ingress.GatewayAPI
+		for key, g := range map[string]*base.GatewayAPIGateway{
+			"external-gateways": gateways.ExternalGateway,
+			"local-gateways":    gateways.LocalGateway,
+		} {
+			if g == nil || configured(ks, "gateway", key) {
+				continue
+			}
+			data, err := yaml.Marshal([]gatewayEntry{{
+				Class:                g.Class,
+				Gateway:              g.Gateway,
+				Service:              g.Service,
+				SupportedFeatures:    g.SupportedFeatures,
+				ProxyProtocolEnabled: g.ProxyProtocolEnabled,
+			}})
+			if err != nil {
+				return err
+			}
+			if err := unstructured.SetNestedField(u.Object, string(data), "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// renderGateway renders the Gateway with its listeners.
+func renderGateway(g *base.GatewayAPIGateway) unstructured.Unstructured {
+	namespace, name, _ := strings.Cut(g.Gateway, "/")
+	listeners := g.Listeners
+	if len(listeners) == 0 {
+		listeners = []base.GatewayAPIListener{{Name: "http", Port: 80, Protocol: "HTTP"}}
+	}
+	specListeners := make([]interface{}, 0, len(listeners))
+	for _, l := range listeners {
+		protocol := l.Protocol
+		if protocol == "" {
+			protocol = "HTTP"
+		}
+		listener := map[string]interface{}{
+			"name":     l.Name,
+			"port":     int64(l.Port),
+			"protocol": protocol,
+			// net-gateway-api creates the HTTPRoutes in the namespaces of the Knative Services.
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{"from": "All"},
+			},
+		}
+		if l.Hostname != "" {
+			listener["hostname"] = l.Hostname
+		}
+		if len(l.CertificateRefs) > 0 {
+			refs := make([]interface{}, 0, len(l.CertificateRefs))
+			for _, ref := range l.CertificateRefs {
+				r := map[string]interface{}{"kind": "Secret", "group": "", "name": ref.Name}
+				if ref.Namespace != "" {
+					r["namespace"] = ref.Namespace
+				}
+				refs = append(refs, r)
+			}
+			listener["tls"] = map[string]interface{}{"mode": "Terminate", "certificateRefs": refs}
+		}
+		specListeners = append(specListeners, listener)
+	}
+
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"gatewayClassName": g.Class,
+			"listeners":        specListeners,
+		},
+	}}
+	u.SetAPIVersion(gatewayAPIVersion)
+	u.SetKind("Gateway")
+	u.SetName(name)
+	u.SetNamespace(namespace)
+	u.SetLabels(map[string]string{createdByLabel: createdByValue})
+	return u
+}
@@ -18,9 +18,18 @@ package ingress
 
 import (
 	"context"
+	"os"
 	"testing"
 
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
 	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
 	util "knative.dev/operator/pkg/reconciler/common/testing"
 )
 
@@ -29,3 +38,189 @@ func TestGatewayAPITransformers(t *testing.T) {
 	transformer := gatewayAPITransformers(context.TODO(), instance)
 	util.AssertEqual(t, len(transformer), 0)
 }
+
+func gatewayAPIServing() *servingv1beta1.KnativeServing {
+	return &servingv1beta1.KnativeServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "knative-serving", Namespace: "knative-serving"},
+		Spec: servingv1beta1.KnativeServingSpec{
+			CommonSpec: base.CommonSpec{Version: "1.9"},
+			Ingress: &servingv1beta1.IngressConfigs{
+				GatewayAPI: base.GatewayAPIIngressConfiguration{
+					Enabled: true,
+					ExternalGateway: &base.GatewayAPIGateway{
+						Class:             "envoy",
+						Gateway:           "envoy-gateway-system/knative-external",
+						Service:           "envoy-gateway-system/envoy-knative-external",
+						SupportedFeatures: []string{"HTTPRouteRequestTimeout"},
+						Create:            true,
+						Listeners: []base.GatewayAPIListener{{
+							Name: "http",
+							Port: 8080,
+						}, {
+							Name:            "https",
+							Port:            8443,
+							Protocol:        "HTTPS",
+							Hostname:        "*.example.com",
+							CertificateRefs: []base.GatewayAPICertificateRef{{Name: "wildcard", Namespace: "certs"}},
+						}},
+					},
+					LocalGateway: &base.GatewayAPIGateway{
+						Class:   "envoy",
+						Gateway: "knative-serving/knative-local",
+						Create:  true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConfigGatewayTransform(t *testing.T) {
+	ks := gatewayAPIServing()
+	ks.Spec.Config = base.ConfigMapData{"gateway": {"local-gateways": "[]"}}
+
+	cm := util.MakeUnstructured(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config-gateway", Namespace: "knative-serving"},
+		Data:       map[string]string{"local-gateways": "[]"},
+	})
+	for _, transformer := range gatewayAPITransformers(context.TODO(), ks) {
+		if err := transformer(&cm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	util.AssertDeepEqual(t, data, map[string]string{
+		"external-gateways": `- class: envoy
+  gateway: envoy-gateway-system/knative-external
+  service: envoy-gateway-system/envoy-knative-external
+  supported-features:
+  - HTTPRouteRequestTimeout
+`,
+		"local-gateways": "[]",
+	})
+}
+
+func TestGatewayAPIResources(t *testing.T) {
+	ks := gatewayAPIServing()
+	resources, err := gatewayAPIPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(resources), 2)
+
+	external := resources[0]
+	util.AssertEqual(t, external.GetNamespace(), "envoy-gateway-system")
+	util.AssertEqual(t, external.GetName(), "knative-external")
+	util.AssertEqual(t, external.GetLabels()[createdByLabel], createdByValue)
+	util.AssertDeepEqual(t, external.Object["spec"], map[string]interface{}{
+		"gatewayClassName": "envoy",
+		"listeners": []interface{}{
+			map[string]interface{}{
+				"name":          "http",
+				"port":          int64(8080),
+				"protocol":      "HTTP",
+				"allowedRoutes": map[string]interface{}{"namespaces": map[string]interface{}{"from": "All"}},
+			},
+			map[string]interface{}{
+				"name":          "https",
+				"port":          int64(8443),
+				"protocol":      "HTTPS",
+				"hostname":      "*.example.com",
+				"allowedRoutes": map[string]interface{}{"namespaces": map[string]interface{}{"from": "All"}},
+				"tls": map[string]interface{}{
+					"mode": "Terminate",
+					"certificateRefs": []interface{}{
+						map[string]interface{}{"kind": "Secret", "group": "", "name": "wildcard", "namespace": "certs"},
+					},
+				},
+			},
+		},
+	})
+
+	local := resources[1]
+	util.AssertEqual(t, local.GetNamespace(), "knative-serving")
+	listeners, _, _ := unstructured.NestedSlice(local.Object, "spec", "listeners")
+	util.AssertDeepEqual(t, listeners, []interface{}{map[string]interface{}{
+		"name":          "http",
+		"port":          int64(80),
+		"protocol":      "HTTP",
+		"allowedRoutes": map[string]interface{}{"namespaces": map[string]interface{}{"from": "All"}},
+	}})
+
+	ks.Spec.Ingress.GatewayAPI.ExternalGateway.Create = false
+	ks.Spec.Ingress.GatewayAPI.LocalGateway.Create = false
+	resources, err = gatewayAPIPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(resources), 0)
+}
+
+func TestCreatedGatewaysTransform(t *testing.T) {
+	ks := gatewayAPIServing()
+	resources, err := gatewayAPIPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := mf.InjectOwner(ks)
+	transformers := append([]mf.Transformer{mf.InjectNamespace("knative-serving"), owner}, gatewayAPITransformers(context.TODO(), ks)...)
+	manifest, err = manifest.Transform(transformers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	external, local := manifest.Resources()[0], manifest.Resources()[1]
+	util.AssertEqual(t, external.GetNamespace(), "envoy-gateway-system")
+	util.AssertEqual(t, len(external.GetOwnerReferences()), 0)
+	util.AssertEqual(t, local.GetNamespace(), "knative-serving")
+	util.AssertEqual(t, len(local.GetOwnerReferences()), 1)
+}
+
+func TestGatewayAPICheckReady(t *testing.T) {
+	client := fake.New()
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gateways.gateway.networking.k8s.io", "httproutes.gateway.networking.k8s.io"} {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName(name)
+		if err := client.Create(crd); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ks := gatewayAPIServing()
+	err = gatewayAPIPlugin{}.CheckReady(context.TODO(), &manifest, ks)
+	util.AssertEqual(t, err.Error(), "the GatewayClass envoy of the Gateway envoy-gateway-system/knative-external does not exist")
+
+	class := &unstructured.Unstructured{}
+	class.SetAPIVersion(gatewayAPIVersion)
+	class.SetKind("GatewayClass")
+	class.SetName("envoy")
+	if err := client.Create(class); err != nil {
+		t.Fatal(err)
+	}
+	if err := (gatewayAPIPlugin{}).CheckReady(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("CheckReady() = %v", err)
+	}
+}
+
+func TestAppendTargetIngressGateways(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+
+	manifest, _ := mf.ManifestFrom(mf.Slice{})
+	if err := AppendTargetIngress(context.TODO(), &manifest, gatewayAPIServing()); err != nil {
+		t.Fatalf("AppendTargetIngress() = %v", err)
+	}
+	gateways := manifest.Filter(mf.ByKind("Gateway"), mf.ByLabel(createdByLabel, createdByValue))
+	util.AssertEqual(t, len(gateways.Resources()), 2)
+}
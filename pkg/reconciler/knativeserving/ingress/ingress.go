@@ -18,12 +18,15 @@ package ingress
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	mf "github.com/manifestival/manifestival"
 	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
 	"knative.dev/operator/pkg/reconciler/common"
@@ -32,30 +35,18 @@ import (
 
 // Transformers returns a list of transformers based on the enabled ingresses
 func Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
-	if ks.Spec.Ingress == nil {
-		return istioTransformers(ctx, ks)
-	}
 	var transformers []mf.Transformer
-	if ks.Spec.Ingress.Istio.Enabled {
-		transformers = append(transformers, istioTransformers(ctx, ks)...)
-	}
-	if ks.Spec.Ingress.Kourier.Enabled {
-		transformers = append(transformers, kourierTransformers(ctx, ks)...)
-	}
-	if ks.Spec.Ingress.Contour.Enabled {
-		transformers = append(transformers, contourTransformers(ctx, ks)...)
-	}
-	if ks.Spec.Ingress.GatewayAPI.Enabled {
-		transformers = append(transformers, gatewayAPITransformers(ctx, ks)...)
+	for _, p := range EnabledPlugins(ks) {
+		transformers = append(transformers, p.Transformers(ctx, ks)...)
 	}
 	return transformers
 }
 
-func getIngress(path string) (mf.Manifest, error) {
+func getIngress(ctx context.Context, path string) (mf.Manifest, error) {
 	if path == "" {
 		return mf.Manifest{}, nil
 	}
-	return common.FetchManifest(path)
+	return common.FetchManifest(ctx, path)
 }
 
 // GetIngressPath returns the path of the ingress plugin manifests, selected
@@ -70,29 +61,9 @@ func GetIngressPath(version string, ks *v1beta1.KnativeServing) string {
 
 	// This line can make sure a valid available source version is returned.
 	ingressPath := filepath.Join(koDataDir, "ingress", sourceVersion)
-	if ks.Spec.Ingress == nil {
-		url := filepath.Join(ingressPath, "istio")
-		urls = append(urls, url)
-		return strings.Join(urls, common.COMMA)
-	}
-
-	if ks.Spec.Ingress.Istio.Enabled {
-		url := filepath.Join(ingressPath, "istio")
-		urls = append(urls, url)
-	}
-	if ks.Spec.Ingress.Contour.Enabled {
-		url := filepath.Join(ingressPath, "contour")
-		urls = append(urls, url)
+	for _, p := range EnabledPlugins(ks) {
+		urls = append(urls, p.ManifestPaths(ingressPath, version, ks)...)
 	}
-	if ks.Spec.Ingress.Kourier.Enabled {
-		url := filepath.Join(ingressPath, "kourier")
-		urls = append(urls, url)
-	}
-	if ks.Spec.Ingress.GatewayAPI.Enabled {
-		url := filepath.Join(ingressPath, "gateway-api")
-		urls = append(urls, url)
-	}
-
 	return strings.Join(urls, common.COMMA)
 }
 
@@ -100,10 +71,13 @@ func GetIngressPath(version string, ks *v1beta1.KnativeServing) string {
 func AppendTargetIngress(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	version := common.TargetVersion(instance)
 	ingressPath := GetIngressPath(version, servingcommon.ConvertToKS(instance))
-	m, err := getIngress(ingressPath)
+	m, err := getIngress(ctx, ingressPath)
 	if err == nil {
 		*manifest = manifest.Append(m)
 	}
+	if err := appendPluginResources(ctx, manifest, servingcommon.ConvertToKS(instance)); err != nil {
+		return err
+	}
 	if len(instance.GetSpec().GetManifests()) != 0 {
 		// If spec.manifests is not empty, it is possible that the eventing source is not available with the
 		// specified version. The user can specify the eventing source link in the spec.manifests.
@@ -119,7 +93,7 @@ func AppendInstalledIngresses(ctx context.Context, manifest *mf.Manifest, instan
 		version = common.TargetVersion(instance)
 	}
 	ingressPath := GetIngressPath(version, servingcommon.ConvertToKS(instance))
-	m, err := getIngress(ingressPath)
+	m, err := getIngress(ctx, ingressPath)
 	if err == nil {
 		*manifest = manifest.Append(m)
 	}
@@ -130,3 +104,82 @@ func AppendInstalledIngresses(ctx context.Context, manifest *mf.Manifest, instan
 	// even if the ingress is not available.
 	return nil
 }
+
+// IngressClassTransform sets the ingress-class of the config-network ConfigMap to the ingress class
+// of the enabled networking layer, unless spec.config sets it. Nothing is changed if several
+// networking layers are enabled, since only the configuration can tell which one is the default.
+func IngressClassTransform(ks *v1beta1.KnativeServing) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != "config-network" {
+			return nil
+		}
+		if configured(ks, "network", "ingress-class") || configured(ks, "network", "ingress.class") {
+			return nil
+		}
+		plugins := EnabledPlugins(ks)
+		if len(plugins) != 1 || plugins[0].IngressClass(ks) == "" {
+			return nil
+		}
+		return unstructured.SetNestedField(u.Object, plugins[0].IngressClass(ks), "data", "ingress-class")
+	}
+}
+
+// configured returns true if spec.config sets the key of the ConfigMap, with or without the
+// optional "config-" prefix of its name.
+func configured(ks *v1beta1.KnativeServing, name, key string) bool {
+	for _, n := range []string{name, "config-" + name} {
+		if _, ok := ks.Spec.GetConfig()[n][key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIngresses verifies that the enabled networking layers can work in the target cluster, and
+// marks the dependencies of the KnativeServing missing if they cannot.
+func CheckIngresses(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
+	ks := servingcommon.ConvertToKS(instance)
+	for _, p := range EnabledPlugins(ks) {
+		if err := p.CheckReady(ctx, manifest, ks); err != nil {
+			msg := fmt.Sprintf("the %s ingress is not ready: %v", p.Name(), err)
+			instance.GetStatus().MarkDependencyMissing(msg)
+			return errors.New(msg)
+		}
+	}
+	instance.GetStatus().MarkDependenciesInstalled()
+	return nil
+}
+
+// appendPluginResources appends the resources rendered by the enabled plugins to the manifest, and
+// annotates them with the KnativeServing they are created for.
+func appendPluginResources(ctx context.Context, manifest *mf.Manifest, ks *v1beta1.KnativeServing) error {
+	var resources []unstructured.Unstructured
+	for _, p := range EnabledPlugins(ks) {
+		renderer, ok := p.(ResourceRenderer)
+		if !ok {
+			continue
+		}
+		r, err := renderer.Resources(ctx, ks)
+		if err != nil {
+			return fmt.Errorf("failed to render the resources of the %s ingress: %w", p.Name(), err)
+		}
+		for i := range r {
+			annotations := r[i].GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[createdForAnnotation] = createdFor(ks)
+			r[i].SetAnnotations(annotations)
+		}
+		resources = append(resources, r...)
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	m, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		return err
+	}
+	*manifest = manifest.Append(m)
+	return nil
+}
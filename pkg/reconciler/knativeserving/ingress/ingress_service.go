@@ -26,15 +26,18 @@ import (
 	"sigs.k8s.io/yaml"
 
 	mf "github.com/manifestival/manifestival"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
 )
 
-// localGateway defines the structure for the entries in the 'local-gateways' array.
+// localGatewayConfig defines the structure for the entries in the 'external-gateways' and
+// 'local-gateways' arrays.
 type localGatewayConfig struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Service   string `json:"service"`
+	Name          string                `json:"name"`
+	Namespace     string                `json:"namespace"`
+	Service       string                `json:"service"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 }
 
 // IngressServiceTransform pins the namespace to istio-system for the service named knative-local-gateway.
@@ -85,6 +88,7 @@ func updateIstioService(ks *v1beta1.KnativeServing, u *unstructured.Unstructured
 
 var (
 	knativeLocalGateway = "knative-local-gateway"
+	externalGateways    = "external-gateways"
 	localGateways       = "local-gateways"
 )
 
@@ -109,8 +113,8 @@ func fromStructuredGateways(data map[string]string) string {
 		return ""
 	}
 
-	var gateways []localGatewayConfig
-	if err := yaml.Unmarshal([]byte(raw), &gateways); err != nil {
+	gateways, err := structuredGateways(raw)
+	if err != nil {
 		return ""
 	}
 
@@ -123,6 +127,15 @@ func fromStructuredGateways(data map[string]string) string {
 	return ""
 }
 
+// structuredGateways parses the entries of the 'external-gateways' or 'local-gateways' arrays.
+func structuredGateways(raw string) ([]localGatewayConfig, error) {
+	var gateways []localGatewayConfig
+	if err := yaml.Unmarshal([]byte(raw), &gateways); err != nil {
+		return nil, err
+	}
+	return gateways, nil
+}
+
 func fromLegacyGateway(data map[string]string, ns string) string {
 	key := fmt.Sprintf("local-gateway.%s.%s", ns, knativeLocalGateway)
 
@@ -58,7 +58,7 @@ func TestTransformers(t *testing.T) {
 				},
 			},
 		},
-		expected: 4,
+		expected: 5,
 	}, {
 		name: "Available contour ingress",
 		instance: servingv1beta1.KnativeServing{
@@ -109,7 +109,7 @@ func TestTransformers(t *testing.T) {
 				},
 			},
 		},
-		expected: 5,
+		expected: 6,
 	}}
 
 	for _, tt := range tests {
@@ -148,7 +148,7 @@ func TestGetIngress(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			manifest, _ := mf.ManifestFrom(mf.Slice{})
-			m, err := getIngress(tt.ingressPath)
+			m, err := getIngress(context.TODO(), tt.ingressPath)
 			if err != nil {
 				util.AssertEqual(t, err.Error(), tt.expectedErr.Error())
 				util.AssertEqual(t, len(manifest.Resources()), 0)
@@ -243,6 +243,27 @@ func TestGetIngressPath(t *testing.T) {
 			},
 		},
 		expectedPath: os.Getenv(common.KoEnvKey) + "/ingress/1.8/gateway-api",
+	}, {
+		name:    "Available ingress path for custom ingress",
+		version: "1.8.1",
+		ks: &servingv1beta1.KnativeServing{
+			Spec: servingv1beta1.KnativeServingSpec{
+				Ingress: &servingv1beta1.IngressConfigs{
+					Kourier: base.KourierIngressConfiguration{
+						Enabled: true,
+					},
+					Custom: base.CustomIngressConfiguration{
+						Enabled: true,
+						Manifests: []base.Manifest{
+							{Url: "https://example.com/net-custom/v${VERSION}/crds.yaml"},
+							{Url: "https://example.com/net-custom/v${VERSION}/controller.yaml"},
+						},
+					},
+				},
+			},
+		},
+		expectedPath: os.Getenv(common.KoEnvKey) + "/ingress/1.8/kourier," +
+			"https://example.com/net-custom/v1.8.1/crds.yaml,https://example.com/net-custom/v1.8.1/controller.yaml",
 	}}
 
 	for _, tt := range tests {
@@ -27,9 +27,12 @@ import (
 	istionetworkingv1beta "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"istio.io/client-go/pkg/clientset/versioned/scheme"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/operator/pkg/apis/operator/base"
 	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
 	"knative.dev/pkg/logging"
+	"sigs.k8s.io/yaml"
 )
 
 // istioTLSModes maps the string TLS mode values accepted on the operator CRD
@@ -45,9 +48,62 @@ var istioTLSModes = map[string]istiov1beta1.ServerTLSSettings_TLSmode{
 	"ISTIO_MUTUAL":     istiov1beta1.ServerTLSSettings_ISTIO_MUTUAL,
 }
 
+// istioPlugin installs net-istio. It is enabled by default, when spec.ingress is not set.
+type istioPlugin struct{}
+
+func (istioPlugin) Name() string { return "istio" }
+
+func (istioPlugin) Enabled(ks *servingv1beta1.KnativeServing) bool {
+	return ks.Spec.Ingress == nil || ks.Spec.Ingress.Istio.Enabled
+}
+
+func (p istioPlugin) ManifestPaths(ingressDir, _ string, _ *servingv1beta1.KnativeServing) []string {
+	return kodataPath(ingressDir, p)
+}
+
+func (istioPlugin) Transformers(ctx context.Context, ks *servingv1beta1.KnativeServing) []mf.Transformer {
+	return istioTransformers(ctx, ks)
+}
+
+func (istioPlugin) IngressClass(*servingv1beta1.KnativeServing) string {
+	return "istio.ingress.networking.knative.dev"
+}
+
+func (istioPlugin) CheckReady(_ context.Context, manifest *mf.Manifest, _ *servingv1beta1.KnativeServing) error {
+	if err := common.CheckCRDs(manifest, "gateways.networking.istio.io"); err != nil {
+		return fmt.Errorf("please install istio or disable the istio ingress plugin: %w", err)
+	}
+	return nil
+}
+
+// ResourceKinds returns the kind of the additional Gateways.
+func (istioPlugin) ResourceKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{{Group: "networking.istio.io", Version: "v1beta1", Kind: "Gateway"}}
+}
+
+// Resources renders the additional Gateways of spec.ingress.istio.gateways.
+func (istioPlugin) Resources(ctx context.Context, ks *servingv1beta1.KnativeServing) ([]unstructured.Unstructured, error) {
+	logger := logging.FromContext(ctx)
+	gateways := istioGateways(ks)
+	resources := make([]unstructured.Unstructured, 0, len(gateways))
+	for i := range gateways {
+		u, err := renderIstioGateway(ks, &gateways[i], logger)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, u)
+	}
+	return resources, nil
+}
+
 func istioTransformers(ctx context.Context, instance *servingv1beta1.KnativeServing) []mf.Transformer {
 	logger := logging.FromContext(ctx)
-	return []mf.Transformer{gatewayTransform(instance, logger)}
+	transformers := []mf.Transformer{gatewayTransform(instance, logger)}
+	if len(istioGateways(instance)) > 0 {
+		resources, _ := istioPlugin{}.Resources(ctx, instance)
+		transformers = append(transformers, configIstioTransform(instance), createdResourcesTransform(instance, resources))
+	}
+	return transformers
 }
 
 func gatewayTransform(instance *servingv1beta1.KnativeServing, log *zap.SugaredLogger) mf.Transformer {
@@ -108,6 +164,120 @@ func localGateway(instance *servingv1beta1.KnativeServing) *base.IstioGatewayOve
 	return nil
 }
 
+// istioGateways returns the additional Gateways of spec.ingress.istio.
+func istioGateways(instance *servingv1beta1.KnativeServing) []base.IstioGateway {
+	if instance.Spec.Ingress == nil {
+		return nil
+	}
+	return instance.Spec.Ingress.Istio.Gateways
+}
+
+// istioGatewayNamespace returns the namespace of the additional Gateway.
+func istioGatewayNamespace(instance *servingv1beta1.KnativeServing, g *base.IstioGateway) string {
+	if g.Namespace != "" {
+		return g.Namespace
+	}
+	return instance.GetNamespace()
+}
+
+// renderIstioGateway renders the additional Gateway, from the defaults of the built-in Gateways
+// overridden with its selector and servers.
+func renderIstioGateway(instance *servingv1beta1.KnativeServing, g *base.IstioGateway, log *zap.SugaredLogger) (unstructured.Unstructured, error) {
+	port := uint32(80)
+	if g.Local {
+		port = 8081
+	}
+	gateway := &istionetworkingv1beta.Gateway{}
+	gateway.SetName(g.Name)
+	gateway.SetNamespace(istioGatewayNamespace(instance, g))
+	gateway.SetLabels(map[string]string{createdByLabel: createdByValue})
+	gateway.Spec.Selector = map[string]string{"istio": "ingressgateway"}
+	gateway.Spec.Servers = []*istiov1beta1.Server{{
+		Port:  &istiov1beta1.Port{Number: port, Name: "http", Protocol: "HTTP"},
+		Hosts: []string{"*"},
+	}}
+	u := unstructured.Unstructured{}
+	if err := updateIstioGateway(&g.IstioGatewayOverride, gateway, log); err != nil {
+		return u, err
+	}
+	if err := scheme.Scheme.Convert(gateway, &u, nil); err != nil {
+		return u, err
+	}
+	u.SetAPIVersion("networking.istio.io/v1beta1")
+	u.SetKind("Gateway")
+	return u, nil
+}
+
+// configIstioTransform adds the additional Gateways to the external-gateways and local-gateways
+// entries of the config-istio ConfigMap. The entries keep the Gateways set in spec.config, or
+// the built-in Gateways, which are the defaults of net-istio.
+func configIstioTransform(instance *servingv1beta1.KnativeServing) mf.Transformer {
+	builtIn := map[string]localGatewayConfig{
+		externalGateways: {
+			Name:      "knative-ingress-gateway",
+			Namespace: instance.GetNamespace(),
+			Service:   "istio-ingressgateway.istio-system.svc.cluster.local",
+		},
+		localGateways: {
+			Name:      knativeLocalGateway,
+			Namespace: instance.GetNamespace(),
+			Service:   "knative-local-gateway.istio-system.svc.cluster.local",
+		},
+	}
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != "config-istio" {
+			return nil
+		}
+		data, _, err := unstructured.NestedStringMap(u.Object, "data")
+		if err != nil {
+			return err
+		}
+		for key := range data {
+			// net-istio rejects the legacy format together with the structured one.
+			if strings.HasPrefix(key, "gateway.") || strings.HasPrefix(key, "local-gateway.") {
+				return fmt.Errorf("the Istio gateways cannot be added to config-istio, which sets the legacy entry %q", key)
+			}
+		}
+		for key, local := range map[string]bool{externalGateways: false, localGateways: true} {
+			gateways := []localGatewayConfig{builtIn[key]}
+			if raw, ok := data[key]; ok {
+				if gateways, err = structuredGateways(raw); err != nil {
+					return fmt.Errorf("failed to parse %s of config-istio: %w", key, err)
+				}
+			}
+			for _, g := range istioGateways(instance) {
+				namespace := istioGatewayNamespace(instance, &g)
+				if g.Local != local || hasGateway(gateways, g.Name, namespace) {
+					continue
+				}
+				gateways = append(gateways, localGatewayConfig{
+					Name:          g.Name,
+					Namespace:     namespace,
+					Service:       g.Service,
+					LabelSelector: g.LabelSelector,
+				})
+			}
+			raw, err := yaml.Marshal(gateways)
+			if err != nil {
+				return err
+			}
+			if err := unstructured.SetNestedField(u.Object, string(raw), "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func hasGateway(gateways []localGatewayConfig, name, namespace string) bool {
+	for _, g := range gateways {
+		if g.Name == name && g.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func updateIstioGateway(override *base.IstioGatewayOverride, gateway *istionetworkingv1beta.Gateway, log *zap.SugaredLogger) error {
 	if override != nil && len(override.Selector) > 0 {
 		log.Debugw("Updating Gateway", "name", gateway.GetName(), "gatewayOverrides", override)
@@ -17,14 +17,18 @@ limitations under the License.
 package ingress
 
 import (
+	"context"
 	"testing"
 
+	mf "github.com/manifestival/manifestival"
 	"go.uber.org/zap"
 	istiov1alpha3 "istio.io/api/networking/v1alpha3"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"istio.io/client-go/pkg/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/operator/pkg/apis/operator/base"
 	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
@@ -313,3 +317,116 @@ func makeUnstructuredGatewayAlpha(name string, selector map[string]string, serve
 
 	return result
 }
+
+func istioGatewaysServing() *servingv1beta1.KnativeServing {
+	return &servingv1beta1.KnativeServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "knative-serving", Namespace: "knative-serving"},
+		Spec: servingv1beta1.KnativeServingSpec{
+			Ingress: &servingv1beta1.IngressConfigs{
+				Istio: base.IstioIngressConfiguration{
+					Enabled: true,
+					Gateways: []base.IstioGateway{{
+						Name:          "tenant-a",
+						Namespace:     "tenant-a",
+						Service:       "tenant-a-ingressgateway.istio-system.svc.cluster.local",
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+						IstioGatewayOverride: base.IstioGatewayOverride{
+							Selector: map[string]string{"istio": "tenant-a-ingressgateway"},
+						},
+					}, {
+						Name:          "tenant-a-local",
+						Local:         true,
+						Service:       "tenant-a-local-gateway.istio-system.svc.cluster.local",
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestIstioGatewayResources(t *testing.T) {
+	ks := istioGatewaysServing()
+	resources, err := istioPlugin{}.Resources(context.TODO(), ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transformers := append([]mf.Transformer{mf.InjectNamespace("knative-serving"), mf.InjectOwner(ks)}, istioTransformers(context.TODO(), ks)...)
+	manifest, err = manifest.Transform(transformers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	util.AssertEqual(t, len(manifest.Resources()), 2)
+
+	external, local := manifest.Resources()[0], manifest.Resources()[1]
+	util.AssertEqual(t, external.GetNamespace(), "tenant-a")
+	util.AssertEqual(t, len(external.GetOwnerReferences()), 0)
+	util.AssertEqual(t, local.GetNamespace(), "knative-serving")
+	util.AssertEqual(t, len(local.GetOwnerReferences()), 1)
+
+	gateway := &istionetworkingv1beta1.Gateway{}
+	if err := scheme.Scheme.Convert(&external, gateway, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertDeepEqual(t, gateway.Spec.Selector, map[string]string{"istio": "tenant-a-ingressgateway"})
+	util.AssertEqual(t, gateway.Spec.Servers[0].Port.Number, uint32(80))
+	if err := scheme.Scheme.Convert(&local, gateway, nil); err != nil {
+		t.Fatal(err)
+	}
+	util.AssertDeepEqual(t, gateway.Spec.Selector, map[string]string{"istio": "ingressgateway"})
+	util.AssertEqual(t, gateway.Spec.Servers[0].Port.Number, uint32(8081))
+}
+
+func TestConfigIstioTransform(t *testing.T) {
+	configIstio := func(data map[string]string) unstructured.Unstructured {
+		return util.MakeUnstructured(t, &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "config-istio", Namespace: "knative-serving"},
+			Data:       data,
+		})
+	}
+
+	ks := istioGatewaysServing()
+	cm := configIstio(map[string]string{
+		"local-gateways": `- name: knative-local-gateway
+  namespace: knative-serving
+  service: knative-local-gateway.mesh.svc.cluster.local
+`,
+	})
+	if err := configIstioTransform(ks)(&cm); err != nil {
+		t.Fatal(err)
+	}
+	data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	util.AssertDeepEqual(t, data, map[string]string{
+		"external-gateways": `- name: knative-ingress-gateway
+  namespace: knative-serving
+  service: istio-ingressgateway.istio-system.svc.cluster.local
+- labelSelector:
+    matchLabels:
+      tenant: a
+  name: tenant-a
+  namespace: tenant-a
+  service: tenant-a-ingressgateway.istio-system.svc.cluster.local
+`,
+		"local-gateways": `- name: knative-local-gateway
+  namespace: knative-serving
+  service: knative-local-gateway.mesh.svc.cluster.local
+- labelSelector:
+    matchLabels:
+      tenant: a
+  name: tenant-a-local
+  namespace: knative-serving
+  service: tenant-a-local-gateway.istio-system.svc.cluster.local
+`,
+	})
+
+	cm = configIstio(map[string]string{
+		"gateway.knative-serving.knative-ingress-gateway": "istio-ingressgateway.istio-system.svc.cluster.local",
+	})
+	err := configIstioTransform(ks)(&cm)
+	util.AssertEqual(t, err.Error(), `the Istio gateways cannot be added to config-istio, which sets the legacy entry "gateway.knative-serving.knative-ingress-gateway"`)
+}
@@ -19,6 +19,7 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	mf "github.com/manifestival/manifestival"
@@ -37,16 +38,44 @@ const (
 	kourierGatewayDeploymentNames = "3scale-kourier-gateway"
 	kourierDefaultNamespace       = "knative-serving"
 	kourierBootstrapDataKey       = "envoy-bootstrap.yaml"
+	kourierConfigMapName          = "config-kourier"
 )
 
 var kourierControllerDeploymentNames = sets.NewString("3scale-kourier-control", "net-kourier-controller")
 
+// kourierPlugin installs net-kourier.
+type kourierPlugin struct{}
+
+func (kourierPlugin) Name() string { return "kourier" }
+
+func (kourierPlugin) Enabled(ks *v1beta1.KnativeServing) bool {
+	return ks.Spec.Ingress != nil && ks.Spec.Ingress.Kourier.Enabled
+}
+
+func (p kourierPlugin) ManifestPaths(ingressDir, _ string, _ *v1beta1.KnativeServing) []string {
+	return kodataPath(ingressDir, p)
+}
+
+func (kourierPlugin) Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer {
+	return kourierTransformers(ctx, ks)
+}
+
+func (kourierPlugin) IngressClass(*v1beta1.KnativeServing) string {
+	return "kourier.ingress.networking.knative.dev"
+}
+
+// CheckReady returns nil, net-kourier ships with its gateway.
+func (kourierPlugin) CheckReady(context.Context, *mf.Manifest, *v1beta1.KnativeServing) error {
+	return nil
+}
+
 func kourierTransformers(_ context.Context, instance *v1beta1.KnativeServing) []mf.Transformer {
 	return []mf.Transformer{
 		replaceGatewayNamespace(),
 		replaceBootstrapNamespace(),
 		configureGatewayService(instance),
 		configureBootstrapConfigMap(instance),
+		configureKourierConfigMap(instance),
 	}
 }
 
@@ -191,3 +220,45 @@ func configureGatewayServiceTypeNodePort(instance *v1beta1.KnativeServing, svc *
 		svc.Spec.Ports[i] = v
 	}
 }
+
+// configureKourierConfigMap writes the TLS, external authorization, tracing and PROXY protocol
+// settings of the Kourier gateway to the config-kourier ConfigMap, unless they are set in
+// spec.config. The settings of the ConfigMap take precedence over the environment variables of
+// the controller, and are pushed to the gateway by the controller over xDS, including the
+// certificates it reads from the secrets, so the gateway Deployment is not changed.
+func configureKourierConfigMap(instance *v1beta1.KnativeServing) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" || u.GetName() != kourierConfigMapName {
+			return nil
+		}
+		kourier := instance.Spec.Ingress.Kourier
+		data := map[string]string{
+			"cluster-cert-secret": kourier.ClusterCertSecret,
+		}
+		if kourier.CertsSecret != "" {
+			data["certs-secret-namespace"], data["certs-secret-name"], _ = strings.Cut(kourier.CertsSecret, "/")
+		}
+		if kourier.ExtAuthz != nil {
+			data["extauthz-host"] = kourier.ExtAuthz.Host
+			data["extauthz-protocol"] = kourier.ExtAuthz.Protocol
+			data["extauthz-failure-mode-allow"] = strconv.FormatBool(kourier.ExtAuthz.FailureModeAllow)
+		}
+		if kourier.Tracing != nil {
+			data["tracing-endpoint"] = kourier.Tracing.Endpoint
+			data["tracing-protocol"] = kourier.Tracing.Protocol
+			data["tracing-sampling-rate"] = kourier.Tracing.SamplingRate
+		}
+		if kourier.EnableProxyProtocol {
+			data["enable-proxy-protocol"] = "true"
+		}
+		for key, value := range data {
+			if value == "" || configured(instance, "kourier", key) {
+				continue
+			}
+			if err := unstructured.SetNestedField(u.Object, value, "data", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -219,6 +219,53 @@ func TestReplaceBootstrapNamespace(t *testing.T) {
 	}
 }
 
+func TestConfigureKourierConfigMap(t *testing.T) {
+	instance := servingInstance(servingNamespace, "", "", "")
+	instance.Spec.Ingress.Kourier.ClusterCertSecret = "cluster-certs"
+	instance.Spec.Ingress.Kourier.CertsSecret = "certs/wildcard"
+	instance.Spec.Ingress.Kourier.ExtAuthz = &base.KourierExtAuthzConfiguration{
+		Host:             "my-auth.auth:2222",
+		Protocol:         "http",
+		FailureModeAllow: true,
+	}
+	instance.Spec.Ingress.Kourier.Tracing = &base.KourierTracingConfiguration{
+		Endpoint:     "http://otel-collector.observability.svc:4317",
+		SamplingRate: "0.1",
+	}
+	instance.Spec.Ingress.Kourier.EnableProxyProtocol = true
+	instance.Spec.Config = base.ConfigMapData{"config-kourier": {"tracing-sampling-rate": "0.5"}}
+
+	manifest, err := mf.NewManifest("testdata/kodata/ingress/1.9/kourier/kourier.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	manifest, err = manifest.Transform(configureKourierConfigMap(instance))
+	if err != nil {
+		t.Fatalf("Failed to transform manifest: %v", err)
+	}
+
+	configMaps := manifest.Filter(mf.ByKind("ConfigMap"), mf.ByName(kourierConfigMapName)).Resources()
+	util.AssertEqual(t, len(configMaps), 1)
+	data, _, _ := unstructured.NestedStringMap(configMaps[0].Object, "data")
+	for key, want := range map[string]string{
+		"cluster-cert-secret":         "cluster-certs",
+		"certs-secret-namespace":      "certs",
+		"certs-secret-name":           "wildcard",
+		"extauthz-host":               "my-auth.auth:2222",
+		"extauthz-protocol":           "http",
+		"extauthz-failure-mode-allow": "true",
+		"tracing-endpoint":            "http://otel-collector.observability.svc:4317",
+		"enable-proxy-protocol":       "true",
+	} {
+		util.AssertEqual(t, data[key], want)
+	}
+	// The sampling rate is set in spec.config, and the protocol is left to its default.
+	_, ok := data["tracing-sampling-rate"]
+	util.AssertEqual(t, ok, false)
+	_, ok = data["tracing-protocol"]
+	util.AssertEqual(t, ok, false)
+}
+
 func bootstrapConfigMap(t *testing.T, manifest mf.Manifest) *v1.ConfigMap {
 	t.Helper()
 
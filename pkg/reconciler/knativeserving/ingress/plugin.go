@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+// Plugin is a networking layer of Knative Serving. A networking layer is added by registering its
+// Plugin, and by adding its manifests to the kodata/ingress/<version>/<name> directories.
+type Plugin interface {
+	// Name returns the name of the plugin, which is also the name of its kodata directory.
+	Name() string
+	// Enabled returns true if the KnativeServing enables the networking layer.
+	Enabled(ks *v1beta1.KnativeServing) bool
+	// ManifestPaths returns the paths of the manifests of the networking layer for the version of
+	// Knative Serving, given the kodata directory of the ingresses of the version.
+	ManifestPaths(ingressDir, version string, ks *v1beta1.KnativeServing) []string
+	// Transformers returns the transformers of the manifests of the networking layer.
+	Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transformer
+	// IngressClass returns the ingress class of the networking layer, which is set as the
+	// ingress-class of the config-network ConfigMap, when the networking layer is the only one enabled.
+	IngressClass(ks *v1beta1.KnativeServing) string
+	// CheckReady returns an error if the networking layer cannot work in the cluster of the manifest,
+	// e.g. because the CRDs it depends on are missing.
+	CheckReady(ctx context.Context, manifest *mf.Manifest, ks *v1beta1.KnativeServing) error
+}
+
+// ResourceRenderer is implemented by the plugins, which render resources from the spec of the
+// KnativeServing, in addition to the resources of their manifests.
+type ResourceRenderer interface {
+	Resources(ctx context.Context, ks *v1beta1.KnativeServing) ([]unstructured.Unstructured, error)
+	// ResourceKinds returns the kinds of the rendered resources. The resources of these kinds, which
+	// are not rendered anymore, are deleted.
+	ResourceKinds() []schema.GroupVersionKind
+}
+
+const (
+	// createdByLabel marks the resources rendered by the operator from the spec, rather than read
+	// from the manifests.
+	createdByLabel = "operator.knative.dev/created-by"
+	createdByValue = "knative-operator"
+)
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   []Plugin
+)
+
+func init() {
+	Register(istioPlugin{})
+	Register(contourPlugin{})
+	Register(kourierPlugin{})
+	Register(gatewayAPIPlugin{})
+	Register(customPlugin{})
+}
+
+// Register adds the plugin to the registry. It panics if a plugin with the same name is registered.
+func Register(plugin Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	for _, p := range plugins {
+		if p.Name() == plugin.Name() {
+			panic(fmt.Sprintf("ingress plugin %q is already registered", plugin.Name()))
+		}
+	}
+	plugins = append(plugins, plugin)
+}
+
+// Plugins returns the registered plugins in the order of their registration.
+func Plugins() []Plugin {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	return append([]Plugin(nil), plugins...)
+}
+
+// EnabledPlugins returns the registered plugins enabled by the KnativeServing.
+func EnabledPlugins(ks *v1beta1.KnativeServing) []Plugin {
+	var enabled []Plugin
+	for _, p := range Plugins() {
+		if p.Enabled(ks) {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled
+}
+
+// kodataPath returns the path of the manifests of the plugin in the kodata directory.
+func kodataPath(ingressDir string, plugin Plugin) []string {
+	return []string{filepath.Join(ingressDir, plugin.Name())}
+}
+
+// createdResourcesTransform restores the namespaces of the resources rendered by a plugin, which
+// the common transformers set to the namespace of the KnativeServing. The owner references are
+// removed from the resources in other namespaces, as they are invalid across namespaces.
+func createdResourcesTransform(ks *v1beta1.KnativeServing, resources []unstructured.Unstructured) mf.Transformer {
+	key := func(u *unstructured.Unstructured) string {
+		return u.GetAPIVersion() + "/" + u.GetKind() + "/" + u.GetName()
+	}
+	namespaces := map[string]string{}
+	for i := range resources {
+		namespaces[key(&resources[i])] = resources[i].GetNamespace()
+	}
+	return func(u *unstructured.Unstructured) error {
+		if u.GetLabels()[createdByLabel] != createdByValue {
+			return nil
+		}
+		namespace, ok := namespaces[key(u)]
+		if !ok {
+			return nil
+		}
+		u.SetNamespace(namespace)
+		if namespace != ks.GetNamespace() {
+			u.SetOwnerReferences(nil)
+		}
+		return nil
+	}
+}
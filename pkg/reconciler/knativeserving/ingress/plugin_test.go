@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/manifestival/manifestival/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+// testPlugin is a networking layer, which is enabled by an annotation of the KnativeServing.
+type testPlugin struct{}
+
+func (testPlugin) Name() string { return "test" }
+
+func (testPlugin) Enabled(ks *servingv1beta1.KnativeServing) bool {
+	return ks.GetAnnotations()["test-ingress"] == "true"
+}
+
+func (p testPlugin) ManifestPaths(ingressDir, _ string, _ *servingv1beta1.KnativeServing) []string {
+	return kodataPath(ingressDir, p)
+}
+
+func (testPlugin) Transformers(context.Context, *servingv1beta1.KnativeServing) []mf.Transformer {
+	return []mf.Transformer{func(*unstructured.Unstructured) error { return nil }}
+}
+
+func (testPlugin) IngressClass(*servingv1beta1.KnativeServing) string {
+	return "test.ingress.networking.knative.dev"
+}
+
+func (testPlugin) CheckReady(context.Context, *mf.Manifest, *servingv1beta1.KnativeServing) error {
+	return nil
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+	registered := Plugins()
+	defer func() { plugins = registered }()
+
+	Register(testPlugin{})
+	ks := &servingv1beta1.KnativeServing{}
+	ks.SetAnnotations(map[string]string{"test-ingress": "true"})
+	ks.Spec.Ingress = &servingv1beta1.IngressConfigs{}
+	util.AssertEqual(t, GetIngressPath("1.9", ks), os.Getenv(common.KoEnvKey)+"/ingress/1.9/test")
+	util.AssertEqual(t, len(Transformers(context.TODO(), ks)), 1)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a plugin twice did not panic")
+		}
+	}()
+	Register(testPlugin{})
+}
+
+func TestEnabledPlugins(t *testing.T) {
+	names := func(ks *servingv1beta1.KnativeServing) []string {
+		var names []string
+		for _, p := range EnabledPlugins(ks) {
+			names = append(names, p.Name())
+		}
+		return names
+	}
+	util.AssertDeepEqual(t, names(&servingv1beta1.KnativeServing{}), []string{"istio"})
+	util.AssertDeepEqual(t, names(&servingv1beta1.KnativeServing{
+		Spec: servingv1beta1.KnativeServingSpec{Ingress: &servingv1beta1.IngressConfigs{}},
+	}), []string(nil))
+	util.AssertDeepEqual(t, names(&servingv1beta1.KnativeServing{
+		Spec: servingv1beta1.KnativeServingSpec{
+			Ingress: &servingv1beta1.IngressConfigs{
+				Istio:      base.IstioIngressConfiguration{Enabled: true},
+				Kourier:    base.KourierIngressConfiguration{Enabled: true},
+				Contour:    base.ContourIngressConfiguration{Enabled: true},
+				GatewayAPI: base.GatewayAPIIngressConfiguration{Enabled: true},
+				Custom:     base.CustomIngressConfiguration{Enabled: true},
+			},
+		},
+	}), []string{"istio", "contour", "kourier", "gateway-api", "custom"})
+}
+
+func TestIngressClassTransform(t *testing.T) {
+	kourier := &servingv1beta1.IngressConfigs{Kourier: base.KourierIngressConfiguration{Enabled: true}}
+	tests := []struct {
+		name     string
+		ingress  *servingv1beta1.IngressConfigs
+		config   base.ConfigMapData
+		expected string
+	}{{
+		name:     "default istio",
+		expected: "istio.ingress.networking.knative.dev",
+	}, {
+		name:     "kourier",
+		ingress:  kourier,
+		expected: "kourier.ingress.networking.knative.dev",
+	}, {
+		name: "custom",
+		ingress: &servingv1beta1.IngressConfigs{Custom: base.CustomIngressConfiguration{
+			Enabled:      true,
+			IngressClass: "custom.ingress.networking.knative.dev",
+		}},
+		expected: "custom.ingress.networking.knative.dev",
+	}, {
+		name:     "set by the config",
+		ingress:  kourier,
+		config:   base.ConfigMapData{"config-network": {"ingress.class": "istio.ingress.networking.knative.dev"}},
+		expected: "manifest.ingress.networking.knative.dev",
+	}, {
+		name: "several ingresses",
+		ingress: &servingv1beta1.IngressConfigs{
+			Istio:   base.IstioIngressConfiguration{Enabled: true},
+			Kourier: base.KourierIngressConfiguration{Enabled: true},
+		},
+		expected: "manifest.ingress.networking.knative.dev",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &servingv1beta1.KnativeServing{
+				Spec: servingv1beta1.KnativeServingSpec{
+					CommonSpec: base.CommonSpec{Config: tt.config},
+					Ingress:    tt.ingress,
+				},
+			}
+			cm := util.MakeUnstructured(t, &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: "config-network"},
+				Data:       map[string]string{"ingress-class": "manifest.ingress.networking.knative.dev"},
+			})
+			if err := IngressClassTransform(ks)(&cm); err != nil {
+				t.Fatalf("IngressClassTransform() = %v", err)
+			}
+			class, _, _ := unstructured.NestedString(cm.Object, "data", "ingress-class")
+			util.AssertEqual(t, class, tt.expected)
+		})
+	}
+}
+
+func TestCheckIngresses(t *testing.T) {
+	client := fake.New()
+	manifest, err := mf.ManifestFrom(mf.Slice{}, mf.UseClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := &servingv1beta1.KnativeServing{
+		Spec: servingv1beta1.KnativeServingSpec{
+			Ingress: &servingv1beta1.IngressConfigs{
+				Kourier:    base.KourierIngressConfiguration{Enabled: true},
+				GatewayAPI: base.GatewayAPIIngressConfiguration{Enabled: true},
+			},
+		},
+	}
+	ks.Status.InitializeConditions()
+
+	err = CheckIngresses(context.TODO(), &manifest, ks)
+	util.AssertEqual(t, err.Error(), "the gateway-api ingress is not ready: the CustomResourceDefinition gateways.gateway.networking.k8s.io is not installed")
+	util.AssertEqual(t, ks.Status.GetCondition(base.DependenciesInstalled).IsFalse(), true)
+
+	for _, name := range []string{"gateways.gateway.networking.k8s.io", "httproutes.gateway.networking.k8s.io"} {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName(name)
+		if err := client.Create(crd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := CheckIngresses(context.TODO(), &manifest, ks); err != nil {
+		t.Fatalf("CheckIngresses() = %v", err)
+	}
+	util.AssertEqual(t, ks.Status.GetCondition(base.DependenciesInstalled).IsTrue(), true)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 
@@ -44,6 +45,9 @@ import (
 type Reconciler struct {
 	// kubeClientSet allows us to talk to the k8s for core APIs
 	kubeClientSet kubernetes.Interface
+	// dynamicClients are used to apply the manifests server-side, and to plan the changes of the
+	// components in dry-run mode
+	dynamicClients *common.DynamicClients
 	// operatorClientSet allows us to configure operator objects
 	operatorClientSet clientset.Interface
 	// manifest is empty, but with a valid client and logger. all
@@ -64,7 +68,12 @@ var _ knsreconciler.Finalizer = (*Reconciler)(nil)
 // FinalizeKind removes all resources after deletion of a KnativeServing.
 func (r *Reconciler) FinalizeKind(ctx context.Context, original *v1beta1.KnativeServing) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
-	common.ClearCache()
+
+	// The resources created from the spec belong to this KnativeServing only, and those in other
+	// namespaces have no owner reference to it.
+	if err := r.deleteCreatedResources(ctx, original); err != nil {
+		logger.Error("Failed to delete the created resources", err)
+	}
 
 	kss, err := r.servingLister.List(labels.Everything())
 	if err != nil {
@@ -101,6 +110,27 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, original *v1beta1.Knative
 	return nil
 }
 
+// deleteCreatedResources deletes the resources created from the spec of the KnativeServing in its
+// target cluster.
+func (r *Reconciler) deleteCreatedResources(ctx context.Context, ks *v1beta1.KnativeServing) error {
+	var state common.ReconcileState
+	if ref := ks.Spec.GetClusterProfileRef(); ref != nil {
+		if r.clusterProvider == nil {
+			return fmt.Errorf("cluster provider not configured but clusterProfileRef is set")
+		}
+		clients, _, err := r.clusterProvider.Get(ctx, ref.Namespace+"/"+ref.Name)
+		if err != nil {
+			return err
+		}
+		state.RemoteClients = clients
+	}
+	getter, err := r.dynamicClients.ResourceGetter(&state)
+	if err != nil {
+		return err
+	}
+	return ingress.DeleteCreatedResources(ctx, getter, ks)
+}
+
 // ReconcileKind compares the actual state with the desired, and attempts to
 // converge the two.
 func (r *Reconciler) ReconcileKind(ctx context.Context, ks *v1beta1.KnativeServing) pkgreconciler.Event {
@@ -110,6 +140,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, ks *v1beta1.KnativeServi
 
 	logger.Infow("Reconciling KnativeServing", "status", ks.Status)
 
+	restore, err := common.ResolveVersion(ks, &ks.Spec.Version)
+	defer restore()
+	if err != nil {
+		ks.Status.MarkVersionMigrationNotEligible(err.Error())
+		return nil
+	}
+
 	if err := common.IsVersionValidMigrationEligible(ks); err != nil {
 		ks.Status.MarkVersionMigrationNotEligible(err.Error())
 		return nil
@@ -122,35 +159,99 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, ks *v1beta1.KnativeServi
 
 	var state common.ReconcileState
 
-	stages := common.Stages{
-		common.ResolveTargetCluster(r.clusterProvider, &state),
-		common.AppendTarget,
-		ingress.AppendTargetIngress,
-		security.AppendTargetSecurity,
-		common.AppendAdditionalManifests,
-		r.appendExtensionManifests,
-		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
-			return r.transform(ctx, manifest, comp, state.AnchorOwner)
-		},
-		manifests.Install,
-		manifests.SetManifestPaths,    // setting path right after applying manifests to populate paths
+	stages := common.Stages{common.ResolveTargetCluster(r.clusterProvider, &state)}
+	stages = append(stages, r.renderStages(&state)...)
+	if common.IsDryRun(ks) {
+		stages = append(stages, common.RecordPlan(r.kubeClientSet, r.dynamicClients, &state, r.installed))
+		manifest := r.manifest.Append()
+		if _, err := stages.Execute(ctx, &manifest, ks); err != nil {
+			return err
+		}
+		return pkgreconciler.NewEvent(corev1.EventTypeNormal, "DryRun", "Planned changes: %s", state.Plan.Summary())
+	}
+	stages = append(stages, common.Stages{
+		common.RecordRollbackTarget, // recording the installed release before the manifest paths are overwritten
+		ingress.CheckIngresses,
+		common.DetectDrift(&state),
+		common.ServerSideApply(r.dynamicClients, &state, common.ExcludeDrifted(&state, manifests.Install)),
+		manifests.SetManifestPaths, // setting path right after applying manifests to populate paths
+		ingress.PruneCreatedResources(r.dynamicClients, &state),
+		ingress.PatchEnvoyServices,
 		common.CheckWebhookDeployment, // Wait for webhook to be ready before creating Certificate resources
-		common.InstallWebhookDependentResources,
+		common.ServerSideApply(r.dynamicClients, &state, common.InstallWebhookDependentResources),
 		common.CheckDeployments,
+		common.CheckJobs,
 		common.MarkStatusSuccess,
+		common.RecordHistory,
 		common.DeleteObsoleteResources(ctx, ks, r.installed),
-	}
+	}...)
 	manifest := r.manifest.Append()
 	result, err := stages.Execute(ctx, &manifest, ks)
 	if err != nil {
 		return err
 	}
-	if result.DeploymentsNotReady && state.IsRemote() {
-		return controller.NewRequeueAfter(common.RemoteDeploymentsPollIntervalValue())
+	if !result.DeploymentsNotReady {
+		return nil
+	}
+	exceeded, left := common.ProgressDeadlineExceeded(ks)
+	if exceeded {
+		return r.rollback(ctx, ks, &manifest, &state)
+	}
+	if state.IsRemote() && (left == 0 || left > common.RemoteDeploymentsPollIntervalValue()) {
+		left = common.RemoteDeploymentsPollIntervalValue()
+	}
+	if left > 0 {
+		// Check the deployments again when the progress deadline of the upgrade is reached, at the latest.
+		return controller.NewRequeueAfter(left)
 	}
 	return nil
 }
 
+// rollback reinstalls the release recorded before the upgrade, and deletes the resources, which only the
+// release that failed to become ready contains.
+func (r *Reconciler) rollback(ctx context.Context, ks *v1beta1.KnativeServing, manifest *mf.Manifest, state *common.ReconcileState) error {
+	deployments, err := common.NotReadyDeployments(manifest)
+	if err != nil {
+		return err
+	}
+	failedVersion := common.TargetVersion(ks)
+	target := ks.Spec.Version
+	ks.Spec.Version = ks.Status.GetRollback().Version
+	defer func() { ks.Spec.Version = target }()
+
+	stages := common.Stages{
+		common.AppendRollbackManifests,
+		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+			return r.transform(ctx, manifest, comp, state.AnchorOwner)
+		},
+		common.ServerSideApply(r.dynamicClients, state, manifests.Install),
+		common.ServerSideApply(r.dynamicClients, state, common.InstallWebhookDependentResources),
+		// The status still points to the manifests of the failed release, so its resources are obsolete.
+		common.DeleteObsoleteResources(ctx, ks, r.installed),
+		common.MarkRolledBack(failedVersion, deployments),
+	}
+	// An empty manifest using the client of the target cluster
+	previous := manifest.Filter(mf.Any())
+	_, err = stages.Execute(ctx, &previous, ks)
+	return err
+}
+
+// renderStages returns the stages, which build the fully transformed manifest of the KnativeServing
+// without applying it.
+func (r *Reconciler) renderStages(state *common.ReconcileState) common.Stages {
+	return common.Stages{
+		common.AppendTarget,
+		ingress.AppendTargetIngress,
+		security.AppendTargetSecurity,
+		common.AppendAdditionalManifests,
+		r.appendExtensionManifests,
+		ingress.MarkEnvoyServices,
+		func(ctx context.Context, manifest *mf.Manifest, comp base.KComponent) error {
+			return r.transform(ctx, manifest, comp, state.AnchorOwner)
+		},
+	}
+}
+
 // transform mutates the passed manifest to one with common, component
 // and platform transformations applied
 func (r *Reconciler) transform(ctx context.Context, manifest *mf.Manifest, comp base.KComponent, anchorOwner mf.Owner) error {
@@ -165,7 +266,7 @@ func (r *Reconciler) transform(ctx context.Context, manifest *mf.Manifest, comp
 	)
 	extra = append(extra, r.extension.Transformers(instance)...)
 	extra = append(extra, ingress.Transformers(ctx, instance)...)
-	extra = append(extra, ingress.IngressServiceTransform(instance))
+	extra = append(extra, ingress.IngressServiceTransform(instance), ingress.IngressClassTransform(instance))
 	extra = append(extra, security.Transformers(ctx, instance)...)
 	return common.Transform(ctx, manifest, instance, extra...)
 }
@@ -182,7 +283,7 @@ func (r *Reconciler) installed(ctx context.Context, instance base.KComponent) (*
 	if len(paths) == 0 {
 		return nil, nil
 	}
-	installed, err := common.FetchManifestFromArray(paths)
+	installed, err := common.FetchManifestFromArray(ctx, paths)
 	if err != nil {
 		return &installed, err
 	}
@@ -34,7 +34,7 @@ import (
 // AppendTargetSecurity appends the manifests of the security guard to be installed
 func AppendTargetSecurity(ctx context.Context, manifest *mf.Manifest, instance base.KComponent) error {
 	version := common.TargetVersion(instance)
-	m, err := getSecurity(version, servingcommon.ConvertToKS(instance))
+	m, err := getSecurity(ctx, version, servingcommon.ConvertToKS(instance))
 
 	if err == nil {
 		*manifest = manifest.Append(m)
@@ -62,15 +62,25 @@ func Transformers(ctx context.Context, ks *v1beta1.KnativeServing) []mf.Transfor
 	return transformers
 }
 
-func getSecurity(version string, ks *v1beta1.KnativeServing) (mf.Manifest, error) {
+func getSecurity(ctx context.Context, version string, ks *v1beta1.KnativeServing) (mf.Manifest, error) {
+	sgPath, err := GetSecurityPath(version, ks)
+	if sgPath == "" || err != nil {
+		return mf.Manifest{}, err
+	}
+	return common.FetchManifest(ctx, sgPath)
+}
+
+// GetSecurityPath returns the path of the security guard manifests, selected by the Serving CR, or
+// an empty string if the security guard is not enabled.
+func GetSecurityPath(version string, ks *v1beta1.KnativeServing) (string, error) {
 	if ks.Spec.Security == nil || !ks.Spec.Security.SecurityGuard.Enabled {
 		// If no security option is defined, return an empty string.
-		return mf.Manifest{}, nil
+		return "", nil
 	}
 
 	// If we can not determine the version, append no security guard manifest.
 	if version == "" {
-		return mf.Manifest{}, nil
+		return "", nil
 	}
 	koDataDir := os.Getenv(common.KoEnvKey)
 
@@ -87,10 +97,9 @@ func getSecurity(version string, ks *v1beta1.KnativeServing) (mf.Manifest, error
 	// Find the specific security guard version via the hash map
 	sgVersion, ok := SecurityGuardVersion[common.SanitizeSemver(servingVersion)]
 	if !ok {
-		return mf.Manifest{}, fmt.Errorf("the current version of Knative Serving is %v. You need to install the "+
+		return "", fmt.Errorf("the current version of Knative Serving is %v. You need to install the "+
 			"version 1.8 or above to support the security guard", servingVersion)
 	}
 
-	sgPath := filepath.Join(koDataDir, "security-guard", sgVersion)
-	return common.FetchManifest(sgPath)
+	return filepath.Join(koDataDir, "security-guard", sgVersion), nil
 }
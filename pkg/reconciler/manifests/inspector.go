@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"context"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/broker"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/source"
+	"knative.dev/operator/pkg/reconciler/knativeserving/ingress"
+	"knative.dev/operator/pkg/reconciler/knativeserving/security"
+)
+
+// Inspector implements base.ReleaseInspector with the manifests bundled under the kodata directory.
+type Inspector struct{}
+
+var _ base.ReleaseInspector = Inspector{}
+
+// CheckVersion implements base.ReleaseInspector
+func (Inspector) CheckVersion(comp base.KComponent) error {
+	return common.ValidateTargetVersion(comp)
+}
+
+// TargetResources implements base.ReleaseInspector
+func (Inspector) TargetResources(ctx context.Context, comp base.KComponent) (*base.TargetResources, error) {
+	if len(comp.GetSpec().GetManifests()) != 0 || len(comp.GetSpec().GetAdditionalManifests()) != 0 {
+		// Manifests specified by URL are not fetched during admission.
+		return nil, nil
+	}
+	if ks, ok := comp.(*v1beta1.KnativeServing); ok && ks.Spec.Ingress != nil && ks.Spec.Ingress.Custom.Enabled {
+		// Neither are the manifests of a custom networking layer.
+		return nil, nil
+	}
+
+	var stages common.Stages
+	switch comp.(type) {
+	case *v1beta1.KnativeServing:
+		stages = common.Stages{
+			common.AppendTarget,
+			ingress.AppendTargetIngress,
+			security.AppendTargetSecurity,
+		}
+	case *v1beta1.KnativeEventing:
+		stages = common.Stages{
+			common.AppendTarget,
+			source.AppendTargetSources,
+			broker.AppendTargetBrokers,
+		}
+	default:
+		return nil, nil
+	}
+
+	manifest, _ := mf.ManifestFrom(mf.Slice{})
+	if _, err := stages.Execute(ctx, &manifest, comp); err != nil {
+		return nil, err
+	}
+
+	resources := &base.TargetResources{
+		ConfigMaps: sets.NewString(),
+		Workloads:  sets.NewString(),
+	}
+	for _, u := range manifest.Resources() {
+		switch u.GetKind() {
+		case "ConfigMap":
+			resources.ConfigMaps.Insert(u.GetName())
+		case "Deployment", "StatefulSet":
+			resources.Workloads.Insert(u.GetName())
+		case "Job":
+			// Jobs are overridden by their generate name.
+			if name := u.GetGenerateName(); name != "" {
+				resources.Workloads.Insert(name)
+			}
+		}
+	}
+	return resources, nil
+}
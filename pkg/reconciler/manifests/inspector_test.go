@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+	"knative.dev/operator/pkg/reconciler/common"
+	util "knative.dev/operator/pkg/reconciler/common/testing"
+)
+
+func TestInspectorTargetResources(t *testing.T) {
+	os.Setenv(common.KoEnvKey, "testdata/kodata")
+	defer os.Unsetenv(common.KoEnvKey)
+
+	tests := []struct {
+		name               string
+		instance           base.KComponent
+		expectedNil        bool
+		expectedConfigMaps []string
+		expectedWorkloads  []string
+	}{{
+		name: "Knative Eventing with sources",
+		instance: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "0.23.0",
+				},
+				Source: &v1beta1.SourceConfigs{
+					Redis: base.RedisSourceConfiguration{
+						Enabled: true,
+					},
+				},
+			},
+		},
+		expectedConfigMaps: []string{"config-br-defaults", "config-logging"},
+		expectedWorkloads:  []string{"eventing-controller", "eventing-webhook", "redis-controller-manager"},
+	}, {
+		name: "Knative Serving with kourier",
+		instance: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "1.9.0",
+				},
+				Ingress: &v1beta1.IngressConfigs{
+					Kourier: base.KourierIngressConfiguration{
+						Enabled: true,
+					},
+				},
+			},
+		},
+		expectedWorkloads: []string{"net-kourier-controller", "3scale-kourier-gateway"},
+	}, {
+		name: "Knative Serving with additional manifests",
+		instance: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "1.9.0",
+					AdditionalManifests: []base.Manifest{{
+						Url: "https://example.com/additional.yaml",
+					}},
+				},
+			},
+		},
+		expectedNil: true,
+	}, {
+		name: "Knative Serving with a custom ingress",
+		instance: &v1beta1.KnativeServing{
+			Spec: v1beta1.KnativeServingSpec{
+				CommonSpec: base.CommonSpec{
+					Version: "1.9.0",
+				},
+				Ingress: &v1beta1.IngressConfigs{
+					Custom: base.CustomIngressConfiguration{
+						Enabled: true,
+						Manifests: []base.Manifest{{
+							Url: "https://example.com/ingress/${VERSION}/ingress.yaml",
+						}},
+					},
+				},
+			},
+		},
+		expectedNil: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := Inspector{}.TargetResources(context.TODO(), tt.instance)
+			util.AssertEqual(t, err, nil)
+			util.AssertEqual(t, resources == nil, tt.expectedNil)
+			if resources == nil {
+				return
+			}
+			for _, name := range tt.expectedConfigMaps {
+				util.AssertEqual(t, resources.ConfigMaps.Has(name), true)
+			}
+			for _, name := range tt.expectedWorkloads {
+				util.AssertEqual(t, resources.Workloads.Has(name), true)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
 	"knative.dev/operator/pkg/reconciler/common"
+	"knative.dev/operator/pkg/reconciler/knativeeventing/broker"
 	"knative.dev/operator/pkg/reconciler/knativeeventing/source"
 	ksc "knative.dev/operator/pkg/reconciler/knativeserving/common"
 	"knative.dev/operator/pkg/reconciler/knativeserving/ingress"
@@ -42,14 +43,23 @@ func SetManifestPaths(ctx context.Context, manifest *mf.Manifest, instance base.
 	status := instance.GetStatus()
 	path := common.TargetManifestPathArray(instance)
 	version := common.TargetVersion(instance)
-	addedPath := ingress.GetIngressPath(version, ksc.ConvertToKS(instance))
+	addedPaths := []string{ingress.GetIngressPath(version, ksc.ConvertToKS(instance))}
 	switch instance.(type) {
 	case *v1beta1.KnativeEventing:
-		addedPath = source.GetSourcePath(version, source.ConvertToKE(instance))
+		ke := source.ConvertToKE(instance)
+		brokerPath := broker.GetBrokerPath(version, ke)
+		if broker.Bundled(brokerPath) != nil {
+			// spec.manifests provides the broker and channel manifests, which the operator does not
+			// bundle for the version, and the status must only record paths, which can be fetched.
+			brokerPath = ""
+		}
+		addedPaths = []string{source.GetSourcePath(version, ke), brokerPath}
 	}
-	if addedPath != "" {
-		path = append(path, addedPath)
+	for _, addedPath := range addedPaths {
+		if addedPath != "" {
+			path = append(path, addedPath)
+		}
 	}
-	status.SetManifests(path)
+	status.SetManifests(common.PinManifestPaths(path))
 	return nil
 }
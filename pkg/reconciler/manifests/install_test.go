@@ -72,6 +72,26 @@ func TestInstall(t *testing.T) {
 			},
 		},
 		expectedPath: []string{os.Getenv(common.KoEnvKey) + "/knative-eventing/0.23.0"},
+	}, {
+		name:    "Knative Eventing with a Kafka broker, which is not bundled",
+		version: "0.23.0",
+		instance: &v1beta1.KnativeEventing{
+			Spec: v1beta1.KnativeEventingSpec{
+				CommonSpec: base.CommonSpec{
+					Version:   "0.23.0",
+					Manifests: []base.Manifest{{Url: "testdata/kodata/knative-eventing/0.23.0"}},
+				},
+				Broker: &v1beta1.BrokerConfigs{
+					Kafka: base.KafkaBrokerConfiguration{
+						Enabled: true,
+					},
+				},
+			},
+			Status: v1beta1.KnativeEventingStatus{
+				Version: "0.23",
+			},
+		},
+		expectedPath: []string{"testdata/kodata/knative-eventing/0.23.0"},
 	}, {
 		name:    "Knative Serving with ingress",
 		version: "1.9.0",
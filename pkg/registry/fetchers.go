@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/mod/semver"
+	"knative.dev/operator/pkg/oci"
+	"knative.dev/operator/pkg/packages"
+)
+
+// GetReleases returns a release for every semver tag of the OCI repository. The assets of a release
+// are the layers of its artifact, and are pinned to the digests of the layers.
+func GetReleases(ctx context.Context, client *oci.Client, cfg packages.OCISource) ([]packages.Release, error) {
+	repo, err := name.NewRepository(strings.TrimPrefix(cfg.Repository, oci.Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI repository %q: %w", cfg.Repository, err)
+	}
+	tags, err := client.Tags(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	retval := []packages.Release{}
+	for _, tag := range tags {
+		tagName := tag
+		if !strings.HasPrefix(tagName, "v") {
+			tagName = "v" + tagName
+		}
+		if !semver.IsValid(tagName) {
+			continue
+		}
+		manifest, _, err := client.Manifest(ctx, repo.Tag(tag))
+		if err != nil {
+			return nil, err
+		}
+		retval = append(retval, makeRelease(repo, tagName, manifest))
+	}
+	return retval, nil
+}
+
+func makeRelease(repo name.Repository, tagName string, manifest *oci.Manifest) packages.Release {
+	retval := packages.Release{
+		Repo:    path.Base(repo.RepositoryStr()),
+		TagName: tagName,
+		Assets:  make([]packages.Asset, 0, len(manifest.Layers)),
+	}
+	retval.Created, _ = time.Parse(time.RFC3339, manifest.Annotations[oci.AnnotationCreated])
+	for _, layer := range manifest.Layers {
+		fileName := layer.Annotations[oci.AnnotationTitle]
+		if fileName == "" {
+			continue
+		}
+		retval.Assets = append(retval.Assets, packages.Asset{
+			Name:   fileName,
+			URL:    oci.BlobURL(repo, layer.Digest),
+			Digest: layer.Digest,
+			OCI:    true,
+		})
+	}
+	return retval
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature verifies detached signatures of blobs, as created by `cosign sign-blob`, either
+// with a public key or with a short-lived certificate issued to an OIDC identity.
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// oidIssuerV2 is the Fulcio extension recording the OIDC issuer as a DER-encoded UTF8String.
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+	// oidIssuer is the deprecated Fulcio extension recording the OIDC issuer as a raw string.
+	oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+)
+
+// Identity is the identity, which must have signed a blob with keyless signing.
+type Identity struct {
+	// Issuer is the OIDC issuer of the identity.
+	Issuer string
+	// Subject is the email or URI of the identity. If it is empty, SubjectRegExp must match it.
+	Subject       string
+	SubjectRegExp *regexp.Regexp
+}
+
+// VerifyWithKey verifies the signature of the data with the PEM-encoded public key. The signature may
+// be base64-encoded, as written by cosign.
+func VerifyWithKey(publicKey, data, sig []byte) error {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return errors.New("the public key is not PEM-encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the public key: %w", err)
+	}
+	return verify(key, data, decode(sig))
+}
+
+// VerifyKeyless verifies the signature of the data with the signing certificate, which must have been
+// issued to the identity by a certificate authority of the trusted roots. The certificate, which may
+// be base64-encoded, can be followed by its intermediate certificates.
+//
+// Signing certificates are short-lived, and their validity is checked at the time they were issued,
+// as there is no proof from a transparency log of the time the data was signed.
+func VerifyKeyless(identity Identity, trustedRoots, certificate, data, sig []byte) error {
+	chain, err := parseCertificates(certificate)
+	if err != nil {
+		return fmt.Errorf("failed to parse the signing certificate: %w", err)
+	}
+	if len(chain) == 0 {
+		chain, err = parseCertificates(decode(certificate))
+		if err != nil || len(chain) == 0 {
+			return errors.New("the signing certificate is not PEM-encoded")
+		}
+	}
+	trusted, err := parseCertificates(trustedRoots)
+	if err != nil {
+		return fmt.Errorf("failed to parse the trusted roots: %w", err)
+	}
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	for _, cert := range trusted {
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+			roots.AddCert(cert)
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	leaf := chain[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   leaf.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("the signing certificate is not trusted: %w", err)
+	}
+	if err := checkIdentity(identity, leaf); err != nil {
+		return err
+	}
+	return verify(leaf.PublicKey, data, decode(sig))
+}
+
+func checkIdentity(identity Identity, cert *x509.Certificate) error {
+	issuer := certificateIssuer(cert)
+	if issuer != identity.Issuer {
+		return fmt.Errorf("the signing certificate was issued to an identity of %q, not %q", issuer, identity.Issuer)
+	}
+	subjects := append([]string{}, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	for _, subject := range subjects {
+		if identity.Subject != "" && subject == identity.Subject {
+			return nil
+		}
+		if identity.Subject == "" && identity.SubjectRegExp != nil && identity.SubjectRegExp.MatchString(subject) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the signing certificate was issued to %v, which does not match the expected identity", subjects)
+}
+
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+func verify(key crypto.PublicKey, data, sig []byte) error {
+	digest := sha256.Sum256(data)
+	var ok bool
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, data, sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// decode returns the base64-decoded data, or the data itself if it is not base64-encoded.
+func decode(data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(trimmed)))
+	n, err := base64.StdEncoding.Decode(decoded, trimmed)
+	if err != nil {
+		return data
+	}
+	return decoded[:n]
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var manifest = []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n")
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+func TestVerifyWithKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     []byte
+		data    []byte
+		sig     []byte
+		wantErr string
+	}{{
+		name: "ecdsa",
+		key:  publicKeyPEM(t, key.Public()),
+		data: manifest,
+		sig:  signECDSA(t, key, manifest),
+	}, {
+		name: "ed25519 raw signature",
+		key:  publicKeyPEM(t, edPub),
+		data: manifest,
+		sig:  ed25519.Sign(edKey, manifest),
+	}, {
+		name:    "tampered data",
+		key:     publicKeyPEM(t, key.Public()),
+		data:    append([]byte("# tampered\n"), manifest...),
+		sig:     signECDSA(t, key, manifest),
+		wantErr: "invalid signature",
+	}, {
+		name:    "other key",
+		key:     publicKeyPEM(t, other.Public()),
+		data:    manifest,
+		sig:     signECDSA(t, key, manifest),
+		wantErr: "invalid signature",
+	}, {
+		name:    "invalid key",
+		key:     []byte("not a key"),
+		data:    manifest,
+		sig:     signECDSA(t, key, manifest),
+		wantErr: "not PEM-encoded",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyWithKey(test.key, test.data, test.sig)
+			checkError(t, err, test.wantErr)
+		})
+	}
+}
+
+// certificate issues a code signing certificate to the identity, signed by the parent, or a self-signed
+// CA certificate if parent is nil.
+func certificate(t *testing.T, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, email, issuer string) (*x509.Certificate, []byte) {
+	t.Helper()
+	now := time.Now().Add(-time.Hour)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: "sigstore"},
+		NotBefore:    now,
+		// Signing certificates expire quickly, and are verified at the time they were issued.
+		NotAfter: now.Add(10 * time.Minute),
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		template.NotAfter = now.Add(24 * time.Hour)
+		parent, parentKey = template, key
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+		template.EmailAddresses = []string{email}
+		value, err := asn1.Marshal(issuer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template.ExtraExtensions = []pkix.Extension{{Id: oidIssuerV2, Value: value}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestVerifyKeyless(t *testing.T) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ca, caPEM := certificate(t, caKey, nil, nil, "", "")
+	_, otherCAPEM := certificate(t, caKey, nil, nil, "", "")
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, untrustedCAPEM := certificate(t, otherKey, nil, nil, "", "")
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, leafPEM := certificate(t, key, ca, caKey, "release@knative.dev", "https://accounts.google.com")
+	sig := signECDSA(t, key, manifest)
+	identity := Identity{Issuer: "https://accounts.google.com", Subject: "release@knative.dev"}
+
+	tests := []struct {
+		name     string
+		identity Identity
+		roots    []byte
+		cert     []byte
+		data     []byte
+		wantErr  string
+	}{{
+		name:     "valid",
+		identity: identity,
+		roots:    caPEM,
+		cert:     leafPEM,
+		data:     manifest,
+	}, {
+		name:     "base64-encoded certificate",
+		identity: identity,
+		roots:    append(otherCAPEM, caPEM...),
+		cert:     []byte(base64.StdEncoding.EncodeToString(leafPEM)),
+		data:     manifest,
+	}, {
+		name:     "subject regexp",
+		identity: Identity{Issuer: identity.Issuer, SubjectRegExp: regexp.MustCompile(`@knative\.dev$`)},
+		roots:    caPEM,
+		cert:     leafPEM,
+		data:     manifest,
+	}, {
+		name:     "other subject",
+		identity: Identity{Issuer: identity.Issuer, Subject: "someone@example.com"},
+		roots:    caPEM,
+		cert:     leafPEM,
+		data:     manifest,
+		wantErr:  "does not match the expected identity",
+	}, {
+		name:     "other issuer",
+		identity: Identity{Issuer: "https://token.actions.githubusercontent.com", Subject: identity.Subject},
+		roots:    caPEM,
+		cert:     leafPEM,
+		data:     manifest,
+		wantErr:  "issued to an identity of",
+	}, {
+		name:     "untrusted root",
+		identity: identity,
+		roots:    untrustedCAPEM,
+		cert:     leafPEM,
+		data:     manifest,
+		wantErr:  "not trusted",
+	}, {
+		name:     "tampered data",
+		identity: identity,
+		roots:    caPEM,
+		cert:     leafPEM,
+		data:     []byte("tampered"),
+		wantErr:  "invalid signature",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyKeyless(test.identity, test.roots, test.cert, test.data, sig)
+			checkError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestCertificateIssuer(t *testing.T) {
+	cert := &x509.Certificate{Extensions: []pkix.Extension{{Id: oidIssuer, Value: []byte("https://github.com/login/oauth")}}}
+	if got, want := certificateIssuer(cert), "https://github.com/login/oauth"; got != want {
+		t.Errorf("certificateIssuer() = %q, want %q", got, want)
+	}
+	if got := certificateIssuer(&x509.Certificate{URIs: []*url.URL{{Scheme: "https", Host: "example.com"}}}); got != "" {
+		t.Errorf("certificateIssuer() = %q, want empty", got)
+	}
+}
+
+func checkError(t *testing.T, err error, want string) {
+	t.Helper()
+	if want == "" {
+		if err != nil {
+			t.Fatalf("got error %v, want none", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got error %v, want one containing %q", err, want)
+	}
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package e2e
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -52,7 +53,7 @@ func TestKnativeEventingPostDowngrade(t *testing.T) {
 		resources.SetKodataDir()
 		defer os.Unsetenv(common.KoEnvKey)
 
-		_, err := common.TargetManifest(&v1beta1.KnativeEventing{})
+		_, err := common.TargetManifest(context.Background(), &v1beta1.KnativeEventing{})
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -66,7 +67,7 @@ func TestKnativeEventingPostDowngrade(t *testing.T) {
 		}
 
 		// Based on the previous release version, get the deployment resources.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeEventing manifest: %v", err)
 		}
@@ -97,7 +98,7 @@ func TestKnativeServingPostDowngrade(t *testing.T) {
 		resources.SetKodataDir()
 		defer os.Unsetenv(common.KoEnvKey)
 
-		_, err := common.TargetManifest(&v1beta1.KnativeServing{})
+		_, err := common.TargetManifest(context.Background(), &v1beta1.KnativeServing{})
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -111,7 +112,7 @@ func TestKnativeServingPostDowngrade(t *testing.T) {
 		}
 
 		// Based on the previous release version, get the deployment resources.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeServing manifest: %v", err)
 		}
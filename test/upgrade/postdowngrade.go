@@ -17,6 +17,7 @@ limitations under the License.
 package upgrade
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -71,7 +72,7 @@ func eventingCRPostDowngrade(t *testing.T) {
 		resources.SetKodataDir()
 		defer os.Unsetenv(common.KoEnvKey)
 
-		_, err := common.TargetManifest(&v1beta1.KnativeEventing{})
+		_, err := common.TargetManifest(context.Background(), &v1beta1.KnativeEventing{})
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -85,7 +86,7 @@ func eventingCRPostDowngrade(t *testing.T) {
 		}
 
 		// Based on the previous release version, get the deployment resources.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeEventing manifest: %v", err)
 		}
@@ -115,7 +116,7 @@ func servingCRPostDowngrade(t *testing.T) {
 		resources.SetKodataDir()
 		defer os.Unsetenv(common.KoEnvKey)
 
-		_, err := common.TargetManifest(&v1beta1.KnativeServing{})
+		_, err := common.TargetManifest(context.Background(), &v1beta1.KnativeServing{})
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -129,7 +130,7 @@ func servingCRPostDowngrade(t *testing.T) {
 		}
 
 		// Based on the previous release version, get the deployment resources.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeServing manifest: %v", err)
 		}
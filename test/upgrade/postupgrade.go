@@ -17,6 +17,7 @@ limitations under the License.
 package upgrade
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -96,7 +97,7 @@ func servingCRPostUpgrade(t *testing.T) {
 				},
 			},
 		}
-		targetManifest, err := common.TargetManifest(ks)
+		targetManifest, err := common.TargetManifest(context.Background(), ks)
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -115,7 +116,7 @@ func servingCRPostUpgrade(t *testing.T) {
 		}
 		// Compare the previous manifest with the target manifest, we verify that all the obsolete resources
 		// do not exist any more.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeServing manifest: %v", err)
 		}
@@ -152,7 +153,7 @@ func eventingCRPostUpgrade(t *testing.T) {
 				},
 			},
 		}
-		targetManifest, err := common.TargetManifest(ke)
+		targetManifest, err := common.TargetManifest(context.Background(), ke)
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -170,7 +171,7 @@ func eventingCRPostUpgrade(t *testing.T) {
 		}
 		// Compare the previous manifest with the target manifest, we verify that all the obsolete resources
 		// do not exist any more.
-		preManifest, err := common.TargetManifest(instance)
+		preManifest, err := common.TargetManifest(context.Background(), instance)
 		if err != nil {
 			t.Fatalf("Failed to get KnativeEventing manifest: %v", err)
 		}
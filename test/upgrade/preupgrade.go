@@ -85,7 +85,7 @@ func servingCRPreUpgrade(t *testing.T) {
 				},
 			},
 		}
-		manifest, err := common.TargetManifest(kservingInstalled)
+		manifest, err := common.TargetManifest(context.Background(), kservingInstalled)
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}
@@ -127,7 +127,7 @@ func eventingCRPreUpgrade(t *testing.T) {
 				},
 			},
 		}
-		manifest, err := common.TargetManifest(keventingInstalled)
+		manifest, err := common.TargetManifest(context.Background(), keventingInstalled)
 		if err != nil {
 			t.Fatalf("Failed to get the manifest for Knative: %v", err)
 		}